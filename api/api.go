@@ -2,4 +2,5 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
+// Package api holds the gRPC APIs defined by onos-kpimon; the Go code is generated by build/bin/compile-protos.sh
 package api
@@ -0,0 +1,541 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: aspects/v1/aspects.proto
+
+package aspects
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CellKPIs is a topo aspect of the E2 cells written by onos-kpimon next to the E2Cell aspect;
+// unlike the E2Cell KPI reports, it keeps the typed values, the timestamps and the labels of the KPIs
+type CellKPIs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// kpis are the latest records of the cell, one per measurement name and label set
+	Kpis []*KPI `protobuf:"bytes,1,rep,name=kpis,proto3" json:"kpis,omitempty"`
+	// granularity_period is the measurement granularity period in milliseconds
+	GranularityPeriod uint64 `protobuf:"varint,2,opt,name=granularity_period,json=granularityPeriod,proto3" json:"granularity_period,omitempty"`
+	// updated_at is the time the aspect was written in nanoseconds since the epoch
+	UpdatedAt uint64 `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *CellKPIs) Reset() {
+	*x = CellKPIs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aspects_v1_aspects_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CellKPIs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellKPIs) ProtoMessage() {}
+
+func (x *CellKPIs) ProtoReflect() protoreflect.Message {
+	mi := &file_aspects_v1_aspects_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellKPIs.ProtoReflect.Descriptor instead.
+func (*CellKPIs) Descriptor() ([]byte, []int) {
+	return file_aspects_v1_aspects_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CellKPIs) GetKpis() []*KPI {
+	if x != nil {
+		return x.Kpis
+	}
+	return nil
+}
+
+func (x *CellKPIs) GetGranularityPeriod() uint64 {
+	if x != nil {
+		return x.GranularityPeriod
+	}
+	return 0
+}
+
+func (x *CellKPIs) GetUpdatedAt() uint64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// KPI is the latest record of a measurement
+type KPI struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// labels are the KPM measurement labels of the record, e.g. plmn_id, slice_id or five_qi
+	Labels map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// timestamp is the start of the granularity period of the record in nanoseconds since the epoch
+	Timestamp uint64 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Types that are assignable to Value:
+	//	*KPI_Integer
+	//	*KPI_Real
+	//	*KPI_NoValue
+	Value isKPI_Value `protobuf_oneof:"value"`
+}
+
+func (x *KPI) Reset() {
+	*x = KPI{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aspects_v1_aspects_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KPI) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KPI) ProtoMessage() {}
+
+func (x *KPI) ProtoReflect() protoreflect.Message {
+	mi := &file_aspects_v1_aspects_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KPI.ProtoReflect.Descriptor instead.
+func (*KPI) Descriptor() ([]byte, []int) {
+	return file_aspects_v1_aspects_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *KPI) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *KPI) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *KPI) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (m *KPI) GetValue() isKPI_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *KPI) GetInteger() int64 {
+	if x, ok := x.GetValue().(*KPI_Integer); ok {
+		return x.Integer
+	}
+	return 0
+}
+
+func (x *KPI) GetReal() float64 {
+	if x, ok := x.GetValue().(*KPI_Real); ok {
+		return x.Real
+	}
+	return 0
+}
+
+func (x *KPI) GetNoValue() int32 {
+	if x, ok := x.GetValue().(*KPI_NoValue); ok {
+		return x.NoValue
+	}
+	return 0
+}
+
+type isKPI_Value interface {
+	isKPI_Value()
+}
+
+type KPI_Integer struct {
+	Integer int64 `protobuf:"varint,4,opt,name=integer,proto3,oneof"`
+}
+
+type KPI_Real struct {
+	Real float64 `protobuf:"fixed64,5,opt,name=real,proto3,oneof"`
+}
+
+type KPI_NoValue struct {
+	// no_value is set when the E2 node reported no value for the period
+	NoValue int32 `protobuf:"varint,6,opt,name=no_value,json=noValue,proto3,oneof"`
+}
+
+func (*KPI_Integer) isKPI_Value() {}
+
+func (*KPI_Real) isKPI_Value() {}
+
+func (*KPI_NoValue) isKPI_Value() {}
+
+// E2NodeKPIs is a topo aspect of the E2 nodes written by onos-kpimon with the configured aggregates
+// of the KPIs of their cells
+type E2NodeKPIs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Aggregates []*AggregateKPI `protobuf:"bytes,1,rep,name=aggregates,proto3" json:"aggregates,omitempty"`
+	// updated_at is the time the aspect was written in nanoseconds since the epoch
+	UpdatedAt uint64 `protobuf:"varint,2,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *E2NodeKPIs) Reset() {
+	*x = E2NodeKPIs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aspects_v1_aspects_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *E2NodeKPIs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*E2NodeKPIs) ProtoMessage() {}
+
+func (x *E2NodeKPIs) ProtoReflect() protoreflect.Message {
+	mi := &file_aspects_v1_aspects_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use E2NodeKPIs.ProtoReflect.Descriptor instead.
+func (*E2NodeKPIs) Descriptor() ([]byte, []int) {
+	return file_aspects_v1_aspects_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *E2NodeKPIs) GetAggregates() []*AggregateKPI {
+	if x != nil {
+		return x.Aggregates
+	}
+	return nil
+}
+
+func (x *E2NodeKPIs) GetUpdatedAt() uint64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// AggregateKPI is an aggregate of the latest records of a measurement over the cells of an E2 node,
+// one per label set
+type AggregateKPI struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// function is the aggregate function, one of sum, avg, min or max
+	Function string            `protobuf:"bytes,2,opt,name=function,proto3" json:"function,omitempty"`
+	Labels   map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Value    float64           `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`
+	// cells is the number of cells whose records were aggregated
+	Cells uint32 `protobuf:"varint,5,opt,name=cells,proto3" json:"cells,omitempty"`
+	// timestamp is the latest timestamp of the aggregated records in nanoseconds since the epoch
+	Timestamp uint64 `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *AggregateKPI) Reset() {
+	*x = AggregateKPI{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aspects_v1_aspects_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregateKPI) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateKPI) ProtoMessage() {}
+
+func (x *AggregateKPI) ProtoReflect() protoreflect.Message {
+	mi := &file_aspects_v1_aspects_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateKPI.ProtoReflect.Descriptor instead.
+func (*AggregateKPI) Descriptor() ([]byte, []int) {
+	return file_aspects_v1_aspects_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AggregateKPI) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AggregateKPI) GetFunction() string {
+	if x != nil {
+		return x.Function
+	}
+	return ""
+}
+
+func (x *AggregateKPI) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *AggregateKPI) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *AggregateKPI) GetCells() uint32 {
+	if x != nil {
+		return x.Cells
+	}
+	return 0
+}
+
+func (x *AggregateKPI) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+var File_aspects_v1_aspects_proto protoreflect.FileDescriptor
+
+var file_aspects_v1_aspects_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x61, 0x73, 0x70, 0x65, 0x63, 0x74, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x6f, 0x6e, 0x6f, 0x73,
+	0x2e, 0x6b, 0x70, 0x69, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x70, 0x65, 0x63, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x22, 0x89, 0x01, 0x0a, 0x08, 0x43, 0x65, 0x6c, 0x6c, 0x4b, 0x50, 0x49, 0x73, 0x12,
+	0x2f, 0x0a, 0x04, 0x6b, 0x70, 0x69, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x6b, 0x70, 0x69, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x70, 0x65,
+	0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x50, 0x49, 0x52, 0x04, 0x6b, 0x70, 0x69, 0x73,
+	0x12, 0x2d, 0x0a, 0x12, 0x67, 0x72, 0x61, 0x6e, 0x75, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x5f,
+	0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x67, 0x72,
+	0x61, 0x6e, 0x75, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12,
+	0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x8b,
+	0x02, 0x0a, 0x03, 0x4b, 0x50, 0x49, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3f, 0x0a, 0x06, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6f, 0x6e, 0x6f,
+	0x73, 0x2e, 0x6b, 0x70, 0x69, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x70, 0x65, 0x63, 0x74, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x50, 0x49, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1a, 0x0a, 0x07, 0x69, 0x6e, 0x74,
+	0x65, 0x67, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x07, 0x69, 0x6e,
+	0x74, 0x65, 0x67, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x04, 0x72, 0x65, 0x61, 0x6c, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x04, 0x72, 0x65, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x08, 0x6e,
+	0x6f, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52,
+	0x07, 0x6e, 0x6f, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x71, 0x0a, 0x0a,
+	0x45, 0x32, 0x4e, 0x6f, 0x64, 0x65, 0x4b, 0x50, 0x49, 0x73, 0x12, 0x44, 0x0a, 0x0a, 0x61, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24,
+	0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x6b, 0x70, 0x69, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x4b, 0x50, 0x49, 0x52, 0x0a, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22,
+	0x8d, 0x02, 0x0a, 0x0c, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x4b, 0x50, 0x49,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x48, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x30, 0x2e, 0x6f, 0x6e, 0x6f, 0x73, 0x2e, 0x6b, 0x70, 0x69, 0x6d, 0x6f, 0x6e, 0x2e, 0x61,
+	0x73, 0x70, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x65, 0x4b, 0x50, 0x49, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42,
+	0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x6e,
+	0x6f, 0x73, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f, 0x6f, 0x6e, 0x6f, 0x73, 0x2d, 0x6b,
+	0x70, 0x69, 0x6d, 0x6f, 0x6e, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x73, 0x70, 0x65, 0x63, 0x74,
+	0x73, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x73, 0x70, 0x65, 0x63, 0x74, 0x73, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_aspects_v1_aspects_proto_rawDescOnce sync.Once
+	file_aspects_v1_aspects_proto_rawDescData = file_aspects_v1_aspects_proto_rawDesc
+)
+
+func file_aspects_v1_aspects_proto_rawDescGZIP() []byte {
+	file_aspects_v1_aspects_proto_rawDescOnce.Do(func() {
+		file_aspects_v1_aspects_proto_rawDescData = protoimpl.X.CompressGZIP(file_aspects_v1_aspects_proto_rawDescData)
+	})
+	return file_aspects_v1_aspects_proto_rawDescData
+}
+
+var file_aspects_v1_aspects_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_aspects_v1_aspects_proto_goTypes = []interface{}{
+	(*CellKPIs)(nil),     // 0: onos.kpimon.aspects.v1.CellKPIs
+	(*KPI)(nil),          // 1: onos.kpimon.aspects.v1.KPI
+	(*E2NodeKPIs)(nil),   // 2: onos.kpimon.aspects.v1.E2NodeKPIs
+	(*AggregateKPI)(nil), // 3: onos.kpimon.aspects.v1.AggregateKPI
+	nil,                  // 4: onos.kpimon.aspects.v1.KPI.LabelsEntry
+	nil,                  // 5: onos.kpimon.aspects.v1.AggregateKPI.LabelsEntry
+}
+var file_aspects_v1_aspects_proto_depIdxs = []int32{
+	1, // 0: onos.kpimon.aspects.v1.CellKPIs.kpis:type_name -> onos.kpimon.aspects.v1.KPI
+	4, // 1: onos.kpimon.aspects.v1.KPI.labels:type_name -> onos.kpimon.aspects.v1.KPI.LabelsEntry
+	3, // 2: onos.kpimon.aspects.v1.E2NodeKPIs.aggregates:type_name -> onos.kpimon.aspects.v1.AggregateKPI
+	5, // 3: onos.kpimon.aspects.v1.AggregateKPI.labels:type_name -> onos.kpimon.aspects.v1.AggregateKPI.LabelsEntry
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_aspects_v1_aspects_proto_init() }
+func file_aspects_v1_aspects_proto_init() {
+	if File_aspects_v1_aspects_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_aspects_v1_aspects_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellKPIs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aspects_v1_aspects_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KPI); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aspects_v1_aspects_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*E2NodeKPIs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aspects_v1_aspects_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregateKPI); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_aspects_v1_aspects_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*KPI_Integer)(nil),
+		(*KPI_Real)(nil),
+		(*KPI_NoValue)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aspects_v1_aspects_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aspects_v1_aspects_proto_goTypes,
+		DependencyIndexes: file_aspects_v1_aspects_proto_depIdxs,
+		MessageInfos:      file_aspects_v1_aspects_proto_msgTypes,
+	}.Build()
+	File_aspects_v1_aspects_proto = out.File
+	file_aspects_v1_aspects_proto_rawDesc = nil
+	file_aspects_v1_aspects_proto_goTypes = nil
+	file_aspects_v1_aspects_proto_depIdxs = nil
+}
@@ -0,0 +1,338 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.21.12
+// source: kpimon/v2/kpimon.proto
+
+package kpimon
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Kpimon_ListMeasurements_FullMethodName  = "/onos.kpimon.v2.Kpimon/ListMeasurements"
+	Kpimon_WatchMeasurements_FullMethodName = "/onos.kpimon.v2.Kpimon/WatchMeasurements"
+	Kpimon_GetHistory_FullMethodName        = "/onos.kpimon.v2.Kpimon/GetHistory"
+	Kpimon_QueryRange_FullMethodName        = "/onos.kpimon.v2.Kpimon/QueryRange"
+	Kpimon_ListSubscriptions_FullMethodName = "/onos.kpimon.v2.Kpimon/ListSubscriptions"
+	Kpimon_Resubscribe_FullMethodName       = "/onos.kpimon.v2.Kpimon/Resubscribe"
+)
+
+// KpimonClient is the client API for Kpimon service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type KpimonClient interface {
+	// ListMeasurements gets the latest measurements of the cells matching a filter, paginated
+	ListMeasurements(ctx context.Context, in *ListMeasurementsRequest, opts ...grpc.CallOption) (*ListMeasurementsResponse, error)
+	// WatchMeasurements streams the measurement updates of the cells matching a filter
+	WatchMeasurements(ctx context.Context, in *WatchMeasurementsRequest, opts ...grpc.CallOption) (Kpimon_WatchMeasurementsClient, error)
+	// GetHistory gets the stored records of the cells matching a filter as time ordered series
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// QueryRange aggregates the stored records of the cells matching a filter into time series aligned on a step
+	QueryRange(ctx context.Context, in *QueryRangeRequest, opts ...grpc.CallOption) (*QueryRangeResponse, error)
+	// ListSubscriptions lists the E2 subscriptions of this replica
+	ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error)
+	// Resubscribe closes the E2 subscriptions of an E2 node and subscribes to it again
+	Resubscribe(ctx context.Context, in *ResubscribeRequest, opts ...grpc.CallOption) (*ResubscribeResponse, error)
+}
+
+type kpimonClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKpimonClient(cc grpc.ClientConnInterface) KpimonClient {
+	return &kpimonClient{cc}
+}
+
+func (c *kpimonClient) ListMeasurements(ctx context.Context, in *ListMeasurementsRequest, opts ...grpc.CallOption) (*ListMeasurementsResponse, error) {
+	out := new(ListMeasurementsResponse)
+	err := c.cc.Invoke(ctx, Kpimon_ListMeasurements_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kpimonClient) WatchMeasurements(ctx context.Context, in *WatchMeasurementsRequest, opts ...grpc.CallOption) (Kpimon_WatchMeasurementsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Kpimon_ServiceDesc.Streams[0], Kpimon_WatchMeasurements_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &kpimonWatchMeasurementsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Kpimon_WatchMeasurementsClient interface {
+	Recv() (*WatchMeasurementsResponse, error)
+	grpc.ClientStream
+}
+
+type kpimonWatchMeasurementsClient struct {
+	grpc.ClientStream
+}
+
+func (x *kpimonWatchMeasurementsClient) Recv() (*WatchMeasurementsResponse, error) {
+	m := new(WatchMeasurementsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *kpimonClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, Kpimon_GetHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kpimonClient) QueryRange(ctx context.Context, in *QueryRangeRequest, opts ...grpc.CallOption) (*QueryRangeResponse, error) {
+	out := new(QueryRangeResponse)
+	err := c.cc.Invoke(ctx, Kpimon_QueryRange_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kpimonClient) ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error) {
+	out := new(ListSubscriptionsResponse)
+	err := c.cc.Invoke(ctx, Kpimon_ListSubscriptions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kpimonClient) Resubscribe(ctx context.Context, in *ResubscribeRequest, opts ...grpc.CallOption) (*ResubscribeResponse, error) {
+	out := new(ResubscribeResponse)
+	err := c.cc.Invoke(ctx, Kpimon_Resubscribe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KpimonServer is the server API for Kpimon service.
+// All implementations must embed UnimplementedKpimonServer
+// for forward compatibility
+type KpimonServer interface {
+	// ListMeasurements gets the latest measurements of the cells matching a filter, paginated
+	ListMeasurements(context.Context, *ListMeasurementsRequest) (*ListMeasurementsResponse, error)
+	// WatchMeasurements streams the measurement updates of the cells matching a filter
+	WatchMeasurements(*WatchMeasurementsRequest, Kpimon_WatchMeasurementsServer) error
+	// GetHistory gets the stored records of the cells matching a filter as time ordered series
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// QueryRange aggregates the stored records of the cells matching a filter into time series aligned on a step
+	QueryRange(context.Context, *QueryRangeRequest) (*QueryRangeResponse, error)
+	// ListSubscriptions lists the E2 subscriptions of this replica
+	ListSubscriptions(context.Context, *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error)
+	// Resubscribe closes the E2 subscriptions of an E2 node and subscribes to it again
+	Resubscribe(context.Context, *ResubscribeRequest) (*ResubscribeResponse, error)
+	mustEmbedUnimplementedKpimonServer()
+}
+
+// UnimplementedKpimonServer must be embedded to have forward compatible implementations.
+type UnimplementedKpimonServer struct {
+}
+
+func (UnimplementedKpimonServer) ListMeasurements(context.Context, *ListMeasurementsRequest) (*ListMeasurementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMeasurements not implemented")
+}
+func (UnimplementedKpimonServer) WatchMeasurements(*WatchMeasurementsRequest, Kpimon_WatchMeasurementsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMeasurements not implemented")
+}
+func (UnimplementedKpimonServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedKpimonServer) QueryRange(context.Context, *QueryRangeRequest) (*QueryRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryRange not implemented")
+}
+func (UnimplementedKpimonServer) ListSubscriptions(context.Context, *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubscriptions not implemented")
+}
+func (UnimplementedKpimonServer) Resubscribe(context.Context, *ResubscribeRequest) (*ResubscribeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resubscribe not implemented")
+}
+func (UnimplementedKpimonServer) mustEmbedUnimplementedKpimonServer() {}
+
+// UnsafeKpimonServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KpimonServer will
+// result in compilation errors.
+type UnsafeKpimonServer interface {
+	mustEmbedUnimplementedKpimonServer()
+}
+
+func RegisterKpimonServer(s grpc.ServiceRegistrar, srv KpimonServer) {
+	s.RegisterService(&Kpimon_ServiceDesc, srv)
+}
+
+func _Kpimon_ListMeasurements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMeasurementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KpimonServer).ListMeasurements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Kpimon_ListMeasurements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KpimonServer).ListMeasurements(ctx, req.(*ListMeasurementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kpimon_WatchMeasurements_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMeasurementsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KpimonServer).WatchMeasurements(m, &kpimonWatchMeasurementsServer{stream})
+}
+
+type Kpimon_WatchMeasurementsServer interface {
+	Send(*WatchMeasurementsResponse) error
+	grpc.ServerStream
+}
+
+type kpimonWatchMeasurementsServer struct {
+	grpc.ServerStream
+}
+
+func (x *kpimonWatchMeasurementsServer) Send(m *WatchMeasurementsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Kpimon_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KpimonServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Kpimon_GetHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KpimonServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kpimon_QueryRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KpimonServer).QueryRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Kpimon_QueryRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KpimonServer).QueryRange(ctx, req.(*QueryRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kpimon_ListSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubscriptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KpimonServer).ListSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Kpimon_ListSubscriptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KpimonServer).ListSubscriptions(ctx, req.(*ListSubscriptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kpimon_Resubscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResubscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KpimonServer).Resubscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Kpimon_Resubscribe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KpimonServer).Resubscribe(ctx, req.(*ResubscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Kpimon_ServiceDesc is the grpc.ServiceDesc for Kpimon service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Kpimon_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "onos.kpimon.v2.Kpimon",
+	HandlerType: (*KpimonServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListMeasurements",
+			Handler:    _Kpimon_ListMeasurements_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _Kpimon_GetHistory_Handler,
+		},
+		{
+			MethodName: "QueryRange",
+			Handler:    _Kpimon_QueryRange_Handler,
+		},
+		{
+			MethodName: "ListSubscriptions",
+			Handler:    _Kpimon_ListSubscriptions_Handler,
+		},
+		{
+			MethodName: "Resubscribe",
+			Handler:    _Kpimon_Resubscribe_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchMeasurements",
+			Handler:       _Kpimon_WatchMeasurements_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "kpimon/v2/kpimon.proto",
+}
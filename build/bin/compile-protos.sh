@@ -0,0 +1,15 @@
+#!/bin/sh
+# SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+#
+# SPDX-License-Identifier: Apache-2.0
+
+set -e
+
+cd "$(dirname "$0")/../../api"
+
+find . -name '*.proto' | sed 's|^\./||' | while read -r proto; do
+    protoc -I=. \
+        --go_out=. --go_opt=paths=source_relative \
+        --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+        "$proto"
+done
@@ -6,6 +6,7 @@ package main
 
 import (
 	"flag"
+	"time"
 
 	"github.com/onosproject/onos-kpimon/pkg/manager"
 	"github.com/onosproject/onos-lib-go/pkg/certs"
@@ -24,6 +25,15 @@ func main() {
 	grpcPort := flag.Int("grpcPort", 5150, "grpc Port number")
 	smName := flag.String("smName", "oran-e2sm-kpm", "Service model name in RAN function description")
 	smVersion := flag.String("smVersion", "v2", "Service model version in RAN function description")
+	leaseFile := flag.String("leaseFile", "", "lease file shared by the replicas for the leader election; empty disables the active/standby mode")
+	leaseDuration := flag.Duration("leaseDuration", 10*time.Second, "lease duration of the leader election and the lease directory replica set")
+	candidateID := flag.String("candidateID", "", "replica identifier in the leader election (default host name)")
+	advertiseAddress := flag.String("advertiseAddress", "", "northbound address the standbys replicate from (default host name and grpc port)")
+	shardID := flag.String("shardID", "", "replica identifier in the replica set sharing the E2 nodes (default host name)")
+	shardLeaseDir := flag.String("shardLeaseDir", "", "lease directory shared by the replica set; empty uses the replica set of the configuration")
+	topoWriteRate := flag.Float64("topoWriteRate", 10, "maximum number of cell and E2 node aspect writes per second to topo")
+	snapshotDir := flag.String("snapshotDir", "/var/lib/onos-kpimon/snapshots", "directory of the state snapshot files")
+	metricsPort := flag.Int("metricsPort", 9090, "port of the Prometheus metrics HTTP endpoint; 0 disables it")
 
 	ready := make(chan bool)
 
@@ -45,9 +55,22 @@ func main() {
 		ConfigPath:  *configPath,
 		SMName:      *smName,
 		SMVersion:   *smVersion,
+		SnapshotDir: *snapshotDir,
+
+		LeaseFile:        *leaseFile,
+		LeaseDuration:    *leaseDuration,
+		CandidateID:      *candidateID,
+		AdvertiseAddress: *advertiseAddress,
+		ShardID:          *shardID,
+		ShardLeaseDir:    *shardLeaseDir,
+		TopoWriteRate:    *topoWriteRate,
+		MetricsPort:      *metricsPort,
 	}
 
-	mgr := manager.NewManager(cfg)
+	mgr, err := manager.NewManager(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
 	mgr.Run()
 	<-ready
 }
@@ -48,6 +48,9 @@ type Broker interface {
 
 	// ChannelIDs get all of subscription channel IDs
 	ChannelIDs() []e2api.ChannelID
+
+	// Streams get all of subscription streams
+	Streams() []StreamIO
 }
 
 type streamBroker struct {
@@ -67,6 +70,16 @@ func (b *streamBroker) ChannelIDs() []e2api.ChannelID {
 	return channelIDs
 }
 
+func (b *streamBroker) Streams() []StreamIO {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	streams := make([]StreamIO, 0, len(b.streams))
+	for _, stream := range b.streams {
+		streams = append(streams, stream)
+	}
+	return streams
+}
+
 func (b *streamBroker) OpenReader(_ context.Context, node e2client.Node, subName string, channelID e2api.ChannelID, subSpec e2api.SubscriptionSpec) (StreamReader, error) {
 	b.mu.RLock()
 	stream, ok := b.subs[channelID]
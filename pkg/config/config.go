@@ -6,6 +6,7 @@ package config
 
 import (
 	"context"
+	"strings"
 
 	configurable "github.com/onosproject/onos-ric-sdk-go/pkg/config/registry"
 
@@ -23,9 +24,65 @@ type Config interface {
 	GetReportPeriodWithPath(path string) (uint64, error)
 	GetReportPeriod() (uint64, error)
 	GetGranularityPeriod() (uint64, error)
+	GetStaleReportPeriods() uint64
+	GetStalePolicy() string
+	GetMaxRecords() uint64
+	GetMaxBytes() uint64
+	GetPriorityMeasurements() []string
+	GetReplicaSet() string
+	GetNodeAggregates() string
+	GetReplayBufferSize() uint64
+	GetWatcherQueueSize() uint64
+	GetSlowConsumerPolicy() string
+	GetMetricsMaxSeries() uint64
+	GetExportBatchSize() uint64
+	GetExportFlushInterval() uint64
+	GetExportBufferSize() uint64
+	GetExportMaxRetries() uint64
+	GetKafkaBrokers() []string
+	GetKafkaTopic() string
+	GetKafkaEncoding() string
+	GetInfluxURL() string
+	GetInfluxToken() string
+	GetFileDir() string
+	GetFileFormat() string
+	GetFileMaxSize() uint64
+	GetFileRotationInterval() uint64
+	GetFileCompression() string
+	GetFileMaxFiles() uint64
+	GetHistoryRetention() uint64
+	GetHistoryMaxSamples() uint64
+	GetWebhookURLs() []string
+	GetWebhookSecret() string
+	GetWebhookEvents() []string
+	GetWebhookThresholds() string
+	GetWebhookRateLimit() uint64
+	GetWebhookMaxRetries() uint64
 	Watch(context.Context, chan event.Event) error
 }
 
+const (
+	defaultStaleReportPeriods   = 3
+	defaultStalePolicy          = "evict"
+	defaultHistoryRetention     = 3600
+	defaultReplayBufferSize     = 1000
+	defaultWatcherQueueSize     = 1000
+	defaultSlowConsumerPolicy   = "drop_oldest"
+	defaultMetricsMaxSeries     = 10000
+	defaultExportBatchSize      = 100
+	defaultExportFlushInterval  = 1000
+	defaultExportBufferSize     = 10000
+	defaultExportMaxRetries     = 3
+	defaultKafkaTopic           = "onos-kpimon-measurements"
+	defaultKafkaEncoding        = "json"
+	defaultFileFormat           = "json"
+	defaultFileMaxSize          = 100
+	defaultFileRotationInterval = 3600
+	defaultFileCompression      = "gzip"
+	defaultWebhookRateLimit     = 60
+	defaultWebhookMaxRetries    = 3
+)
+
 // NewConfig initialize the xApp config
 func NewConfig(configPath string) (*AppConfig, error) {
 	appConfig, err := configurable.RegisterConfigurable(configPath, &configurable.RegisterRequest{})
@@ -88,4 +145,214 @@ func (c *AppConfig) GetGranularityPeriod() (uint64, error) {
 	return val, nil
 }
 
+// GetStaleReportPeriods gets the number of report periods without update after which
+// a cell measurement is stale; zero disables the expiry
+func (c *AppConfig) GetStaleReportPeriods() uint64 {
+	return c.getUint64(utils.StaleReportPeriodsConfigPath, defaultStaleReportPeriods)
+}
+
+// GetStalePolicy gets the policy applied to stale cell measurements
+func (c *AppConfig) GetStalePolicy() string {
+	return c.getString(utils.StalePolicyConfigPath, defaultStalePolicy)
+}
+
+// GetMaxRecords gets the maximum number of stored measurement records; zero means unbounded
+func (c *AppConfig) GetMaxRecords() uint64 {
+	return c.getUint64(utils.MaxRecordsConfigPath, 0)
+}
+
+// GetMaxBytes gets the maximum approximate memory used by the stored measurements; zero means unbounded
+func (c *AppConfig) GetMaxBytes() uint64 {
+	return c.getUint64(utils.MaxBytesConfigPath, 0)
+}
+
+// GetPriorityMeasurements gets the measurement names evicted last, highest priority first
+func (c *AppConfig) GetPriorityMeasurements() []string {
+	return splitList(c.getString(utils.PriorityMeasurementsConfigPath, ""))
+}
+
+// GetReplicaSet gets the comma separated "id=address" members of the replica set; empty disables sharding
+func (c *AppConfig) GetReplicaSet() string {
+	return c.getString(utils.ReplicaSetConfigPath, "")
+}
+
+// GetNodeAggregates gets the comma separated "name:function" E2 node aggregates, e.g. "RRC.ConnEstabSucc.Sum:sum"
+func (c *AppConfig) GetNodeAggregates() string {
+	return c.getString(utils.NodeAggregatesConfigPath, "")
+}
+
+// GetReplayBufferSize gets the number of latest measurement events kept to resume the watches
+func (c *AppConfig) GetReplayBufferSize() uint64 {
+	return c.getUint64(utils.ReplayBufferSizeConfigPath, defaultReplayBufferSize)
+}
+
+// GetWatcherQueueSize gets the maximum number of measurement events pending for each watcher
+func (c *AppConfig) GetWatcherQueueSize() uint64 {
+	return c.getUint64(utils.WatcherQueueSizeConfigPath, defaultWatcherQueueSize)
+}
+
+// GetSlowConsumerPolicy gets the policy applied when the queue of a measurement watcher is full
+func (c *AppConfig) GetSlowConsumerPolicy() string {
+	return c.getString(utils.SlowConsumerPolicyConfigPath, defaultSlowConsumerPolicy)
+}
+
+// GetMetricsMaxSeries gets the maximum number of measurement series exposed on the metrics endpoint
+func (c *AppConfig) GetMetricsMaxSeries() uint64 {
+	return c.getUint64(utils.MetricsMaxSeriesConfigPath, defaultMetricsMaxSeries)
+}
+
+// GetExportBatchSize gets the maximum number of measurement events written at once to an export sink
+func (c *AppConfig) GetExportBatchSize() uint64 {
+	return c.getUint64(utils.ExportBatchSizeConfigPath, defaultExportBatchSize)
+}
+
+// GetExportFlushInterval gets the maximum number of milliseconds a measurement event waits for its export batch to fill up
+func (c *AppConfig) GetExportFlushInterval() uint64 {
+	return c.getUint64(utils.ExportFlushIntervalConfigPath, defaultExportFlushInterval)
+}
+
+// GetExportBufferSize gets the maximum number of measurement events waiting to be written to an export sink
+func (c *AppConfig) GetExportBufferSize() uint64 {
+	return c.getUint64(utils.ExportBufferSizeConfigPath, defaultExportBufferSize)
+}
+
+// GetExportMaxRetries gets the number of times a failed write to an export sink is retried
+func (c *AppConfig) GetExportMaxRetries() uint64 {
+	return c.getUint64(utils.ExportMaxRetriesConfigPath, defaultExportMaxRetries)
+}
+
+// GetKafkaBrokers gets the addresses of the Kafka brokers the measurement updates are published to
+func (c *AppConfig) GetKafkaBrokers() []string {
+	return splitList(c.getString(utils.KafkaBrokersConfigPath, ""))
+}
+
+// GetKafkaTopic gets the single Kafka topic all of the measurement updates are published to
+func (c *AppConfig) GetKafkaTopic() string {
+	return c.getString(utils.KafkaTopicConfigPath, defaultKafkaTopic)
+}
+
+// GetKafkaEncoding gets the encoding of the Kafka message values
+func (c *AppConfig) GetKafkaEncoding() string {
+	return c.getString(utils.KafkaEncodingConfigPath, defaultKafkaEncoding)
+}
+
+// GetInfluxURL gets the InfluxDB write URL the measurement records are written to; empty disables the InfluxDB export
+func (c *AppConfig) GetInfluxURL() string {
+	return c.getString(utils.InfluxURLConfigPath, "")
+}
+
+// GetInfluxToken gets the InfluxDB API token
+func (c *AppConfig) GetInfluxToken() string {
+	return c.getString(utils.InfluxTokenConfigPath, "")
+}
+
+// GetFileDir gets the directory the measurement records are recorded to; empty disables the recording
+func (c *AppConfig) GetFileDir() string {
+	return c.getString(utils.FileDirConfigPath, "")
+}
+
+// GetFileFormat gets the format of the recorded files
+func (c *AppConfig) GetFileFormat() string {
+	return c.getString(utils.FileFormatConfigPath, defaultFileFormat)
+}
+
+// GetFileMaxSize gets the size in megabytes after which a recorded file is rotated
+func (c *AppConfig) GetFileMaxSize() uint64 {
+	return c.getUint64(utils.FileMaxSizeConfigPath, defaultFileMaxSize)
+}
+
+// GetFileRotationInterval gets the age in seconds after which a recorded file is rotated
+func (c *AppConfig) GetFileRotationInterval() uint64 {
+	return c.getUint64(utils.FileRotationIntervalConfigPath, defaultFileRotationInterval)
+}
+
+// GetFileCompression gets the compression of the rotated files
+func (c *AppConfig) GetFileCompression() string {
+	return c.getString(utils.FileCompressionConfigPath, defaultFileCompression)
+}
+
+// GetFileMaxFiles gets the number of rotated files kept; zero keeps all of them
+func (c *AppConfig) GetFileMaxFiles() uint64 {
+	return c.getUint64(utils.FileMaxFilesConfigPath, 0)
+}
+
+// GetWebhookURLs gets the URLs the KPI events are posted to; empty disables the webhooks
+func (c *AppConfig) GetWebhookURLs() []string {
+	return splitList(c.getString(utils.WebhookURLsConfigPath, ""))
+}
+
+// GetWebhookSecret gets the key of the HMAC-SHA256 signature of the webhook payloads; empty disables the signature
+func (c *AppConfig) GetWebhookSecret() string {
+	return c.getString(utils.WebhookSecretConfigPath, "")
+}
+
+// GetWebhookEvents gets the types of the KPI events posted to the webhooks; empty posts all of them
+func (c *AppConfig) GetWebhookEvents() []string {
+	return splitList(c.getString(utils.WebhookEventsConfigPath, ""))
+}
+
+// GetWebhookThresholds gets the comma separated measurement thresholds whose crossings are posted to the webhooks
+func (c *AppConfig) GetWebhookThresholds() string {
+	return c.getString(utils.WebhookThresholdsConfigPath, "")
+}
+
+// GetWebhookRateLimit gets the maximum number of requests per minute to each webhook
+func (c *AppConfig) GetWebhookRateLimit() uint64 {
+	return c.getUint64(utils.WebhookRateLimitConfigPath, defaultWebhookRateLimit)
+}
+
+// GetWebhookMaxRetries gets the number of times a failed webhook request is retried
+func (c *AppConfig) GetWebhookMaxRetries() uint64 {
+	return c.getUint64(utils.WebhookMaxRetriesConfigPath, defaultWebhookMaxRetries)
+}
+
+// GetHistoryRetention gets the number of seconds the measurement history is kept for
+func (c *AppConfig) GetHistoryRetention() uint64 {
+	return c.getUint64(utils.HistoryRetentionConfigPath, defaultHistoryRetention)
+}
+
+// GetHistoryMaxSamples gets the maximum number of samples kept per measurement series
+func (c *AppConfig) GetHistoryMaxSamples() uint64 {
+	return c.getUint64(utils.HistoryMaxSamplesConfigPath, 0)
+}
+
+// splitList splits a comma separated config value, skipping the empty items
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getUint64 gets an optional uint64 config value
+func (c *AppConfig) getUint64(path string, defaultValue uint64) uint64 {
+	entry, err := c.appConfig.Get(path)
+	if err != nil {
+		return defaultValue
+	}
+	val, err := configutils.ToUint64(entry.Value)
+	if err != nil {
+		log.Warnf("Invalid value for %s, using default %d: %v", path, defaultValue, err)
+		return defaultValue
+	}
+	return val
+}
+
+// getString gets an optional string config value
+func (c *AppConfig) getString(path string, defaultValue string) string {
+	entry, err := c.appConfig.Get(path)
+	if err != nil {
+		return defaultValue
+	}
+	val, err := configutils.ToString(entry.Value)
+	if err != nil {
+		log.Warnf("Invalid value for %s, using default %s: %v", path, defaultValue, err)
+		return defaultValue
+	}
+	return val
+}
+
 var _ Config = &AppConfig{}
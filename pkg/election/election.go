@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package election
+
+import (
+	"context"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+)
+
+var log = logging.GetLogger()
+
+// CandidateID is the identifier of an election candidate
+type CandidateID string
+
+// Candidate is a kpimon replica taking part in the leader election
+type Candidate struct {
+	ID CandidateID
+	// Address is the northbound address of the candidate, used by the standbys to replicate the leader state
+	Address string
+}
+
+// Term is a leadership term
+type Term struct {
+	// Term is increased every time the leadership changes hands
+	Term uint64
+	// Leader is the current leader; it is empty if there is no leader
+	Leader Candidate
+	// Expiry is the time until which the leader holds the lease unless it renews it
+	Expiry time.Time
+}
+
+// HasLeader returns true if the term has a leader
+func (t Term) HasLeader() bool {
+	return t.Leader.ID != ""
+}
+
+// Election is a leader election among the kpimon replicas
+type Election interface {
+	// Campaign runs for leadership until the context is done, at which point a held lease is released
+	Campaign(ctx context.Context) error
+
+	// Term gets the current term as last seen by the candidate
+	Term() Term
+
+	// IsLeader returns true if the candidate currently holds the leadership
+	IsLeader() bool
+
+	// Watch watches the term changes; the current term is sent first
+	Watch(ctx context.Context, ch chan<- Term) error
+}
@@ -0,0 +1,278 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package election
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onosproject/onos-kpimon/pkg/store/watcher"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// lease is the content of a lease file
+type lease struct {
+	Term      uint64    `json:"term"`
+	HolderID  string    `json:"holderId"`
+	Address   string    `json:"address"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// NewLeaseFileElection creates a leader election whose lease is a file shared by the candidates
+// The candidates must share a file system and a clock, which makes it suited for local
+// deployments and tests rather than for replicas running on different hosts.
+func NewLeaseFileElection(path string, candidate Candidate, opts ...Option) (Election, error) {
+	if path == "" {
+		return nil, errors.NewInvalid("lease file path is required")
+	}
+	if candidate.ID == "" {
+		return nil, errors.NewInvalid("candidate ID is required")
+	}
+	return &leaseFileElection{
+		path:      path,
+		candidate: candidate,
+		options:   newOptions(opts...),
+		watchers:  watcher.NewWatchers[Term](),
+	}, nil
+}
+
+type leaseFileElection struct {
+	path      string
+	candidate Candidate
+	options   Options
+	watchers  *watcher.Watchers[Term]
+	mu        sync.RWMutex
+	term      Term
+	// leaderUntil is the time until which the candidate considers itself the leader
+	leaderUntil time.Time
+}
+
+func (e *leaseFileElection) Campaign(ctx context.Context) error {
+	err := os.MkdirAll(filepath.Dir(e.path), 0755)
+	if err != nil {
+		return err
+	}
+	e.tryAcquire()
+	go func() {
+		ticker := time.NewTicker(e.options.RenewInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				e.tryAcquire()
+			case <-ctx.Done():
+				e.release()
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (e *leaseFileElection) Term() Term {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.term
+}
+
+func (e *leaseFileElection) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.isLeader(time.Now())
+}
+
+func (e *leaseFileElection) Watch(ctx context.Context, ch chan<- Term) error {
+	id := uuid.New()
+	e.mu.RLock()
+	err := e.watchers.AddWatcher(id, ch, e.term)
+	e.mu.RUnlock()
+	if err != nil {
+		close(ch)
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		err := e.watchers.RemoveWatcher(id)
+		if err != nil && !errors.IsNotFound(err) {
+			log.Error(err)
+		}
+	}()
+	return nil
+}
+
+// isLeader must be called with the lock held
+// The leader steps down one renewal interval before its lease expires, so that it has stopped acting
+// as the leader before another candidate can acquire the lease.
+func (e *leaseFileElection) isLeader(now time.Time) bool {
+	return e.term.Leader.ID == e.candidate.ID && now.Before(e.leaderUntil)
+}
+
+// tryAcquire renews the lease if the candidate holds it, acquires it if it has expired
+// and otherwise refreshes the term from the lease file
+func (e *leaseFileElection) tryAcquire() {
+	now := time.Now()
+	var current lease
+	var acquired bool
+	err := e.withLock(now, func() error {
+		var err error
+		current, err = e.read()
+		if err != nil {
+			return err
+		}
+		if current.HolderID != string(e.candidate.ID) && current.HolderID != "" && now.Before(current.ExpiresAt) {
+			return nil
+		}
+		if current.HolderID != string(e.candidate.ID) {
+			current.Term++
+		}
+		current.HolderID = string(e.candidate.ID)
+		current.Address = e.candidate.Address
+		current.ExpiresAt = now.Add(e.options.LeaseDuration)
+		if err := e.write(current); err != nil {
+			return err
+		}
+		acquired = true
+		return nil
+	})
+	if errors.IsConflict(err) {
+		log.Debug(err)
+		e.update(now, nil, false)
+		return
+	} else if err != nil {
+		log.Warnf("Failed to acquire lease %s: %v", e.path, err)
+		e.update(now, nil, false)
+		return
+	}
+	e.update(now, &current, acquired)
+}
+
+// release gives up the lease if the candidate holds it, so that a standby can take over right away
+func (e *leaseFileElection) release() {
+	now := time.Now()
+	err := e.withLock(now, func() error {
+		current, err := e.read()
+		if err != nil {
+			return err
+		}
+		if current.HolderID != string(e.candidate.ID) {
+			return nil
+		}
+		current.ExpiresAt = now
+		return e.write(current)
+	})
+	if err != nil {
+		log.Warnf("Failed to release lease %s: %v", e.path, err)
+	}
+	e.mu.Lock()
+	e.leaderUntil = time.Time{}
+	e.mu.Unlock()
+}
+
+// update updates the term with the lease read from the file, if any, and notifies the watchers of changes
+func (e *leaseFileElection) update(now time.Time, current *lease, acquired bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	wasLeader := e.isLeader(now)
+	term := e.term
+	if acquired {
+		e.leaderUntil = current.ExpiresAt.Add(-e.options.RenewInterval)
+	}
+	if current != nil {
+		term = Term{
+			Term:   current.Term,
+			Expiry: current.ExpiresAt,
+		}
+		if current.HolderID != "" && now.Before(current.ExpiresAt) {
+			term.Leader = Candidate{
+				ID:      CandidateID(current.HolderID),
+				Address: current.Address,
+			}
+		}
+	} else if !wasLeader {
+		return
+	}
+
+	isLeader := term.Leader.ID == e.candidate.ID && now.Before(e.leaderUntil)
+	if isLeader != wasLeader {
+		if isLeader {
+			log.Infof("Candidate %s is the leader for term %d", e.candidate.ID, term.Term)
+		} else {
+			log.Infof("Candidate %s is no longer the leader", e.candidate.ID)
+		}
+	}
+	if !isLeader && term.Leader.ID == e.candidate.ID {
+		// the lease could not be renewed in time; the candidate no longer acts as the leader
+		term.Leader = Candidate{}
+	}
+	if term.Term == e.term.Term && term.Leader == e.term.Leader {
+		e.term = term
+		return
+	}
+	e.term = term
+	e.watchers.Send(term)
+}
+
+// withLock calls a function while holding the lease lock file
+// A lock file older than the lease duration was left behind by a crashed candidate and is removed.
+func (e *leaseFileElection) withLock(now time.Time, f func() error) error {
+	lockPath := e.path + ".lock"
+	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if os.IsExist(err) {
+		info, statErr := os.Stat(lockPath)
+		if statErr == nil && now.Sub(info.ModTime()) > e.options.LeaseDuration {
+			log.Warnf("Removing stale lock file %s", lockPath)
+			_ = os.Remove(lockPath)
+		}
+		return errors.NewConflict("lease %s is locked", e.path)
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		file.Close()
+		_ = os.Remove(lockPath)
+	}()
+	return f()
+}
+
+func (e *leaseFileElection) read() (lease, error) {
+	current := lease{}
+	data, err := os.ReadFile(e.path)
+	if os.IsNotExist(err) {
+		return current, nil
+	}
+	if err != nil {
+		return current, err
+	}
+	if len(data) == 0 {
+		return current, nil
+	}
+	err = json.Unmarshal(data, &current)
+	if err != nil {
+		return current, errors.NewInvalid("invalid lease file %s: %v", e.path, err)
+	}
+	return current, nil
+}
+
+// write writes the lease to a temporary file that replaces the lease file, so that a reader never sees a partial lease
+func (e *leaseFileElection) write(current lease) error {
+	data, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	tmpPath := e.path + ".tmp"
+	err = os.WriteFile(tmpPath, data, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, e.path)
+}
+
+var _ Election = &leaseFileElection{}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package election
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testLeaseDuration = 300 * time.Millisecond
+	testTimeout       = 3 * time.Second
+)
+
+func newTestElection(t *testing.T, path string, id CandidateID) Election {
+	e, err := NewLeaseFileElection(path, Candidate{ID: id, Address: string(id) + ":5150"},
+		WithLeaseDuration(testLeaseDuration))
+	assert.NoError(t, err)
+	return e
+}
+
+// waitLeader waits for a term led by the given candidate
+func waitLeader(t *testing.T, ch <-chan Term, id CandidateID) Term {
+	timeout := time.After(testTimeout)
+	for {
+		select {
+		case term := <-ch:
+			if term.Leader.ID == id {
+				return term
+			}
+		case <-timeout:
+			t.Fatalf("candidate %s did not become the leader", id)
+			return Term{}
+		}
+	}
+}
+
+// stop stops the campaign of a leader and waits for its lease to be released
+func stop(t *testing.T, cancel context.CancelFunc, e Election) {
+	cancel()
+	assert.Eventually(t, func() bool {
+		return !e.IsLeader()
+	}, testTimeout, time.Millisecond)
+}
+
+func TestNewLeaseFileElection(t *testing.T) {
+	_, err := NewLeaseFileElection("", Candidate{ID: "a"})
+	assert.True(t, errors.IsInvalid(err))
+	_, err = NewLeaseFileElection(filepath.Join(t.TempDir(), "lease"), Candidate{})
+	assert.True(t, errors.IsInvalid(err))
+}
+
+func TestFailover(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lease")
+	ctxA, cancelA := context.WithCancel(context.Background())
+	defer cancelA()
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+
+	a := newTestElection(t, path, "a")
+	chA := make(chan Term)
+	assert.NoError(t, a.Watch(ctxA, chA))
+	assert.NoError(t, a.Campaign(ctxA))
+	termA := waitLeader(t, chA, "a")
+	assert.True(t, a.IsLeader())
+
+	// the second candidate sees the first one as the leader
+	b := newTestElection(t, path, "b")
+	chB := make(chan Term)
+	assert.NoError(t, b.Watch(ctxB, chB))
+	assert.NoError(t, b.Campaign(ctxB))
+	term := waitLeader(t, chB, "a")
+	assert.Equal(t, termA.Term, term.Term)
+	assert.Equal(t, "a:5150", term.Leader.Address)
+	assert.False(t, b.IsLeader())
+
+	// the lease is released once the leader stops campaigning and the standby takes over in a new term
+	stop(t, cancelA, a)
+	termB := waitLeader(t, chB, "b")
+	assert.Greater(t, termB.Term, termA.Term)
+	assert.True(t, b.IsLeader())
+	stop(t, cancelB, b)
+}
+
+func TestRenew(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lease")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := newTestElection(t, path, "a")
+	ch := make(chan Term)
+	assert.NoError(t, a.Watch(ctx, ch))
+	assert.NoError(t, a.Campaign(ctx))
+	term := waitLeader(t, ch, "a")
+
+	// the leader keeps its term for longer than the lease duration
+	time.Sleep(3 * testLeaseDuration)
+	assert.True(t, a.IsLeader())
+	assert.Equal(t, term.Term, a.Term().Term)
+	stop(t, cancel, a)
+}
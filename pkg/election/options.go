@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package election
+
+import "time"
+
+const (
+	defaultLeaseDuration = 10 * time.Second
+)
+
+// Options election options
+type Options struct {
+	// LeaseDuration is how long a lease is held without being renewed
+	LeaseDuration time.Duration
+	// RenewInterval is the interval at which the candidates renew or try to acquire the lease;
+	// it defaults to a third of the lease duration
+	RenewInterval time.Duration
+}
+
+// Option election option interface
+type Option interface {
+	apply(*Options)
+}
+
+type funcOption struct {
+	f func(*Options)
+}
+
+func (f funcOption) apply(options *Options) {
+	f.f(options)
+}
+
+func newOption(f func(*Options)) Option {
+	return funcOption{
+		f: f,
+	}
+}
+
+// WithLeaseDuration sets the lease duration
+func WithLeaseDuration(duration time.Duration) Option {
+	return newOption(func(options *Options) {
+		options.LeaseDuration = duration
+	})
+}
+
+// WithRenewInterval sets the lease renewal interval
+func WithRenewInterval(interval time.Duration) Option {
+	return newOption(func(options *Options) {
+		options.RenewInterval = interval
+	})
+}
+
+func newOptions(opts ...Option) Options {
+	options := Options{}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	if options.LeaseDuration <= 0 {
+		options.LeaseDuration = defaultLeaseDuration
+	}
+	if options.RenewInterval <= 0 || options.RenewInterval >= options.LeaseDuration {
+		options.RenewInterval = options.LeaseDuration / 3
+	}
+	return options
+}
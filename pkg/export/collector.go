@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	healthyDesc = prometheus.NewDesc("kpimon_export_sink_healthy",
+		"Whether the writes of the export sink succeed", []string{"sink"}, nil)
+	bufferedDesc = prometheus.NewDesc("kpimon_export_sink_buffered_events",
+		"Number of events waiting to be written to the export sink", []string{"sink"}, nil)
+	deliveredDesc = prometheus.NewDesc("kpimon_export_sink_delivered_events_total",
+		"Number of events written to the export sink", []string{"sink"}, nil)
+	droppedDesc = prometheus.NewDesc("kpimon_export_sink_dropped_events_total",
+		"Number of events dropped because the buffer of the export sink was full", []string{"sink"}, nil)
+	failedDesc = prometheus.NewDesc("kpimon_export_sink_failed_events_total",
+		"Number of events given up on after the retries of the export sink", []string{"sink"}, nil)
+	retriesDesc = prometheus.NewDesc("kpimon_export_sink_retries_total",
+		"Number of retried writes to the export sink", []string{"sink"}, nil)
+)
+
+// Describe describes the delivery status metrics of the sinks
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- healthyDesc
+	ch <- bufferedDesc
+	ch <- deliveredDesc
+	ch <- droppedDesc
+	ch <- failedDesc
+	ch <- retriesDesc
+}
+
+// Collect collects the delivery status of the sinks
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	for _, status := range e.Status() {
+		healthy := 0.0
+		if status.Healthy {
+			healthy = 1
+		}
+		ch <- prometheus.MustNewConstMetric(healthyDesc, prometheus.GaugeValue, healthy, status.Name)
+		ch <- prometheus.MustNewConstMetric(bufferedDesc, prometheus.GaugeValue, float64(status.Buffered), status.Name)
+		ch <- prometheus.MustNewConstMetric(deliveredDesc, prometheus.CounterValue, float64(status.Delivered), status.Name)
+		ch <- prometheus.MustNewConstMetric(droppedDesc, prometheus.CounterValue, float64(status.Dropped), status.Name)
+		ch <- prometheus.MustNewConstMetric(failedDesc, prometheus.CounterValue, float64(status.Failed), status.Name)
+		ch <- prometheus.MustNewConstMetric(retriesDesc, prometheus.CounterValue, float64(status.Retries), status.Name)
+	}
+}
+
+var _ prometheus.Collector = &Exporter{}
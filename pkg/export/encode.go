@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	kpimonv2api "github.com/onosproject/onos-kpimon/api/kpimon/v2"
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+)
+
+// NewCellID gets the identities of the cell of an entry as a v2 API cell ID
+func NewCellID(entry *measurements.Entry) *kpimonv2api.CellID {
+	return &kpimonv2api.CellID{
+		NodeId:       entry.Key.NodeID,
+		CellObjectId: entry.Key.CellIdentity.CellID,
+		CellGlobalId: entry.CellGlobalID,
+		PlmnId:       entry.PlmnID,
+	}
+}
+
+// NewCellMeasurements gets an entry as v2 API cell measurements, shared by the sinks and the northbound API
+func NewCellMeasurements(entry *measurements.Entry) *kpimonv2api.CellMeasurements {
+	cellMeasurements := &kpimonv2api.CellMeasurements{
+		Cell:      NewCellID(entry),
+		Periods:   make([]*kpimonv2api.Period, 0, len(entry.Value)),
+		UpdatedAt: uint64(entry.UpdatedAt.UnixNano()),
+		Stale:     entry.Stale,
+	}
+	for _, measItem := range entry.Value {
+		period := &kpimonv2api.Period{
+			Records: make([]*kpimonv2api.Record, 0, len(measItem.MeasurementRecords)),
+		}
+		for _, record := range measItem.MeasurementRecords {
+			period.Records = append(period.Records, &kpimonv2api.Record{
+				Name:      record.MeasurementName,
+				Timestamp: record.Timestamp,
+				Value:     NewValue(record.MeasurementValue),
+				Labels:    record.Labels,
+			})
+		}
+		cellMeasurements.Periods = append(cellMeasurements.Periods, period)
+	}
+	return cellMeasurements
+}
+
+// NewValue gets a measurement value as a v2 API value
+func NewValue(value interface{}) *kpimonv2api.Value {
+	switch val := value.(type) {
+	case int64:
+		return &kpimonv2api.Value{Value: &kpimonv2api.Value_Integer{Integer: val}}
+	case float64:
+		return &kpimonv2api.Value{Value: &kpimonv2api.Value_Real{Real: val}}
+	case int32:
+		return &kpimonv2api.Value{Value: &kpimonv2api.Value_NoValue{NoValue: val}}
+	default:
+		return &kpimonv2api.Value{}
+	}
+}
@@ -0,0 +1,411 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+)
+
+var log = logging.GetLogger()
+
+const (
+	defaultBatchSize     = 100
+	defaultFlushInterval = time.Second
+	defaultBufferSize    = 10000
+	defaultMaxRetries    = 3
+	defaultRetryBackoff  = 500 * time.Millisecond
+	defaultCloseTimeout  = 5 * time.Second
+	maxRetryBackoff      = 30 * time.Second
+	statusInterval       = time.Minute
+)
+
+// Exporter delivers the measurement store events to a set of sinks
+// Each sink has its own buffer and delivery loop, so that a slow or failing sink does not hold back the others:
+// the events are batched, the failed writes are retried with an exponential backoff and the oldest events
+// are dropped once the buffer of the sink is full.
+type Exporter struct {
+	options   Options
+	mu        sync.RWMutex
+	pipelines []*pipeline
+}
+
+// NewExporter creates a new exporter; the options are the default delivery options of its sinks
+func NewExporter(opts ...Option) *Exporter {
+	options := Options{
+		BatchSize:     defaultBatchSize,
+		FlushInterval: defaultFlushInterval,
+		BufferSize:    defaultBufferSize,
+		MaxRetries:    defaultMaxRetries,
+		RetryBackoff:  defaultRetryBackoff,
+		CloseTimeout:  defaultCloseTimeout,
+	}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	return &Exporter{
+		options: options,
+	}
+}
+
+// AddSink adds a sink, overriding the default delivery options with the given ones; the sinks are added before Run
+func (e *Exporter) AddSink(sink Sink, opts ...Option) error {
+	options := e.options
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultBatchSize
+	}
+	if options.BufferSize < options.BatchSize {
+		options.BufferSize = options.BatchSize
+	}
+	if options.MaxRetries < 0 {
+		options.MaxRetries = 0
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, p := range e.pipelines {
+		if p.sink.Name() == sink.Name() {
+			return errors.NewAlreadyExists("export sink %s already exists", sink.Name())
+		}
+	}
+	e.pipelines = append(e.pipelines, newPipeline(sink, options))
+	return nil
+}
+
+// Len returns the number of sinks
+func (e *Exporter) Len() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return len(e.pipelines)
+}
+
+// Status gets the delivery status of the sinks
+func (e *Exporter) Status() []Status {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	statuses := make([]Status, 0, len(e.pipelines))
+	for _, p := range e.pipelines {
+		statuses = append(statuses, p.getStatus())
+	}
+	return statuses
+}
+
+// Run watches the measurement store and delivers its events to the sinks until the context is done;
+// the buffered events are then written within the close timeout before the sinks are closed
+func (e *Exporter) Run(ctx context.Context, store measurements.Store) error {
+	ch := make(chan measurements.Event)
+	err := store.Watch(ctx, ch)
+	if err != nil {
+		return err
+	}
+
+	e.mu.RLock()
+	pipelines := e.pipelines
+	e.mu.RUnlock()
+	for _, p := range pipelines {
+		go p.run(ctx)
+	}
+	go func() {
+		filter := newUpdateFilter()
+		for event := range ch {
+			if !filter.accept(event) {
+				continue
+			}
+			for _, p := range pipelines {
+				p.push(event)
+			}
+		}
+	}()
+	go e.logStatus(ctx)
+	return nil
+}
+
+// updateFilter skips the updates that do not carry a new report of the cell, like the eviction
+// of the oldest records or the stale marking, which keep the update time of the entry
+type updateFilter struct {
+	updatedAt map[measurements.Key]time.Time
+}
+
+func newUpdateFilter() *updateFilter {
+	return &updateFilter{updatedAt: make(map[measurements.Key]time.Time)}
+}
+
+// accept returns whether an event is exported
+func (f *updateFilter) accept(event measurements.Event) bool {
+	switch event.Type {
+	case measurements.Deleted:
+		delete(f.updatedAt, event.Key)
+		return true
+	case measurements.Created, measurements.Updated:
+		if event.Value == nil {
+			return true
+		}
+		if last, ok := f.updatedAt[event.Key]; ok && !event.Value.UpdatedAt.After(last) {
+			return false
+		}
+		f.updatedAt[event.Key] = event.Value.UpdatedAt
+	}
+	return true
+}
+
+// logStatus periodically logs the delivery status of the sinks whenever it changed
+func (e *Exporter) logStatus(ctx context.Context) {
+	ticker := time.NewTicker(statusInterval)
+	defer ticker.Stop()
+	last := make(map[string]Status)
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		for _, status := range e.Status() {
+			previous := last[status.Name]
+			last[status.Name] = status
+			if status.Delivered == previous.Delivered && status.Dropped == previous.Dropped && status.Failed == previous.Failed {
+				continue
+			}
+			if status.Healthy {
+				log.Infof("Export sink %s: %d delivered, %d dropped, %d failed, %d retries, %d buffered",
+					status.Name, status.Delivered, status.Dropped, status.Failed, status.Retries, status.Buffered)
+			} else {
+				log.Warnf("Export sink %s is unhealthy: %d delivered, %d dropped, %d failed, %d retries, %d buffered, last error: %s",
+					status.Name, status.Delivered, status.Dropped, status.Failed, status.Retries, status.Buffered, status.LastError)
+			}
+		}
+	}
+}
+
+// pipeline is the buffer and the delivery loop of a sink
+type pipeline struct {
+	sink    Sink
+	options Options
+	mu      sync.Mutex
+	buffer  []measurements.Event
+	// oldestAt is the time the oldest buffered event was pushed
+	oldestAt time.Time
+	notify   chan struct{}
+	status   Status
+}
+
+func newPipeline(sink Sink, options Options) *pipeline {
+	return &pipeline{
+		sink:    sink,
+		options: options,
+		notify:  make(chan struct{}, 1),
+		status: Status{
+			Name:    sink.Name(),
+			Healthy: true,
+		},
+	}
+}
+
+// push buffers an event, dropping the oldest one if the buffer is full
+func (p *pipeline) push(event measurements.Event) {
+	if event.Type == measurements.Resync {
+		return
+	}
+	p.mu.Lock()
+	first := len(p.buffer) == 0
+	if first {
+		p.oldestAt = time.Now()
+	}
+	if len(p.buffer) >= p.options.BufferSize {
+		p.buffer = p.buffer[1:]
+		p.status.Dropped++
+	}
+	p.buffer = append(p.buffer, event)
+	full := len(p.buffer) >= p.options.BatchSize
+	p.mu.Unlock()
+
+	// the delivery loop is woken up to start the flush interval of the first event or to write a full batch
+	if first || full {
+		select {
+		case p.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (p *pipeline) run(ctx context.Context) {
+	defer func() {
+		err := p.sink.Close()
+		if err != nil {
+			log.Warnf("Failed to close export sink %s: %v", p.sink.Name(), err)
+		}
+	}()
+	for {
+		batch, ok := p.next(ctx)
+		if !ok {
+			p.flush()
+			return
+		}
+		if !p.write(ctx, batch) {
+			// the batch interrupted by the exporter stopping is flushed first
+			p.requeue(batch)
+			p.flush()
+			return
+		}
+	}
+}
+
+// requeue puts back a batch in front of the buffered events
+func (p *pipeline) requeue(batch []measurements.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.buffer = append(batch, p.buffer...)
+}
+
+// flush writes the buffered events once the exporter stops, giving up on them after the close timeout
+func (p *pipeline) flush() {
+	if p.options.CloseTimeout <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), p.options.CloseTimeout)
+	defer cancel()
+	for ctx.Err() == nil {
+		p.mu.Lock()
+		n := len(p.buffer)
+		if n > p.options.BatchSize {
+			n = p.options.BatchSize
+		}
+		batch := make([]measurements.Event, n)
+		copy(batch, p.buffer)
+		p.buffer = p.buffer[n:]
+		p.mu.Unlock()
+		if n == 0 {
+			return
+		}
+		if !p.write(ctx, batch) {
+			p.requeue(batch)
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.buffer) > 0 {
+		log.Warnf("Gave up on %d buffered events of export sink %s after %s", len(p.buffer), p.sink.Name(), p.options.CloseTimeout)
+		p.status.Failed += uint64(len(p.buffer))
+		p.buffer = nil
+	}
+}
+
+// next waits for a full batch or for the flush interval of the oldest buffered event to elapse
+func (p *pipeline) next(ctx context.Context) ([]measurements.Event, bool) {
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for {
+		p.mu.Lock()
+		n := len(p.buffer)
+		wait := p.options.FlushInterval - time.Since(p.oldestAt)
+		if n >= p.options.BatchSize || (n > 0 && wait <= 0) {
+			if n > p.options.BatchSize {
+				n = p.options.BatchSize
+			}
+			batch := make([]measurements.Event, n)
+			copy(batch, p.buffer)
+			p.buffer = p.buffer[n:]
+			// the remaining events were pushed after the batch was due, so they start a new interval
+			p.oldestAt = time.Now()
+			p.mu.Unlock()
+			return batch, true
+		}
+		p.mu.Unlock()
+
+		var timerCh <-chan time.Time
+		if n > 0 {
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.NewTimer(wait)
+			timerCh = timer.C
+		}
+		select {
+		case <-p.notify:
+		case <-timerCh:
+		case <-ctx.Done():
+			return nil, false
+		}
+	}
+}
+
+// write writes a batch, retrying with an exponential backoff; it returns false if the context is done before
+// the batch is written or given up on
+func (p *pipeline) write(ctx context.Context, batch []measurements.Event) bool {
+	backoff := p.options.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := p.sink.Write(ctx, batch)
+		if err == nil {
+			p.recordWrite(len(batch))
+			return true
+		}
+		p.recordError(err)
+		if ctx.Err() != nil {
+			return false
+		}
+		if attempt >= p.options.MaxRetries {
+			p.recordFailure(len(batch))
+			log.Warnf("Failed to write %d events to export sink %s after %d retries: %v", len(batch), p.sink.Name(), attempt, err)
+			return true
+		}
+		log.Debugf("Retrying write to export sink %s in %s: %v", p.sink.Name(), backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return false
+		}
+		p.recordRetry()
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
+
+func (p *pipeline) recordWrite(events int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status.Delivered += uint64(events)
+	p.status.LastWrite = time.Now()
+	p.status.Healthy = true
+}
+
+func (p *pipeline) recordError(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status.Healthy = false
+	p.status.LastError = err.Error()
+}
+
+func (p *pipeline) recordRetry() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status.Retries++
+}
+
+func (p *pipeline) recordFailure(events int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status.Failed += uint64(events)
+}
+
+func (p *pipeline) getStatus() Status {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	status := p.status
+	status.Buffered = len(p.buffer)
+	return status
+}
@@ -0,0 +1,267 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSink records the cell IDs of the written batches, returning the scripted errors first
+type testSink struct {
+	mu      sync.Mutex
+	batches [][]string
+	errs    []error
+	// failing fails all of the writes
+	failing bool
+	closed  chan struct{}
+}
+
+func newTestSink(errs ...error) *testSink {
+	return &testSink{
+		errs:   errs,
+		closed: make(chan struct{}),
+	}
+}
+
+func (s *testSink) Name() string {
+	return "test"
+}
+
+func (s *testSink) Write(_ context.Context, events []measurements.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failing {
+		return errors.NewUnavailable("sink is unavailable")
+	}
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return err
+	}
+	batch := make([]string, 0, len(events))
+	for _, event := range events {
+		batch = append(batch, event.Value.Key.CellIdentity.CellID)
+	}
+	s.batches = append(s.batches, batch)
+	return nil
+}
+
+func (s *testSink) Close() error {
+	close(s.closed)
+	return nil
+}
+
+func (s *testSink) getBatches() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]string(nil), s.batches...)
+}
+
+func put(t *testing.T, store measurements.Store, cellIDs ...int) {
+	for _, cellID := range cellIDs {
+		key := measurements.NewKey(measurements.CellIdentity{CellID: strconv.Itoa(cellID)}, "e2:1")
+		_, err := store.Put(context.Background(), key, []measurements.MeasurementItem{{
+			MeasurementRecords: []measurements.MeasurementRecord{{MeasurementName: "A", MeasurementValue: int64(cellID)}},
+		}})
+		assert.NoError(t, err)
+	}
+}
+
+func startExporter(t *testing.T, ctx context.Context, sink Sink, opts ...Option) (*Exporter, measurements.Store) {
+	exporter := NewExporter(opts...)
+	assert.NoError(t, exporter.AddSink(sink))
+	store := measurements.NewStore()
+	assert.NoError(t, exporter.Run(ctx, store))
+	return exporter, store
+}
+
+func waitClosed(t *testing.T, sink *testSink) {
+	select {
+	case <-sink.closed:
+	case <-time.After(time.Second):
+		t.Fatal("the sink was not closed")
+	}
+}
+
+func TestAddSink(t *testing.T) {
+	exporter := NewExporter()
+	assert.NoError(t, exporter.AddSink(newTestSink()))
+	assert.True(t, errors.IsAlreadyExists(exporter.AddSink(newTestSink())))
+	assert.Equal(t, 1, exporter.Len())
+}
+
+func TestBatching(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink := newTestSink()
+	exporter, store := startExporter(t, ctx, sink, WithBatchSize(3), WithFlushInterval(100*time.Millisecond))
+
+	// the full batches are written at once, the others once the flush interval of their oldest event elapsed
+	start := time.Now()
+	put(t, store, 1, 2, 3, 4, 5, 6, 7)
+	assert.Eventually(t, func() bool {
+		return len(sink.getBatches()) == 2
+	}, time.Second, time.Millisecond)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return len(sink.getBatches()) == 3
+	}, time.Second, time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, [][]string{{"1", "2", "3"}, {"4", "5", "6"}, {"7"}}, sink.getBatches())
+
+	status := exporter.Status()[0]
+	assert.Equal(t, "test", status.Name)
+	assert.True(t, status.Healthy)
+	assert.Equal(t, uint64(7), status.Delivered)
+	assert.Equal(t, 0, status.Buffered)
+}
+
+func TestRetries(t *testing.T) {
+	unavailable := errors.NewUnavailable("sink is unavailable")
+	tests := []struct {
+		name    string
+		errs    []error
+		batches [][]string
+		status  Status
+	}{
+		{
+			name:    "written",
+			batches: [][]string{{"1", "2"}},
+			status:  Status{Healthy: true, Delivered: 2},
+		},
+		{
+			name:    "retried",
+			errs:    []error{unavailable, unavailable},
+			batches: [][]string{{"1", "2"}},
+			status:  Status{Healthy: true, Delivered: 2, Retries: 2},
+		},
+		{
+			name:   "retries exhausted",
+			errs:   []error{unavailable, unavailable, unavailable},
+			status: Status{Healthy: false, Failed: 2, Retries: 2},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			sink := newTestSink(test.errs...)
+			exporter, store := startExporter(t, ctx, sink,
+				WithBatchSize(2), WithMaxRetries(2), WithRetryBackoff(time.Millisecond))
+			put(t, store, 1, 2)
+
+			assert.Eventually(t, func() bool {
+				status := exporter.Status()[0]
+				return status.Delivered+status.Failed == 2
+			}, time.Second, time.Millisecond)
+			assert.Equal(t, test.batches, sink.getBatches())
+			status := exporter.Status()[0]
+			assert.Equal(t, test.status.Healthy, status.Healthy)
+			assert.Equal(t, test.status.Delivered, status.Delivered)
+			assert.Equal(t, test.status.Failed, status.Failed)
+			assert.Equal(t, test.status.Retries, status.Retries)
+		})
+	}
+}
+
+func TestBufferOverflow(t *testing.T) {
+	p := newPipeline(newTestSink(), Options{BatchSize: 2, BufferSize: 3})
+	store := measurements.NewStore()
+	ch := make(chan measurements.Event, 10)
+	assert.NoError(t, store.Watch(context.Background(), ch))
+	put(t, store, 1, 2, 3, 4, 5)
+	for i := 0; i < 5; i++ {
+		p.push(<-ch)
+	}
+	// the resync events are not exported
+	p.push(measurements.Event{Type: measurements.Resync})
+
+	status := p.getStatus()
+	assert.Equal(t, uint64(2), status.Dropped)
+	assert.Equal(t, 3, status.Buffered)
+	assert.Equal(t, "3", p.buffer[0].Value.Key.CellIdentity.CellID)
+}
+
+func TestCloseFlush(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sink := newTestSink()
+	exporter, store := startExporter(t, ctx, sink, WithBatchSize(2), WithFlushInterval(time.Hour))
+
+	// the buffered events are written before the sink is closed
+	put(t, store, 1, 2, 3)
+	assert.Eventually(t, func() bool {
+		return exporter.Status()[0].Buffered == 1
+	}, time.Second, time.Millisecond)
+	cancel()
+	waitClosed(t, sink)
+	assert.Equal(t, [][]string{{"1", "2"}, {"3"}}, sink.getBatches())
+	assert.Equal(t, uint64(3), exporter.Status()[0].Delivered)
+}
+
+func TestCloseTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sink := newTestSink()
+	sink.failing = true
+	exporter, store := startExporter(t, ctx, sink, WithBatchSize(2), WithMaxRetries(100),
+		WithRetryBackoff(10*time.Millisecond), WithCloseTimeout(50*time.Millisecond))
+
+	// the batch being retried and the buffered events are given up on after the close timeout
+	put(t, store, 1, 2, 3)
+	assert.Eventually(t, func() bool {
+		return exporter.Status()[0].Retries > 0
+	}, time.Second, time.Millisecond)
+	start := time.Now()
+	cancel()
+	waitClosed(t, sink)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	status := exporter.Status()[0]
+	assert.Equal(t, uint64(0), status.Delivered)
+	assert.Equal(t, uint64(3), status.Failed)
+	assert.Equal(t, 0, status.Buffered)
+}
+
+func TestInternalUpdatesSkipped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink := newTestSink()
+	exporter := NewExporter(WithFlushInterval(10 * time.Millisecond))
+	assert.NoError(t, exporter.AddSink(sink))
+	store := measurements.NewStore(measurements.WithMaxRecords(3))
+	assert.NoError(t, exporter.Run(ctx, store))
+
+	// putting the second cell evicts the past granularity periods of the first one, which is not exported again
+	items := make([]measurements.MeasurementItem, 0, 3)
+	for i := 0; i < 3; i++ {
+		items = append(items, measurements.MeasurementItem{MeasurementRecords: []measurements.MeasurementRecord{
+			{Timestamp: uint64(i), MeasurementName: "A", MeasurementValue: int64(i)},
+		}})
+	}
+	key := measurements.NewKey(measurements.CellIdentity{CellID: "1"}, "e2:1")
+	_, err := store.Put(ctx, key, items)
+	assert.NoError(t, err)
+	put(t, store, 2)
+	entry, err := store.Get(ctx, key)
+	assert.NoError(t, err)
+	assert.Len(t, entry.Value, 1)
+	assert.Eventually(t, func() bool {
+		return exporter.Status()[0].Delivered == 2
+	}, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, uint64(2), exporter.Status()[0].Delivered)
+
+	// a new report of the cell is exported
+	put(t, store, 1)
+	assert.Eventually(t, func() bool {
+		return exporter.Status()[0].Delivered == 3
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+)
+
+// csvHeader is the header row of the CSV files
+var csvHeader = []string{"timestamp", "node_id", "cell_object_id", "cell_global_id", "plmn_id", "name", "value", "labels"}
+
+// row is a recorded measurement record
+type row struct {
+	// Timestamp is the record timestamp in nanoseconds
+	Timestamp    uint64 `json:"timestamp"`
+	NodeID       string `json:"node_id"`
+	CellObjectID string `json:"cell_object_id"`
+	CellGlobalID string `json:"cell_global_id,omitempty"`
+	PlmnID       string `json:"plmn_id,omitempty"`
+	Name         string `json:"name"`
+	// Value is an integer, a float or null for the records without value
+	Value  interface{}       `json:"value"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// newRows gets a row per record of an entry
+func newRows(entry *measurements.Entry) []row {
+	var plmnID string
+	if entry.PlmnID != 0 {
+		// hex encoded like the PLMN ID labels
+		plmnID = fmt.Sprintf("%06x", entry.PlmnID)
+	}
+
+	rows := make([]row, 0)
+	for _, measItem := range entry.Value {
+		for _, record := range measItem.MeasurementRecords {
+			var value interface{}
+			switch val := record.MeasurementValue.(type) {
+			case int64:
+				value = val
+			case float64:
+				if !math.IsNaN(val) && !math.IsInf(val, 0) {
+					value = val
+				}
+			}
+			rows = append(rows, row{
+				Timestamp:    record.Timestamp,
+				NodeID:       entry.Key.NodeID,
+				CellObjectID: entry.Key.CellIdentity.CellID,
+				CellGlobalID: entry.CellGlobalID,
+				PlmnID:       plmnID,
+				Name:         record.MeasurementName,
+				Value:        value,
+				Labels:       record.Labels,
+			})
+		}
+	}
+	return rows
+}
+
+// encode encodes a row as a line of the given format
+func encode(format Format, r row) ([]byte, error) {
+	if format == CSVFormat {
+		return encodeCSV(r.fields())
+	}
+	line, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return append(line, '\n'), nil
+}
+
+func encodeCSV(fields []string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(fields); err != nil {
+		return nil, err
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// fields gets the CSV fields of a row; the labels are sorted "name=value" pairs separated by semicolons
+func (r row) fields() []string {
+	var value string
+	switch val := r.Value.(type) {
+	case int64:
+		value = strconv.FormatInt(val, 10)
+	case float64:
+		value = strconv.FormatFloat(val, 'g', -1, 64)
+	}
+
+	names := make([]string, 0, len(r.Labels))
+	for name := range r.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	labels := make([]string, 0, len(names))
+	for _, name := range names {
+		labels = append(labels, name+"="+r.Labels[name])
+	}
+
+	return []string{
+		strconv.FormatUint(r.Timestamp, 10),
+		r.NodeID,
+		r.CellObjectID,
+		r.CellGlobalID,
+		r.PlmnID,
+		r.Name,
+		value,
+		strings.Join(labels, ";"),
+	}
+}
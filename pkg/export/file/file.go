@@ -0,0 +1,288 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/export"
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+)
+
+var log = logging.GetLogger()
+
+const (
+	// Name is the name of the file sink
+	Name = "file"
+
+	defaultPrefix = "kpimon"
+	// timeFormat is the format of the time the files are created at in their names, so that the names sort by time
+	timeFormat = "20060102T150405.000Z"
+	gzipExt    = ".gz"
+)
+
+// Sink records the measurement records to local files, one line per record
+// The files are named "<prefix>-<creation time>.<jsonl|csv>" and are rotated by size and time; the rotated files
+// are compressed to "<name>.gz" and only the latest of them are kept if a retention count is set. A file is only
+// rotated on a write, so that the rotation interval is the minimum age of a rotated file.
+type Sink struct {
+	dir     string
+	options Options
+	mu      sync.Mutex
+	file    *os.File
+	writer  *bufio.Writer
+	size    int64
+	// headerSize is the size of the header of the current file
+	headerSize int64
+	openedAt   time.Time
+}
+
+// NewSink creates a new file sink recording to the given directory, which is created if it does not exist
+func NewSink(dir string, opts ...Option) (*Sink, error) {
+	options := Options{
+		Format: JSONLinesFormat,
+		Prefix: defaultPrefix,
+	}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	if dir == "" {
+		return nil, errors.NewInvalid("no recording directory")
+	}
+	switch options.Format {
+	case JSONLinesFormat, CSVFormat:
+	default:
+		return nil, errors.NewInvalid("unknown recording format %s", options.Format)
+	}
+	if options.Prefix == "" || strings.ContainsRune(options.Prefix, filepath.Separator) {
+		return nil, errors.NewInvalid("invalid file name prefix %q", options.Prefix)
+	}
+	return &Sink{
+		dir:     dir,
+		options: options,
+	}, nil
+}
+
+// Name gets the name of the sink
+func (s *Sink) Name() string {
+	return Name
+}
+
+// Write records the records of a batch of measurement updates; the deleted cells are ignored
+// A batch is written to a single file, rotated before it if needed, and is truncated away if it fails to be written,
+// so that the retried batches are not recorded twice.
+func (s *Sink) Write(_ context.Context, events []measurements.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var batch []byte
+	for _, event := range events {
+		if event.Type == measurements.Deleted {
+			continue
+		}
+		for _, r := range newRows(event.Value) {
+			line, err := encode(s.options.Format, r)
+			if err != nil {
+				return errors.NewInvalid("failed to encode a record of %s: %v", r.CellObjectID, err)
+			}
+			batch = append(batch, line...)
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+
+	if s.file != nil && s.shouldRotate(len(batch)) {
+		if err := s.closeFile(); err != nil {
+			return err
+		}
+	}
+	if s.file == nil {
+		if err := s.openFile(); err != nil {
+			return err
+		}
+	}
+	size := s.size
+	_, err := s.writer.Write(batch)
+	if err == nil {
+		err = s.writer.Flush()
+	}
+	if err != nil {
+		s.truncate(size)
+		return errors.NewUnavailable("failed to write %s: %v", s.file.Name(), err)
+	}
+	s.size += int64(len(batch))
+	return nil
+}
+
+// truncate drops the part of a failed batch written to the current file
+func (s *Sink) truncate(size int64) {
+	s.writer.Reset(s.file)
+	if err := s.file.Truncate(size); err != nil {
+		// the next batch goes to a new file, after the partial batch
+		log.Warnf("Failed to truncate %s, the retried records may be recorded twice: %v", s.file.Name(), err)
+		if err := s.closeFile(); err != nil {
+			log.Warn(err)
+		}
+	}
+}
+
+// Close closes the current file, which is compressed like the rotated files
+func (s *Sink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closeFile()
+}
+
+// shouldRotate returns true if the current file is too old or if it holds records and the batch would make it too big
+func (s *Sink) shouldRotate(batchSize int) bool {
+	if s.options.MaxSize > 0 && s.size > s.headerSize && s.size+int64(batchSize) > s.options.MaxSize {
+		return true
+	}
+	return s.options.RotationInterval > 0 && time.Since(s.openedAt) >= s.options.RotationInterval
+}
+
+func (s *Sink) ext() string {
+	if s.options.Format == CSVFormat {
+		return ".csv"
+	}
+	return ".jsonl"
+}
+
+func (s *Sink) openFile() error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return errors.NewUnavailable("failed to create %s: %v", s.dir, err)
+	}
+	now := time.Now().Truncate(time.Millisecond)
+	if !now.After(s.openedAt) {
+		// a file rotated within the same millisecond gets the next name
+		now = s.openedAt.Add(time.Millisecond)
+	}
+	name := filepath.Join(s.dir, s.options.Prefix+"-"+now.UTC().Format(timeFormat)+s.ext())
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return errors.NewUnavailable("failed to open %s: %v", name, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return errors.NewUnavailable("failed to open %s: %v", name, err)
+	}
+
+	size := info.Size()
+	if s.options.Format == CSVFormat && size == 0 {
+		header, err := encodeCSV(csvHeader)
+		if err == nil {
+			_, err = file.Write(header)
+		}
+		if err != nil {
+			_ = file.Close()
+			return errors.NewUnavailable("failed to write the header of %s: %v", name, err)
+		}
+		size = int64(len(header))
+	}
+
+	s.file = file
+	s.writer = bufio.NewWriter(file)
+	s.size = size
+	s.openedAt = now
+	log.Infof("Recording the measurements to %s", name)
+	s.headerSize = s.size
+	return nil
+}
+
+// closeFile closes the current file, compresses it and deletes the rotated files beyond the retention count
+func (s *Sink) closeFile() error {
+	if s.file == nil {
+		return nil
+	}
+	file := s.file
+	err := s.writer.Flush()
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	s.file, s.writer, s.size, s.headerSize = nil, nil, 0, 0
+	if err != nil {
+		return errors.NewUnavailable("failed to close %s: %v", file.Name(), err)
+	}
+
+	if s.options.Compress {
+		if err := compress(file.Name()); err != nil {
+			// the file is kept uncompressed
+			log.Warnf("Failed to compress %s: %v", file.Name(), err)
+		}
+	}
+	s.prune()
+	return nil
+}
+
+// compress compresses a file to "<name>.gz" and deletes it
+func compress(name string) error {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(name+gzipExt, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	zw.Name = filepath.Base(name)
+	_, err = io.Copy(zw, src)
+	if closeErr := zw.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(name + gzipExt)
+		return err
+	}
+	return os.Remove(name)
+}
+
+// prune deletes the oldest rotated files beyond the retention count
+func (s *Sink) prune() {
+	if s.options.MaxFiles <= 0 {
+		return
+	}
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		log.Warnf("Failed to list %s: %v", s.dir, err)
+		return
+	}
+	names := make([]string, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, s.options.Prefix+"-") {
+			continue
+		}
+		if strings.HasSuffix(name, s.ext()) || strings.HasSuffix(name, s.ext()+gzipExt) {
+			names = append(names, name)
+		}
+	}
+	// the creation time makes the names sort by age
+	sort.Strings(names)
+	for i := 0; i < len(names)-s.options.MaxFiles; i++ {
+		name := filepath.Join(s.dir, names[i])
+		if err := os.Remove(name); err != nil {
+			log.Warnf("Failed to delete %s: %v", name, err)
+		}
+	}
+}
+
+var _ export.Sink = &Sink{}
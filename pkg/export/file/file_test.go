@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEvent(eventType measurements.MeasurementEvent, cellID int) measurements.Event {
+	key := measurements.NewKey(measurements.CellIdentity{CellID: strconv.Itoa(cellID)}, "e2:1")
+	return measurements.Event{
+		Key:  key,
+		Type: eventType,
+		Value: &measurements.Entry{
+			Key: key,
+			Value: []measurements.MeasurementItem{{
+				MeasurementRecords: []measurements.MeasurementRecord{{Timestamp: 1, MeasurementName: "A", MeasurementValue: int64(cellID)}},
+			}},
+		},
+	}
+}
+
+func newTestEvents(cellIDs ...int) []measurements.Event {
+	events := make([]measurements.Event, 0, len(cellIDs))
+	for _, cellID := range cellIDs {
+		events = append(events, newTestEvent(measurements.Updated, cellID))
+	}
+	return events
+}
+
+// listFiles lists the names of the files of a directory
+func listFiles(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+// readFile reads the lines of a recorded file, uncompressing it if needed
+func readFile(t *testing.T, name string) []string {
+	f, err := os.Open(name)
+	assert.NoError(t, err)
+	defer f.Close()
+	var r io.Reader = f
+	if strings.HasSuffix(name, gzipExt) {
+		zr, err := gzip.NewReader(f)
+		assert.NoError(t, err)
+		r = zr
+	}
+	data, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+// readLines reads the lines of the files of a directory, in the order of their names
+func readLines(t *testing.T, dir string) []string {
+	lines := make([]string, 0)
+	for _, name := range listFiles(t, dir) {
+		lines = append(lines, readFile(t, filepath.Join(dir, name))...)
+	}
+	return lines
+}
+
+// failingWriter writes up to n bytes and then fails
+type failingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n, _ := w.w.Write(p[:w.n])
+		w.n -= n
+		return n, io.ErrShortWrite
+	}
+	w.n -= len(p)
+	return w.w.Write(p)
+}
+
+func TestWriteFailure(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewSink(dir, WithFormat(CSVFormat))
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Write(context.Background(), newTestEvents(1)))
+
+	// the part of the failed batch written to the file is truncated, so that the retried batch is recorded once
+	sink.writer = bufio.NewWriterSize(&failingWriter{w: sink.file, n: 20}, 16)
+	assert.Error(t, sink.Write(context.Background(), newTestEvents(2, 3)))
+	assert.NoError(t, sink.Write(context.Background(), newTestEvents(2, 3)))
+	assert.NoError(t, sink.Close())
+	assert.Equal(t, []string{
+		"timestamp,node_id,cell_object_id,cell_global_id,plmn_id,name,value,labels",
+		"1,e2:1,1,,,A,1,",
+		"1,e2:1,2,,,A,2,",
+		"1,e2:1,3,,,A,3,",
+	}, readLines(t, dir))
+}
+
+func TestNewSink(t *testing.T) {
+	_, err := NewSink("")
+	assert.True(t, errors.IsInvalid(err))
+	_, err = NewSink(t.TempDir(), WithFormat("xml"))
+	assert.True(t, errors.IsInvalid(err))
+	_, err = NewSink(t.TempDir(), WithPrefix("a/b"))
+	assert.True(t, errors.IsInvalid(err))
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "recordings")
+	sink, err := NewSink(dir, WithPrefix("drive"))
+	assert.NoError(t, err)
+	event := newTestEvent(measurements.Updated, 1)
+	event.Value.CellGlobalID = "13842601454c001"
+	event.Value.PlmnID = 0x138426
+	event.Value.Value[0].MeasurementRecords = append(event.Value.Value[0].MeasurementRecords,
+		measurements.MeasurementRecord{Timestamp: 2, MeasurementName: "B", MeasurementValue: int32(0), Labels: map[string]string{"slice_id": "1"}})
+	// the deleted cells are not recorded
+	assert.NoError(t, sink.Write(context.Background(), []measurements.Event{event, newTestEvent(measurements.Deleted, 2)}))
+	assert.NoError(t, sink.Close())
+
+	names := listFiles(t, dir)
+	assert.Len(t, names, 1)
+	assert.True(t, strings.HasPrefix(names[0], "drive-"), names[0])
+	assert.True(t, strings.HasSuffix(names[0], ".jsonl"), names[0])
+	lines := readLines(t, dir)
+	assert.Len(t, lines, 2)
+	var rows []map[string]interface{}
+	for _, line := range lines {
+		var r map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &r))
+		rows = append(rows, r)
+	}
+	assert.Equal(t, map[string]interface{}{"timestamp": 1.0, "node_id": "e2:1", "cell_object_id": "1",
+		"cell_global_id": "13842601454c001", "plmn_id": "138426", "name": "A", "value": 1.0}, rows[0])
+	assert.Equal(t, map[string]interface{}{"timestamp": 2.0, "node_id": "e2:1", "cell_object_id": "1",
+		"cell_global_id": "13842601454c001", "plmn_id": "138426", "name": "B", "value": nil,
+		"labels": map[string]interface{}{"slice_id": "1"}}, rows[1])
+}
+
+func TestRotationBySize(t *testing.T) {
+	dir := t.TempDir()
+	// the header is 74 bytes long and a row of a test event 16 to 18 bytes
+	sink, err := NewSink(dir, WithFormat(CSVFormat), WithMaxSize(160))
+	assert.NoError(t, err)
+	for _, batch := range [][]int{{1, 2}, {3, 4}, {5}, {6, 7, 8, 9, 10, 11, 12}, {13}} {
+		assert.NoError(t, sink.Write(context.Background(), newTestEvents(batch...)))
+	}
+	assert.NoError(t, sink.Close())
+
+	// a file is rotated before the batch that would make it too big, so that a batch is never split;
+	// a batch bigger than the maximum size is written to a single file
+	names := listFiles(t, dir)
+	assert.Len(t, names, 3)
+	var cellIDs [][]string
+	for _, name := range names {
+		lines := readFile(t, filepath.Join(dir, name))
+		assert.Equal(t, strings.Join(csvHeader, ","), lines[0])
+		ids := make([]string, 0)
+		for _, line := range lines[1:] {
+			ids = append(ids, strings.Split(line, ",")[2])
+		}
+		cellIDs = append(cellIDs, ids)
+	}
+	assert.Equal(t, [][]string{{"1", "2", "3", "4", "5"}, {"6", "7", "8", "9", "10", "11", "12"}, {"13"}}, cellIDs)
+}
+
+func TestRotationByTime(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewSink(dir, WithRotationInterval(50*time.Millisecond))
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Write(context.Background(), newTestEvents(1)))
+	assert.NoError(t, sink.Write(context.Background(), newTestEvents(2)))
+	// a file is only rotated on a write once it is old enough
+	time.Sleep(60 * time.Millisecond)
+	assert.Len(t, listFiles(t, dir), 1)
+	assert.NoError(t, sink.Write(context.Background(), newTestEvents(3)))
+	assert.NoError(t, sink.Close())
+
+	names := listFiles(t, dir)
+	assert.Len(t, names, 2)
+	assert.Len(t, readFile(t, filepath.Join(dir, names[0])), 2)
+	assert.Len(t, readFile(t, filepath.Join(dir, names[1])), 1)
+}
+
+func TestCompressionAndPruning(t *testing.T) {
+	dir := t.TempDir()
+	// the files of other prefixes or formats are not pruned
+	others := []string{"kpimon-20200101T000000.000Z.csv", "other-20200101T000000.000Z.jsonl", "notes.txt"}
+	for _, name := range others {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("x\n"), 0644))
+	}
+	sink, err := NewSink(dir, WithMaxSize(1), WithCompression(), WithMaxFiles(2))
+	assert.NoError(t, err)
+	for i := 1; i <= 4; i++ {
+		assert.NoError(t, sink.Write(context.Background(), newTestEvents(i)))
+	}
+	// the current file is not compressed until it is rotated or closed
+	names := listFiles(t, dir)
+	assert.Len(t, names, len(others)+3)
+	assert.True(t, strings.HasSuffix(names[len(others)+2], ".jsonl"), names)
+	assert.NoError(t, sink.Close())
+
+	// only the latest rotated files are kept, compressed
+	names = listFiles(t, dir)
+	assert.Len(t, names, len(others)+2)
+	for _, name := range others {
+		assert.Contains(t, names, name)
+	}
+	var lines []string
+	for _, name := range names {
+		if strings.HasSuffix(name, ".jsonl"+gzipExt) {
+			lines = append(lines, readFile(t, filepath.Join(dir, name))...)
+		}
+	}
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"cell_object_id":"3"`)
+	assert.Contains(t, lines[1], `"cell_object_id":"4"`)
+}
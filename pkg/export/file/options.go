@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"time"
+)
+
+// Format is the format of the recorded files
+type Format string
+
+const (
+	// JSONLinesFormat records a JSON object per line
+	JSONLinesFormat Format = "json"
+	// CSVFormat records a CSV row per line, after a header row
+	CSVFormat Format = "csv"
+)
+
+// Options file sink options
+type Options struct {
+	// Format is the format of the recorded files
+	Format Format
+	// Prefix is the prefix of the file names
+	Prefix string
+	// MaxSize is the size in bytes after which a file is rotated; zero disables the rotation by size
+	MaxSize int64
+	// RotationInterval is the age after which a file is rotated; zero disables the rotation by time
+	RotationInterval time.Duration
+	// Compress compresses the rotated files with gzip
+	Compress bool
+	// MaxFiles is the number of rotated files kept, the oldest are deleted; zero keeps all of them
+	MaxFiles int
+}
+
+// Option file sink option interface
+type Option interface {
+	apply(*Options)
+}
+
+type funcOption struct {
+	f func(*Options)
+}
+
+func (f funcOption) apply(options *Options) {
+	f.f(options)
+}
+
+func newOption(f func(*Options)) Option {
+	return funcOption{
+		f: f,
+	}
+}
+
+// WithFormat sets the format of the recorded files
+func WithFormat(format Format) Option {
+	return newOption(func(options *Options) {
+		options.Format = format
+	})
+}
+
+// WithPrefix sets the prefix of the file names
+func WithPrefix(prefix string) Option {
+	return newOption(func(options *Options) {
+		options.Prefix = prefix
+	})
+}
+
+// WithMaxSize sets the size in bytes after which a file is rotated
+func WithMaxSize(maxSize int64) Option {
+	return newOption(func(options *Options) {
+		options.MaxSize = maxSize
+	})
+}
+
+// WithRotationInterval sets the age after which a file is rotated
+func WithRotationInterval(interval time.Duration) Option {
+	return newOption(func(options *Options) {
+		options.RotationInterval = interval
+	})
+}
+
+// WithCompression compresses the rotated files with gzip
+func WithCompression() Option {
+	return newOption(func(options *Options) {
+		options.Compress = true
+	})
+}
+
+// WithMaxFiles sets the number of rotated files kept
+func WithMaxFiles(maxFiles int) Option {
+	return newOption(func(options *Options) {
+		options.MaxFiles = maxFiles
+	})
+}
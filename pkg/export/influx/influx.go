@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package influx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/export"
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+const (
+	// Name is the name of the InfluxDB sink
+	Name = "influx"
+
+	defaultTimeout = 10 * time.Second
+	// maxErrorBody is the maximum number of bytes of an error response reported in the write error
+	maxErrorBody = 512
+)
+
+// Sink writes the measurement records to an InfluxDB write endpoint in the line protocol
+// The write URL selects the database or bucket, e.g. http://influxdb:8086/api/v2/write?org=sdran&bucket=kpimon&precision=ns
+// for InfluxDB 2 or http://influxdb:8086/write?db=kpimon for InfluxDB 1; the precision must be nanoseconds, the default.
+// A point is overwritten by a point of the same series and timestamp, so that the retried writes are idempotent.
+type Sink struct {
+	url     string
+	options Options
+}
+
+// NewSink creates a new InfluxDB sink of the given write URL
+func NewSink(writeURL string, opts ...Option) (*Sink, error) {
+	options := Options{}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	if options.Client == nil {
+		options.Client = &http.Client{
+			Timeout: defaultTimeout,
+		}
+	}
+	u, err := url.Parse(writeURL)
+	if err != nil {
+		return nil, errors.NewInvalid("invalid InfluxDB write URL %s: %v", writeURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.NewInvalid("invalid InfluxDB write URL %s: the scheme is not http or https", writeURL)
+	}
+	return &Sink{
+		url:     writeURL,
+		options: options,
+	}, nil
+}
+
+// Name gets the name of the sink
+func (s *Sink) Name() string {
+	return Name
+}
+
+// Write writes the records of a batch of measurement updates in a single request; the deleted cells are ignored
+func (s *Sink) Write(ctx context.Context, events []measurements.Event) error {
+	var b strings.Builder
+	lines := 0
+	for _, event := range events {
+		if event.Type == measurements.Deleted {
+			continue
+		}
+		lines += appendLines(&b, event.Value)
+	}
+	if lines == 0 {
+		return nil
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, strings.NewReader(b.String()))
+	if err != nil {
+		return errors.NewInvalid(err.Error())
+	}
+	request.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if s.options.Token != "" {
+		request.Header.Set("Authorization", "Token "+s.options.Token)
+	}
+
+	response, err := s.options.Client.Do(request)
+	if err != nil {
+		return errors.NewUnavailable("failed to write %d points to InfluxDB: %v", lines, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBody))
+		return errors.NewUnavailable("failed to write %d points to InfluxDB: %s: %s", lines, response.Status, strings.TrimSpace(string(body)))
+	}
+	// drain the body so that the connection is reused
+	_, _ = io.Copy(io.Discard, response.Body)
+	return nil
+}
+
+// Close closes the idle connections
+func (s *Sink) Close() error {
+	s.options.Client.CloseIdleConnections()
+	return nil
+}
+
+var _ export.Sink = &Sink{}
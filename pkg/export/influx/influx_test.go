@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package influx
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// request is a write request received by the test server
+type request struct {
+	path          string
+	authorization string
+	contentType   string
+	body          string
+}
+
+// newTestServer starts an InfluxDB write endpoint recording the requests and answering with the given status
+func newTestServer(t *testing.T, status int) (*httptest.Server, *[]request) {
+	requests := make([]request, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requests = append(requests, request{
+			path:          r.URL.RequestURI(),
+			authorization: r.Header.Get("Authorization"),
+			contentType:   r.Header.Get("Content-Type"),
+			body:          string(body),
+		})
+		w.WriteHeader(status)
+		if status/100 != 2 {
+			_, _ = w.Write([]byte(`{"code":"invalid","message":"unable to parse points"}` + "\n"))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func newTestEvent(eventType measurements.MeasurementEvent, entry *measurements.Entry) measurements.Event {
+	return measurements.Event{
+		Key:   entry.Key,
+		Type:  eventType,
+		Value: entry,
+	}
+}
+
+func newTestEntry(cellID string, records ...measurements.MeasurementRecord) *measurements.Entry {
+	return &measurements.Entry{
+		Key:          measurements.NewKey(measurements.CellIdentity{CellID: cellID}, "e2:1"),
+		Value:        []measurements.MeasurementItem{{MeasurementRecords: records}},
+		CellGlobalID: "13842601454c001",
+		PlmnID:       0x138426,
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	_, err := NewSink("influxdb:8086/write")
+	assert.True(t, errors.IsInvalid(err))
+	_, err = NewSink("ftp://influxdb:8086/write")
+	assert.True(t, errors.IsInvalid(err))
+	_, err = NewSink("http://influxdb:8086/write?db=kpimon")
+	assert.NoError(t, err)
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []measurements.Event
+		// body is the expected request body, or empty if no request is expected
+		body string
+	}{
+		{
+			name: "integer and float values",
+			events: []measurements.Event{newTestEvent(measurements.Updated, newTestEntry("1",
+				measurements.MeasurementRecord{Timestamp: 1000, MeasurementName: "RRC.ConnEstabSucc.Sum", MeasurementValue: int64(3)},
+				measurements.MeasurementRecord{Timestamp: 2000, MeasurementName: "DRB.UEThpDl", MeasurementValue: 2.5}))},
+			body: "RRC.ConnEstabSucc.Sum,cell_global_id=13842601454c001,cell_object_id=1,node_id=e2:1,plmn_id=138426 value=3i 1000\n" +
+				"DRB.UEThpDl,cell_global_id=13842601454c001,cell_object_id=1,node_id=e2:1,plmn_id=138426 value=2.5 2000\n",
+		},
+		{
+			name: "escaping",
+			events: []measurements.Event{newTestEvent(measurements.Created, newTestEntry("cell 1,a=b",
+				measurements.MeasurementRecord{Timestamp: 1000, MeasurementName: "PDCP Vol,DL=x", MeasurementValue: int64(1),
+					Labels: map[string]string{"slice id": "a,b=c", "empty": ""}}))},
+			body: `PDCP\ Vol\,DL=x,cell_global_id=13842601454c001,cell_object_id=cell\ 1\,a\=b,node_id=e2:1,plmn_id=138426,slice\ id=a\,b\=c value=1i 1000` + "\n",
+		},
+		{
+			name: "cell tags take precedence",
+			events: []measurements.Event{newTestEvent(measurements.Updated, newTestEntry("1",
+				measurements.MeasurementRecord{Timestamp: 1000, MeasurementName: "A", MeasurementValue: int64(1),
+					Labels: map[string]string{"node_id": "x", "fiveQI": "9"}}))},
+			body: "A,cell_global_id=13842601454c001,cell_object_id=1,fiveQI=9,node_id=e2:1,plmn_id=138426 value=1i 1000\n",
+		},
+		{
+			name: "values skipped",
+			events: []measurements.Event{newTestEvent(measurements.Updated, newTestEntry("1",
+				measurements.MeasurementRecord{Timestamp: 1000, MeasurementName: "A", MeasurementValue: math.NaN()},
+				measurements.MeasurementRecord{Timestamp: 1000, MeasurementName: "B", MeasurementValue: math.Inf(1)},
+				measurements.MeasurementRecord{Timestamp: 1000, MeasurementName: "C", MeasurementValue: math.Inf(-1)},
+				measurements.MeasurementRecord{Timestamp: 1000, MeasurementName: "D", MeasurementValue: int32(0)},
+				measurements.MeasurementRecord{Timestamp: 1000, MeasurementName: "E", MeasurementValue: -1.5}))},
+			body: "E,cell_global_id=13842601454c001,cell_object_id=1,node_id=e2:1,plmn_id=138426 value=-1.5 1000\n",
+		},
+		{
+			name: "no point",
+			events: []measurements.Event{
+				newTestEvent(measurements.Deleted, newTestEntry("1",
+					measurements.MeasurementRecord{Timestamp: 1000, MeasurementName: "A", MeasurementValue: int64(1)})),
+				newTestEvent(measurements.Updated, newTestEntry("2",
+					measurements.MeasurementRecord{Timestamp: 1000, MeasurementName: "A", MeasurementValue: math.NaN()})),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, requests := newTestServer(t, http.StatusNoContent)
+			sink, err := NewSink(server.URL + "/api/v2/write?org=sdran&bucket=kpimon")
+			assert.NoError(t, err)
+			assert.NoError(t, sink.Write(context.Background(), test.events))
+			assert.NoError(t, sink.Close())
+			if test.body == "" {
+				assert.Empty(t, *requests)
+				return
+			}
+			assert.Len(t, *requests, 1)
+			assert.Equal(t, test.body, (*requests)[0].body)
+			assert.Equal(t, "/api/v2/write?org=sdran&bucket=kpimon", (*requests)[0].path)
+			assert.Equal(t, "text/plain; charset=utf-8", (*requests)[0].contentType)
+			assert.Equal(t, "", (*requests)[0].authorization)
+		})
+	}
+}
+
+func TestWriteToken(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusNoContent)
+	sink, err := NewSink(server.URL+"/api/v2/write?org=sdran&bucket=kpimon", WithToken("secret"))
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Write(context.Background(), []measurements.Event{newTestEvent(measurements.Updated, newTestEntry("1",
+		measurements.MeasurementRecord{Timestamp: 1000, MeasurementName: "A", MeasurementValue: int64(1)}))}))
+	assert.Len(t, *requests, 1)
+	assert.Equal(t, "Token secret", (*requests)[0].authorization)
+}
+
+func TestWriteError(t *testing.T) {
+	events := []measurements.Event{newTestEvent(measurements.Updated, newTestEntry("1",
+		measurements.MeasurementRecord{Timestamp: 1000, MeasurementName: "A", MeasurementValue: int64(1)}))}
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusServiceUnavailable} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			server, _ := newTestServer(t, status)
+			sink, err := NewSink(server.URL + "/write?db=kpimon")
+			assert.NoError(t, err)
+			err = sink.Write(context.Background(), events)
+			assert.True(t, errors.IsUnavailable(err), err)
+			// the error reports the status and the body of the response
+			assert.Contains(t, err.Error(), http.StatusText(status))
+			assert.Contains(t, err.Error(), "unable to parse points")
+		})
+	}
+
+	// the unreachable endpoints fail too
+	server, _ := newTestServer(t, http.StatusNoContent)
+	sink, err := NewSink(server.URL + "/write?db=kpimon")
+	assert.NoError(t, err)
+	server.Close()
+	assert.True(t, errors.IsUnavailable(sink.Write(context.Background(), events)))
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package influx
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+)
+
+const (
+	nodeIDTag       = "node_id"
+	cellObjectIDTag = "cell_object_id"
+	cellGlobalIDTag = "cell_global_id"
+	plmnIDTag       = "plmn_id"
+	valueField      = "value"
+)
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `, "\n", `\n`, "\r", `\r`)
+	keyEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `, "\n", `\n`, "\r", `\r`)
+)
+
+// appendLines appends the line protocol points of the records of an entry, one per record with a value
+// The KPM measurement name is the measurement, the cell identities and the KPM labels are the tags
+// and the value is the integer or float value field; the record timestamp is kept in nanoseconds.
+func appendLines(b *strings.Builder, entry *measurements.Entry) int {
+	tags := map[string]string{
+		nodeIDTag:       entry.Key.NodeID,
+		cellObjectIDTag: entry.Key.CellIdentity.CellID,
+		cellGlobalIDTag: entry.CellGlobalID,
+	}
+	if entry.PlmnID != 0 {
+		// hex encoded like the PLMN ID labels
+		tags[plmnIDTag] = fmt.Sprintf("%06x", entry.PlmnID)
+	}
+
+	lines := 0
+	for _, measItem := range entry.Value {
+		for _, record := range measItem.MeasurementRecords {
+			var field string
+			switch val := record.MeasurementValue.(type) {
+			case int64:
+				field = strconv.FormatInt(val, 10) + "i"
+			case float64:
+				if math.IsNaN(val) || math.IsInf(val, 0) {
+					// not supported by the line protocol
+					continue
+				}
+				field = strconv.FormatFloat(val, 'g', -1, 64)
+			default:
+				// the records without value have no field
+				continue
+			}
+
+			b.WriteString(measurementEscaper.Replace(record.MeasurementName))
+			writeTags(b, tags, record.Labels)
+			b.WriteString(" ")
+			b.WriteString(valueField)
+			b.WriteString("=")
+			b.WriteString(field)
+			b.WriteString(" ")
+			b.WriteString(strconv.FormatUint(record.Timestamp, 10))
+			b.WriteString("\n")
+			lines++
+		}
+	}
+	return lines
+}
+
+// writeTags writes the tags of a point sorted by key, as recommended by InfluxDB; the cell tags take precedence
+// over the labels with the same name and the empty tags are left out
+func writeTags(b *strings.Builder, tags map[string]string, labels map[string]string) {
+	keys := make([]string, 0, len(tags)+len(labels))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	for key := range labels {
+		if _, ok := tags[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, ok := tags[key]
+		if !ok {
+			value = labels[key]
+		}
+		if value == "" {
+			continue
+		}
+		b.WriteString(",")
+		b.WriteString(keyEscaper.Replace(key))
+		b.WriteString("=")
+		b.WriteString(keyEscaper.Replace(value))
+	}
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package influx
+
+import (
+	"net/http"
+)
+
+// Options InfluxDB sink options
+type Options struct {
+	// Token is the API token sent in the Authorization header, if any
+	Token string
+	// Client is the HTTP client of the writes
+	Client *http.Client
+}
+
+// Option InfluxDB sink option interface
+type Option interface {
+	apply(*Options)
+}
+
+type funcOption struct {
+	f func(*Options)
+}
+
+func (f funcOption) apply(options *Options) {
+	f.f(options)
+}
+
+func newOption(f func(*Options)) Option {
+	return funcOption{
+		f: f,
+	}
+}
+
+// WithToken sets the API token sent in the Authorization header
+func WithToken(token string) Option {
+	return newOption(func(options *Options) {
+		options.Token = token
+	})
+}
+
+// WithClient sets the HTTP client of the writes, e.g. to set its TLS configuration
+func WithClient(client *http.Client) Option {
+	return newOption(func(options *Options) {
+		options.Client = client
+	})
+}
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"github.com/onosproject/onos-kpimon/pkg/export"
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// Name is the name of the Kafka sink
+	Name = "kafka"
+	// DefaultTopic is the default topic the measurement updates are published to
+	DefaultTopic = "onos-kpimon-measurements"
+
+	// EventTypeHeader is the message header holding the type of the measurement update, e.g. Created or Updated
+	EventTypeHeader = "kpimon-event-type"
+	// SequenceHeader is the message header holding the sequence number of the measurement update
+	SequenceHeader = "kpimon-sequence"
+
+	clientID = "onos-kpimon"
+)
+
+// Sink publishes the measurement updates to a Kafka topic
+// Each update is a message keyed by the node ID and the cell object ID of the cell, "<node ID>:<cell object ID>",
+// whose value holds the v2 API cell measurements; the deleted cells are published as tombstones, without value.
+// The messages are acknowledged by all of the in-sync replicas and the messages of a cell are kept in order,
+// so that, with the retries of the exporter, the updates are delivered at least once and in order.
+type Sink struct {
+	brokers  []string
+	options  Options
+	mu       sync.Mutex
+	producer sarama.SyncProducer
+}
+
+// NewSink creates a new Kafka sink of the given brokers; the brokers are connected to on the first write
+func NewSink(brokers []string, opts ...Option) (*Sink, error) {
+	options := Options{
+		Topic:    DefaultTopic,
+		Encoding: JSONEncoding,
+	}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	if len(brokers) == 0 {
+		return nil, errors.NewInvalid("no Kafka broker")
+	}
+	if options.Topic == "" {
+		return nil, errors.NewInvalid("no Kafka topic")
+	}
+	switch options.Encoding {
+	case JSONEncoding, ProtobufEncoding:
+	default:
+		return nil, errors.NewInvalid("unknown Kafka message encoding %s", options.Encoding)
+	}
+
+	config := options.Config
+	if config == nil {
+		config = sarama.NewConfig()
+		config.ClientID = clientID
+	}
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Successes = true
+	config.Producer.Return.Errors = true
+	config.Producer.Partitioner = sarama.NewHashPartitioner
+	// a single in-flight request keeps the messages in order across the producer retries
+	config.Net.MaxOpenRequests = 1
+	if err := config.Validate(); err != nil {
+		return nil, errors.NewInvalid("invalid Kafka producer configuration: %v", err)
+	}
+	options.Config = config
+
+	return &Sink{
+		brokers: brokers,
+		options: options,
+	}, nil
+}
+
+// Name gets the name of the sink
+func (s *Sink) Name() string {
+	return Name
+}
+
+// Write publishes a batch of measurement updates
+// The batch is retried as a whole if any of its messages fails, so that the messages may be published more than once.
+func (s *Sink) Write(_ context.Context, events []measurements.Event) error {
+	messages := make([]*sarama.ProducerMessage, 0, len(events))
+	for _, event := range events {
+		message, err := s.newMessage(event)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	producer, err := s.getProducer()
+	if err != nil {
+		return err
+	}
+	err = producer.SendMessages(messages)
+	if err != nil {
+		if producerErrors, ok := err.(sarama.ProducerErrors); ok {
+			return errors.NewUnavailable("failed to publish %d of %d messages to %s: %v",
+				len(producerErrors), len(messages), s.options.Topic, producerErrors[0].Err)
+		}
+		return errors.NewUnavailable("failed to publish to %s: %v", s.options.Topic, err)
+	}
+	return nil
+}
+
+// Close closes the producer
+func (s *Sink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.producer == nil {
+		return nil
+	}
+	err := s.producer.Close()
+	s.producer = nil
+	return err
+}
+
+// getProducer gets the producer, connecting to the brokers if they are not connected yet
+func (s *Sink) getProducer() (sarama.SyncProducer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.producer != nil {
+		return s.producer, nil
+	}
+	producer, err := sarama.NewSyncProducer(s.brokers, s.options.Config)
+	if err != nil {
+		return nil, errors.NewUnavailable("failed to connect to the Kafka brokers %s: %v", strings.Join(s.brokers, ","), err)
+	}
+	s.producer = producer
+	return producer, nil
+}
+
+func (s *Sink) newMessage(event measurements.Event) (*sarama.ProducerMessage, error) {
+	entry := event.Value
+	message := &sarama.ProducerMessage{
+		Topic: s.options.Topic,
+		Key:   sarama.StringEncoder(entry.Key.NodeID + ":" + entry.Key.CellIdentity.CellID),
+		Headers: []sarama.RecordHeader{
+			{
+				Key:   []byte(EventTypeHeader),
+				Value: []byte(event.Type.String()),
+			},
+			{
+				Key:   []byte(SequenceHeader),
+				Value: []byte(strconv.FormatUint(event.Sequence, 10)),
+			},
+		},
+	}
+	if event.Type == measurements.Deleted {
+		return message, nil
+	}
+
+	var value []byte
+	var err error
+	cellMeasurements := export.NewCellMeasurements(entry)
+	if s.options.Encoding == ProtobufEncoding {
+		value, err = proto.Marshal(cellMeasurements)
+	} else {
+		value, err = protojson.Marshal(cellMeasurements)
+	}
+	if err != nil {
+		return nil, errors.NewInvalid("failed to encode the measurements of %s: %v", message.Key, err)
+	}
+	message.Value = sarama.ByteEncoder(value)
+	return message, nil
+}
+
+var _ export.Sink = &Sink{}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	kpimonv2api "github.com/onosproject/onos-kpimon/api/kpimon/v2"
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestEvent(eventType measurements.MeasurementEvent, cellID string, sequence uint64) measurements.Event {
+	key := measurements.NewKey(measurements.CellIdentity{CellID: cellID}, "e2:1")
+	return measurements.Event{
+		Key:      key,
+		Type:     eventType,
+		Sequence: sequence,
+		Value: &measurements.Entry{
+			Key: key,
+			Value: []measurements.MeasurementItem{{
+				MeasurementRecords: []measurements.MeasurementRecord{{Timestamp: 1, MeasurementName: "A", MeasurementValue: int64(3)}},
+			}},
+			CellGlobalID: "13842601454c001",
+		},
+	}
+}
+
+// newTestSink creates a sink publishing to a mock producer
+func newTestSink(t *testing.T, opts ...Option) (*Sink, *mocks.SyncProducer) {
+	sink, err := NewSink([]string{"kafka:9092"}, opts...)
+	assert.NoError(t, err)
+	producer := mocks.NewSyncProducer(t, sink.options.Config)
+	sink.producer = producer
+	return sink, producer
+}
+
+// recordMessage appends the sent messages to the given slice
+func recordMessage(sent *[]*sarama.ProducerMessage) mocks.MessageChecker {
+	return func(message *sarama.ProducerMessage) error {
+		*sent = append(*sent, message)
+		return nil
+	}
+}
+
+func getHeaders(message *sarama.ProducerMessage) map[string]string {
+	headers := make(map[string]string)
+	for _, header := range message.Headers {
+		headers[string(header.Key)] = string(header.Value)
+	}
+	return headers
+}
+
+func TestNewSink(t *testing.T) {
+	_, err := NewSink(nil)
+	assert.True(t, errors.IsInvalid(err))
+	_, err = NewSink([]string{"kafka:9092"}, WithTopic(""))
+	assert.True(t, errors.IsInvalid(err))
+	_, err = NewSink([]string{"kafka:9092"}, WithEncoding("avro"))
+	assert.True(t, errors.IsInvalid(err))
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding Encoding
+		decode   func([]byte, proto.Message) error
+	}{
+		{
+			name:     "json",
+			encoding: JSONEncoding,
+			decode:   protojson.Unmarshal,
+		},
+		{
+			name:     "protobuf",
+			encoding: ProtobufEncoding,
+			decode:   proto.Unmarshal,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var sent []*sarama.ProducerMessage
+			sink, producer := newTestSink(t, WithTopic("kpis"), WithEncoding(test.encoding))
+			producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(recordMessage(&sent))
+			producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(recordMessage(&sent))
+
+			assert.NoError(t, sink.Write(context.Background(), []measurements.Event{
+				newTestEvent(measurements.Updated, "1", 1),
+				newTestEvent(measurements.Deleted, "2", 2),
+			}))
+			assert.NoError(t, sink.Close())
+			assert.Len(t, sent, 2)
+
+			// the messages are keyed by cell and hold the v2 API cell measurements
+			assert.Equal(t, "kpis", sent[0].Topic)
+			key, err := sent[0].Key.Encode()
+			assert.NoError(t, err)
+			assert.Equal(t, "e2:1:1", string(key))
+			assert.Equal(t, map[string]string{EventTypeHeader: "Updated", SequenceHeader: "1"}, getHeaders(sent[0]))
+			value, err := sent[0].Value.Encode()
+			assert.NoError(t, err)
+			cellMeasurements := &kpimonv2api.CellMeasurements{}
+			assert.NoError(t, test.decode(value, cellMeasurements))
+			assert.Equal(t, "13842601454c001", cellMeasurements.GetCell().GetCellGlobalId())
+			assert.Equal(t, int64(3), cellMeasurements.GetPeriods()[0].GetRecords()[0].GetValue().GetInteger())
+
+			// the deleted cells are tombstones of the same topic, so that they compact the earlier messages of the cell
+			assert.Equal(t, "kpis", sent[1].Topic)
+			key, err = sent[1].Key.Encode()
+			assert.NoError(t, err)
+			assert.Equal(t, "e2:1:2", string(key))
+			assert.Equal(t, map[string]string{EventTypeHeader: "Deleted", SequenceHeader: "2"}, getHeaders(sent[1]))
+			assert.Nil(t, sent[1].Value)
+		})
+	}
+}
+
+func TestWriteRetried(t *testing.T) {
+	var sent []*sarama.ProducerMessage
+	sink, producer := newTestSink(t)
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndFail(recordMessage(&sent), sarama.ErrNotEnoughReplicas)
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(recordMessage(&sent))
+	events := []measurements.Event{newTestEvent(measurements.Updated, "1", 1)}
+
+	// a failed batch is published again when the exporter retries it
+	err := sink.Write(context.Background(), events)
+	assert.True(t, errors.IsUnavailable(err), err)
+	assert.NoError(t, sink.Write(context.Background(), events))
+	assert.NoError(t, sink.Close())
+	assert.Len(t, sent, 2)
+	for _, message := range sent {
+		key, err := message.Key.Encode()
+		assert.NoError(t, err)
+		assert.Equal(t, "e2:1:1", string(key))
+	}
+}
+
+func TestWriteUnavailableBroker(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	addr := broker.Addr()
+	broker.Close()
+
+	config := sarama.NewConfig()
+	config.Metadata.Retry.Max = 0
+	sink, err := NewSink([]string{addr}, WithConfig(config))
+	assert.NoError(t, err)
+	err = sink.Write(context.Background(), []measurements.Event{newTestEvent(measurements.Updated, "1", 1)})
+	assert.True(t, errors.IsUnavailable(err), err)
+	assert.NoError(t, sink.Close())
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"github.com/Shopify/sarama"
+)
+
+// Encoding is the encoding of the message values
+type Encoding string
+
+const (
+	// JSONEncoding encodes the values in the JSON mapping of protobuf
+	JSONEncoding Encoding = "json"
+	// ProtobufEncoding encodes the values in the protobuf binary format
+	ProtobufEncoding Encoding = "protobuf"
+)
+
+// Options Kafka sink options
+type Options struct {
+	// Topic is the topic all of the measurement updates are published to; the updates of a cell stay in one topic
+	// so that they are kept in order and that the tombstones compact them
+	Topic string
+	// Encoding is the encoding of the message values
+	Encoding Encoding
+	// Config is the configuration of the producer; the producer settings the delivery guarantees rely on are overridden
+	Config *sarama.Config
+}
+
+// Option Kafka sink option interface
+type Option interface {
+	apply(*Options)
+}
+
+type funcOption struct {
+	f func(*Options)
+}
+
+func (f funcOption) apply(options *Options) {
+	f.f(options)
+}
+
+func newOption(f func(*Options)) Option {
+	return funcOption{
+		f: f,
+	}
+}
+
+// WithTopic sets the topic all of the measurement updates are published to
+func WithTopic(topic string) Option {
+	return newOption(func(options *Options) {
+		options.Topic = topic
+	})
+}
+
+// WithEncoding sets the encoding of the message values
+func WithEncoding(encoding Encoding) Option {
+	return newOption(func(options *Options) {
+		options.Encoding = encoding
+	})
+}
+
+// WithConfig sets the configuration of the producer, e.g. its TLS or SASL settings
+func WithConfig(config *sarama.Config) Option {
+	return newOption(func(options *Options) {
+		options.Config = config
+	})
+}
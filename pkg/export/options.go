@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import "time"
+
+// Options sink delivery options
+type Options struct {
+	// BatchSize is the maximum number of events written at once
+	BatchSize int
+	// FlushInterval is the maximum time an event waits for its batch to fill up
+	FlushInterval time.Duration
+	// BufferSize is the maximum number of events waiting to be written; the oldest are dropped beyond it
+	BufferSize int
+	// MaxRetries is the number of times a failed write is retried before the batch is given up on
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, doubled on each retry
+	RetryBackoff time.Duration
+	// CloseTimeout is the maximum time spent writing the buffered events once the exporter stops
+	CloseTimeout time.Duration
+}
+
+// Option sink delivery option interface
+type Option interface {
+	apply(*Options)
+}
+
+type funcOption struct {
+	f func(*Options)
+}
+
+func (f funcOption) apply(options *Options) {
+	f.f(options)
+}
+
+func newOption(f func(*Options)) Option {
+	return funcOption{
+		f: f,
+	}
+}
+
+// WithBatchSize sets the maximum number of events written at once
+func WithBatchSize(size int) Option {
+	return newOption(func(options *Options) {
+		options.BatchSize = size
+	})
+}
+
+// WithFlushInterval sets the maximum time an event waits for its batch to fill up
+func WithFlushInterval(interval time.Duration) Option {
+	return newOption(func(options *Options) {
+		options.FlushInterval = interval
+	})
+}
+
+// WithBufferSize sets the maximum number of events waiting to be written
+func WithBufferSize(size int) Option {
+	return newOption(func(options *Options) {
+		options.BufferSize = size
+	})
+}
+
+// WithMaxRetries sets the number of times a failed write is retried
+func WithMaxRetries(maxRetries int) Option {
+	return newOption(func(options *Options) {
+		options.MaxRetries = maxRetries
+	})
+}
+
+// WithRetryBackoff sets the delay before the first retry of a failed write
+func WithRetryBackoff(backoff time.Duration) Option {
+	return newOption(func(options *Options) {
+		options.RetryBackoff = backoff
+	})
+}
+
+// WithCloseTimeout sets the maximum time spent writing the buffered events once the exporter stops
+func WithCloseTimeout(timeout time.Duration) Option {
+	return newOption(func(options *Options) {
+		options.CloseTimeout = timeout
+	})
+}
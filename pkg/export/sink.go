@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"context"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+)
+
+// Sink is an export destination of the measurement updates
+type Sink interface {
+	// Name gets the name of the sink, unique among the sinks of an exporter
+	Name() string
+
+	// Write writes a batch of measurement events in the order they occurred
+	// The batch is retried if an error is returned, so that a sink should write all of it or nothing.
+	Write(ctx context.Context, events []measurements.Event) error
+
+	// Close closes the sink once the exporter stops
+	Close() error
+}
+
+// Status is the delivery status of a sink
+type Status struct {
+	// Name is the name of the sink
+	Name string
+	// Healthy is false while the writes of the sink fail
+	Healthy bool
+	// LastError is the error of the last failed write
+	LastError string
+	// LastWrite is the time of the last successful write
+	LastWrite time.Time
+	// Buffered is the number of events waiting to be written
+	Buffered int
+	// Delivered is the number of events written
+	Delivered uint64
+	// Dropped is the number of events dropped because the buffer of the sink was full
+	Dropped uint64
+	// Failed is the number of events given up on after the retries
+	Failed uint64
+	// Retries is the number of retried writes
+	Retries uint64
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/onosproject/onos-kpimon/pkg/election"
+	"github.com/onosproject/onos-kpimon/pkg/store/generic"
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+)
+
+// startElection runs for leadership; the leader owns the E2 subscriptions and the topo updates
+// while the standbys replicate the leader state to serve the northbound reads
+func (m *Manager) startElection() error {
+	candidate, err := m.getCandidate()
+	if err != nil {
+		return err
+	}
+	leaderElection, err := election.NewLeaseFileElection(m.config.LeaseFile, candidate,
+		election.WithLeaseDuration(m.config.LeaseDuration))
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	ch := make(chan election.Term)
+	err = leaderElection.Watch(ctx, ch)
+	if err != nil {
+		return err
+	}
+	err = leaderElection.Campaign(ctx)
+	if err != nil {
+		return err
+	}
+	go m.watchTerms(ctx, candidate, ch)
+	return nil
+}
+
+func (m *Manager) watchTerms(ctx context.Context, candidate election.Candidate, ch <-chan election.Term) {
+	var replicaAddress string
+	cancelReplica := func() {}
+	for term := range ch {
+		if term.Leader.Address != replicaAddress {
+			cancelReplica()
+			cancelReplica = func() {}
+			replicaAddress = ""
+		}
+
+		if term.Leader.ID == candidate.ID {
+			if !m.leader.Load() {
+				log.Infof("Starting the subscriptions as the leader of term %d", term.Term)
+				m.leader.Store(true)
+				err := m.subManager.Start()
+				if err != nil {
+					log.Warn(err)
+				}
+			}
+			continue
+		}
+
+		if m.leader.Load() {
+			// the subscriptions are closed so that the new leader can take them over
+			log.Warnf("Candidate %s lost the leadership in term %d, returning to standby", candidate.ID, term.Term)
+			m.leader.Store(false)
+			err := m.subManager.Stop()
+			if err != nil {
+				log.Warn(err)
+			}
+		}
+
+		if term.HasLeader() && replicaAddress == "" {
+			replicaCtx, cancel := context.WithCancel(ctx)
+			err := m.replicator.Replicate(replicaCtx, term.Leader.Address)
+			if err != nil {
+				log.Warn(err)
+				cancel()
+				continue
+			}
+			cancelReplica = cancel
+			replicaAddress = term.Leader.Address
+		}
+	}
+	cancelReplica()
+}
+
+// leaderStore is a measurement store whose watchers only receive the events put while the replica is the leader,
+// so that the consumers running on every replica ignore the state a standby restores from the leader
+type leaderStore struct {
+	measurements.Store
+	leader *atomic.Bool
+}
+
+func (s *leaderStore) Watch(ctx context.Context, ch chan<- measurements.Event, opts ...generic.WatchOption) error {
+	events := make(chan measurements.Event)
+	err := s.Store.Watch(ctx, events, opts...)
+	if err != nil {
+		close(ch)
+		return err
+	}
+	go func() {
+		defer close(ch)
+		for e := range events {
+			if s.leader.Load() {
+				ch <- e
+			}
+		}
+	}()
+	return nil
+}
+
+// getCandidate gets the identity of the replica in the leader election
+func (m *Manager) getCandidate() (election.Candidate, error) {
+	candidate := election.Candidate{
+		ID: election.CandidateID(m.config.CandidateID),
+	}
+	if candidate.ID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return election.Candidate{}, err
+		}
+		candidate.ID = election.CandidateID(hostname)
+	}
+	address, err := getAdvertiseAddress(m.config)
+	if err != nil {
+		return election.Candidate{}, err
+	}
+	candidate.Address = address
+	return candidate, nil
+}
+
+// getAdvertiseAddress gets the northbound address the other replicas reach the replica at
+func getAdvertiseAddress(config Config) (string, error) {
+	if config.AdvertiseAddress != "" {
+		return config.AdvertiseAddress, nil
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%d", hostname, config.GRPCPort), nil
+}
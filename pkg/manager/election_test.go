@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manager
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderStore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var leader atomic.Bool
+	store := measurements.NewStore()
+	s := &leaderStore{Store: store, leader: &leader}
+	ch := make(chan measurements.Event)
+	assert.NoError(t, s.Watch(ctx, ch))
+
+	tests := []struct {
+		name      string
+		leader    bool
+		delivered bool
+	}{
+		{"standby", false, false},
+		{"leader", true, true},
+		{"stepped down", false, false},
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			leader.Store(test.leader)
+			key := measurements.NewKey(measurements.CellIdentity{CellID: "cell1"}, "node1")
+			_, err := store.Put(ctx, key, []measurements.MeasurementItem{{
+				MeasurementRecords: []measurements.MeasurementRecord{{
+					Timestamp:        uint64(i),
+					MeasurementName:  "A",
+					MeasurementValue: int64(i),
+				}},
+			}})
+			assert.NoError(t, err)
+			select {
+			case e := <-ch:
+				assert.True(t, test.delivered)
+				assert.Equal(t, int64(i), e.Value.Value[0].MeasurementRecords[0].MeasurementValue)
+			case <-time.After(100 * time.Millisecond):
+				assert.False(t, test.delivered)
+			}
+		})
+	}
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		_, ok := <-ch
+		return !ok
+	}, time.Second, time.Millisecond)
+}
@@ -5,12 +5,28 @@
 package manager
 
 import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
 	"github.com/onosproject/onos-kpimon/pkg/broker"
 	appConfig "github.com/onosproject/onos-kpimon/pkg/config"
+	"github.com/onosproject/onos-kpimon/pkg/export"
+	"github.com/onosproject/onos-kpimon/pkg/metrics"
 	nbi "github.com/onosproject/onos-kpimon/pkg/northbound"
+	"github.com/onosproject/onos-kpimon/pkg/replica"
+	"github.com/onosproject/onos-kpimon/pkg/rnib"
+	"github.com/onosproject/onos-kpimon/pkg/sharding"
+	"github.com/onosproject/onos-kpimon/pkg/snapshot"
 	"github.com/onosproject/onos-kpimon/pkg/southbound/e2/subscription"
 	"github.com/onosproject/onos-kpimon/pkg/store/actions"
+	"github.com/onosproject/onos-kpimon/pkg/store/history"
 	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-kpimon/pkg/webhook"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 	"github.com/onosproject/onos-lib-go/pkg/northbound"
 )
@@ -28,19 +44,51 @@ type Config struct {
 	ConfigPath  string
 	SMName      string
 	SMVersion   string
+	SnapshotDir string
+	// LeaseFile enables the active/standby mode with a lease file shared by the replicas
+	LeaseFile     string
+	LeaseDuration time.Duration
+	// CandidateID identifies the replica in the leader election; it defaults to the host name
+	CandidateID string
+	// AdvertiseAddress is the northbound address the standbys replicate from; it defaults to the host name and gRPC port
+	AdvertiseAddress string
+	// ShardID identifies the replica in the replica set sharing the E2 nodes; it defaults to the host name
+	ShardID string
+	// ShardLeaseDir is a directory shared by the replicas whose live leases make up the replica set;
+	// the replica set of the app config is used if it is empty
+	ShardLeaseDir string
+	// TopoWriteRate is the maximum number of cell and E2 node aspect writes per second to topo
+	TopoWriteRate float64
+	// MetricsPort is the port of the Prometheus metrics HTTP endpoint; zero disables it
+	MetricsPort int
 }
 
 // NewManager generates the new KPIMON xAPP manager
-func NewManager(config Config) *Manager {
+func NewManager(config Config) (*Manager, error) {
 	appCfg, err := appConfig.NewConfig(config.ConfigPath)
 	if err != nil {
 		log.Warn(err)
 	}
 	subscriptionBroker := broker.NewBroker()
-	measStore := measurements.NewStore()
+	measStore := measurements.NewStore(getMeasurementStoreOptions(appCfg)...)
 	actionsStore := actions.NewStore()
+	historyStore := history.NewStore(getHistoryStoreOptions(appCfg)...)
+
+	snapshots := snapshot.NewManager(config.SnapshotDir, measStore, actionsStore, subscriptionBroker)
+	sharder := newSharder(config, appCfg)
+	// the R-NIB client and its topo cache are shared by all of the components
+	rnibClient, err := rnib.NewCachedClient(context.Background())
+	if err != nil {
+		log.Warn(err)
+		return nil, err
+	}
+
+	topoWriter := rnib.NewWriter(context.Background(), rnibClient, rnib.WithWriteRate(config.TopoWriteRate))
 
-	subManager, err := subscription.NewManager(
+	notifier := webhook.NewNotifier(getWebhookOptions(appCfg)...)
+	addWebhookEndpoints(notifier, appCfg)
+
+	subOpts := []subscription.Option{
 		subscription.WithE2TAddress("onos-e2t", 5150),
 		subscription.WithServiceModel(subscription.ServiceModelName(config.SMName),
 			subscription.ServiceModelVersion(config.SMVersion)),
@@ -48,19 +96,40 @@ func NewManager(config Config) *Manager {
 		subscription.WithAppID("onos-kpimon"),
 		subscription.WithBroker(subscriptionBroker),
 		subscription.WithActionStore(actionsStore),
-		subscription.WithMeasurementStore(measStore))
-
+		subscription.WithMeasurementStore(measStore),
+		subscription.WithSharder(sharder),
+		subscription.WithFailureHandler(func(e2NodeID topoapi.ID, err error) {
+			notifier.Publish(webhook.NewSubscriptionFailedEvent(string(e2NodeID), err))
+		}),
+		// the cell aspect updates of the monitors are coalesced and throttled
+		subscription.WithRNIBClient(topoWriter),
+	}
+	subManager, err := subscription.NewManager(subOpts...)
 	if err != nil {
 		log.Warn(err)
+		return nil, err
 	}
 
+	exporter := export.NewExporter(getExportOptions(appCfg)...)
+	addExportSinks(exporter, appCfg)
+
 	manager := &Manager{
 		appConfig:        appCfg,
+		metrics:          metrics.NewExporter(measStore, getMetricsOptions(appCfg)...),
+		exporter:         exporter,
+		notifier:         notifier,
 		config:           config,
 		subManager:       subManager,
 		measurementStore: measStore,
+		historyStore:     historyStore,
+		snapshots:        snapshots,
+		replicator:       replica.NewReplicator(snapshots),
+		sharder:          sharder,
+		rnibClient:       rnibClient,
+		topoWriter:       topoWriter,
+		streams:          subscriptionBroker,
 	}
-	return manager
+	return manager, nil
 }
 
 // Manager is an abstract struct for manager
@@ -68,7 +137,19 @@ type Manager struct {
 	appConfig        appConfig.Config
 	config           Config
 	measurementStore measurements.Store
-	subManager       subscription.Manager
+	historyStore     history.Store
+	subManager       *subscription.Manager
+	snapshots        *snapshot.Manager
+	replicator       *replica.Replicator
+	sharder          sharding.Sharder
+	rnibClient       rnib.Client
+	topoWriter       *rnib.Writer
+	streams          broker.Broker
+	metrics          *metrics.Exporter
+	exporter         *export.Exporter
+	notifier         *webhook.Notifier
+	// leader is true while the replica owns the subscriptions, which is always the case without a lease file
+	leader atomic.Bool
 }
 
 // Run runs KPIMON manager
@@ -82,21 +163,69 @@ func (m *Manager) Run() {
 // Close closes manager
 func (m *Manager) Close() {
 	log.Info("closing Manager")
+	if err := m.measurementStore.Close(); err != nil {
+		log.Warn(err)
+	}
 }
 
 func (m *Manager) start() error {
-	err := m.startNorthboundServer()
+	// without a lease file the replica is the only one and leads from the start
+	m.leader.Store(m.config.LeaseFile == "")
+	// the consumers run on every replica but only see the measurements put by the leader
+	leaderMeasurements := &leaderStore{
+		Store:  m.measurementStore,
+		leader: &m.leader,
+	}
+	err := history.Record(context.Background(), leaderMeasurements, m.historyStore)
 	if err != nil {
 		log.Warn(err)
 		return err
 	}
 
-	err = m.subManager.Start()
+	if m.exporter.Len() > 0 {
+		err = m.exporter.Run(context.Background(), leaderMeasurements)
+		if err != nil {
+			log.Warn(err)
+			return err
+		}
+	}
+
+	if m.notifier.Len() > 0 {
+		err = m.notifier.Run(context.Background(), leaderMeasurements)
+		if err != nil {
+			log.Warn(err)
+			return err
+		}
+	}
+
+	err = m.startNorthboundServer()
 	if err != nil {
 		log.Warn(err)
 		return err
 	}
 
+	if m.config.MetricsPort != 0 {
+		err = m.startMetricsServer()
+		if err != nil {
+			log.Warn(err)
+			return err
+		}
+	}
+
+	if m.config.LeaseFile == "" {
+		err = m.subManager.Start()
+		if err != nil {
+			log.Warn(err)
+			return err
+		}
+		return nil
+	}
+
+	err = m.startElection()
+	if err != nil {
+		log.Warn(err)
+		return err
+	}
 	return nil
 }
 
@@ -109,7 +238,9 @@ func (m *Manager) startNorthboundServer() error {
 		true,
 		northbound.SecurityConfig{}))
 
-	s.AddService(nbi.NewService(m.measurementStore))
+	s.AddService(nbi.NewService(m.measurementStore, m.sharder, m.rnibClient))
+	s.AddService(nbi.NewV2Service(m.measurementStore, m.historyStore, m.sharder, m.rnibClient, m.streams, m.subManager))
+	s.AddService(nbi.NewAdminService(m.snapshots))
 
 	doneCh := make(chan error)
 	go func() {
@@ -124,6 +255,28 @@ func (m *Manager) startNorthboundServer() error {
 	return <-doneCh
 }
 
+func (m *Manager) startMetricsServer() error {
+	mux := http.NewServeMux()
+	mux.Handle(metrics.Path, metrics.NewHandler(m.metrics, m.exporter, m.notifier, m.topoWriter))
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", m.config.MetricsPort))
+	if err != nil {
+		return err
+	}
+	log.Infof("Started metrics endpoint on %s%s", lis.Addr(), metrics.Path)
+	go func() {
+		err := server.Serve(lis)
+		if err != nil && err != http.ErrServerClosed {
+			log.Error(err)
+		}
+	}()
+	return nil
+}
+
 // GetMeasurementStore returns measurement store
 func (m *Manager) GetMeasurementStore() measurements.Store {
 	return m.measurementStore
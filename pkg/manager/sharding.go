@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manager
+
+import (
+	"context"
+	"os"
+
+	appConfig "github.com/onosproject/onos-kpimon/pkg/config"
+	"github.com/onosproject/onos-kpimon/pkg/sharding"
+	"github.com/onosproject/onos-kpimon/pkg/utils"
+	"github.com/onosproject/onos-ric-sdk-go/pkg/config/event"
+)
+
+// newSharder creates the sharder of the replica and keeps its replica set in sync with the lease directory,
+// if any, or else with the app config
+func newSharder(config Config, appCfg *appConfig.AppConfig) sharding.Sharder {
+	shardID := config.ShardID
+	if shardID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			log.Warn(err)
+		}
+		shardID = hostname
+	}
+	sharder := sharding.NewSharder(sharding.MemberID(shardID))
+	if config.ShardLeaseDir != "" {
+		address, err := getAdvertiseAddress(config)
+		if err != nil {
+			log.Warn(err)
+			return sharder
+		}
+		err = sharding.JoinLeaseDir(context.Background(), sharder, config.ShardLeaseDir, address,
+			sharding.WithLeaseDuration(config.LeaseDuration))
+		if err != nil {
+			log.Warn(err)
+		}
+		return sharder
+	}
+	if appCfg == nil {
+		return sharder
+	}
+
+	setReplicaSet(sharder, appCfg)
+	go func() {
+		err := watchReplicaSet(context.Background(), sharder, appCfg)
+		if err != nil {
+			log.Warn(err)
+		}
+	}()
+	return sharder
+}
+
+// watchReplicaSet updates the replica set whenever it changes in the app config
+func watchReplicaSet(ctx context.Context, sharder sharding.Sharder, appCfg *appConfig.AppConfig) error {
+	ch := make(chan event.Event)
+	err := appCfg.Watch(ctx, ch)
+	if err != nil {
+		return err
+	}
+	for configEvent := range ch {
+		if configEvent.Key == utils.ReplicaSetConfigPath {
+			setReplicaSet(sharder, appCfg)
+		}
+	}
+	return nil
+}
+
+func setReplicaSet(sharder sharding.Sharder, appCfg *appConfig.AppConfig) {
+	members, err := sharding.ParseMembers(appCfg.GetReplicaSet())
+	if err != nil {
+		log.Warnf("Ignoring the invalid replica set: %v", err)
+		return
+	}
+	sharder.SetMembers(members)
+}
@@ -3,3 +3,199 @@
 // SPDX-License-Identifier: Apache-2.0
 
 package manager
+
+import (
+	"time"
+
+	appConfig "github.com/onosproject/onos-kpimon/pkg/config"
+	"github.com/onosproject/onos-kpimon/pkg/export"
+	"github.com/onosproject/onos-kpimon/pkg/export/file"
+	"github.com/onosproject/onos-kpimon/pkg/export/influx"
+	"github.com/onosproject/onos-kpimon/pkg/export/kafka"
+	"github.com/onosproject/onos-kpimon/pkg/metrics"
+	"github.com/onosproject/onos-kpimon/pkg/store/history"
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-kpimon/pkg/store/watcher"
+	"github.com/onosproject/onos-kpimon/pkg/webhook"
+)
+
+// getStaleTTL gets the duration after which the measurements of a cell that are not updated are stale
+// The TTL follows the report period, which may change at runtime.
+func getStaleTTL(appCfg *appConfig.AppConfig) func() time.Duration {
+	return func() time.Duration {
+		reportPeriod, err := appCfg.GetReportPeriod()
+		if err != nil {
+			return 0
+		}
+		return time.Duration(appCfg.GetStaleReportPeriods()*reportPeriod) * time.Millisecond
+	}
+}
+
+// getMeasurementStoreOptions gets the measurement store options from the app config
+func getMeasurementStoreOptions(appCfg *appConfig.AppConfig) []measurements.Option {
+	if appCfg == nil {
+		return nil
+	}
+
+	stalePolicy := measurements.EvictStale
+	switch appCfg.GetStalePolicy() {
+	case "evict":
+	case "mark":
+		stalePolicy = measurements.MarkStale
+	default:
+		log.Warnf("Unknown stale policy %s, evicting stale measurements", appCfg.GetStalePolicy())
+	}
+
+	slowConsumerPolicy := watcher.DropOldest
+	switch appCfg.GetSlowConsumerPolicy() {
+	case "drop_oldest":
+	case "drop_newest":
+		slowConsumerPolicy = watcher.DropNewest
+	case "disconnect":
+		slowConsumerPolicy = watcher.Disconnect
+	default:
+		log.Warnf("Unknown slow consumer policy %s, dropping the oldest events", appCfg.GetSlowConsumerPolicy())
+	}
+
+	priorityMeasurements := appCfg.GetPriorityMeasurements()
+	priorities := make(map[string]int, len(priorityMeasurements))
+	for i, name := range priorityMeasurements {
+		priorities[name] = len(priorityMeasurements) - i
+	}
+
+	return []measurements.Option{
+		measurements.WithStaleTTL(getStaleTTL(appCfg)),
+		measurements.WithStalePolicy(stalePolicy),
+		measurements.WithMaxRecords(int(appCfg.GetMaxRecords())),
+		measurements.WithMaxBytes(int(appCfg.GetMaxBytes())),
+		measurements.WithMeasurementPriorities(priorities),
+		measurements.WithReplayBufferSize(int(appCfg.GetReplayBufferSize())),
+		measurements.WithWatcherQueueSize(int(appCfg.GetWatcherQueueSize())),
+		measurements.WithSlowConsumerPolicy(slowConsumerPolicy),
+	}
+}
+
+// getMetricsOptions gets the metrics exporter options from the app config
+func getMetricsOptions(appCfg *appConfig.AppConfig) []metrics.Option {
+	if appCfg == nil {
+		return nil
+	}
+	return []metrics.Option{
+		metrics.WithMaxSeries(int(appCfg.GetMetricsMaxSeries())),
+	}
+}
+
+// getExportOptions gets the default delivery options of the export sinks from the app config
+func getExportOptions(appCfg *appConfig.AppConfig) []export.Option {
+	if appCfg == nil {
+		return nil
+	}
+	return []export.Option{
+		export.WithBatchSize(int(appCfg.GetExportBatchSize())),
+		export.WithFlushInterval(time.Duration(appCfg.GetExportFlushInterval()) * time.Millisecond),
+		export.WithBufferSize(int(appCfg.GetExportBufferSize())),
+		export.WithMaxRetries(int(appCfg.GetExportMaxRetries())),
+	}
+}
+
+// addExportSinks adds the export sinks enabled in the app config
+func addExportSinks(exporter *export.Exporter, appCfg *appConfig.AppConfig) {
+	if appCfg == nil {
+		return
+	}
+	if brokers := appCfg.GetKafkaBrokers(); len(brokers) > 0 {
+		sink, err := kafka.NewSink(brokers,
+			kafka.WithTopic(appCfg.GetKafkaTopic()),
+			kafka.WithEncoding(kafka.Encoding(appCfg.GetKafkaEncoding())))
+		if err != nil {
+			log.Warn(err)
+		} else if err := exporter.AddSink(sink); err != nil {
+			log.Warn(err)
+		}
+	}
+	if dir := appCfg.GetFileDir(); dir != "" {
+		fileOpts := []file.Option{
+			file.WithFormat(file.Format(appCfg.GetFileFormat())),
+			file.WithMaxSize(int64(appCfg.GetFileMaxSize()) * 1024 * 1024),
+			file.WithRotationInterval(time.Duration(appCfg.GetFileRotationInterval()) * time.Second),
+			file.WithMaxFiles(int(appCfg.GetFileMaxFiles())),
+		}
+		switch appCfg.GetFileCompression() {
+		case "gzip":
+			fileOpts = append(fileOpts, file.WithCompression())
+		case "none":
+		default:
+			log.Warnf("Unknown file compression %s, the rotated files are not compressed", appCfg.GetFileCompression())
+		}
+		sink, err := file.NewSink(dir, fileOpts...)
+		if err != nil {
+			log.Warn(err)
+		} else if err := exporter.AddSink(sink); err != nil {
+			log.Warn(err)
+		}
+	}
+	if writeURL := appCfg.GetInfluxURL(); writeURL != "" {
+		sink, err := influx.NewSink(writeURL, influx.WithToken(appCfg.GetInfluxToken()))
+		if err != nil {
+			log.Warn(err)
+		} else if err := exporter.AddSink(sink); err != nil {
+			log.Warn(err)
+		}
+	}
+}
+
+// getWebhookOptions gets the webhook notifier options from the app config
+func getWebhookOptions(appCfg *appConfig.AppConfig) []webhook.Option {
+	if appCfg == nil {
+		return nil
+	}
+	opts := []webhook.Option{
+		// the nodes whose cells are deleted have stopped reporting once their measurements would be stale
+		webhook.WithSilenceTimeout(getStaleTTL(appCfg)),
+	}
+	thresholds, err := webhook.ParseThresholds(appCfg.GetWebhookThresholds())
+	if err != nil {
+		log.Warn(err)
+		return opts
+	}
+	return append(opts, webhook.WithThresholds(thresholds...))
+}
+
+// addWebhookEndpoints adds the webhook endpoints set in the app config
+func addWebhookEndpoints(notifier *webhook.Notifier, appCfg *appConfig.AppConfig) {
+	if appCfg == nil {
+		return
+	}
+	events := make([]webhook.Type, 0)
+	for _, value := range appCfg.GetWebhookEvents() {
+		eventType, err := webhook.ParseType(value)
+		if err != nil {
+			log.Warn(err)
+			continue
+		}
+		events = append(events, eventType)
+	}
+	for _, webhookURL := range appCfg.GetWebhookURLs() {
+		err := notifier.AddEndpoint(webhookURL,
+			webhook.WithSecret(appCfg.GetWebhookSecret()),
+			webhook.WithEvents(events...),
+			webhook.WithRateLimit(float64(appCfg.GetWebhookRateLimit())/60),
+			webhook.WithMaxRetries(int(appCfg.GetWebhookMaxRetries())))
+		if err != nil {
+			log.Warn(err)
+		}
+	}
+}
+
+// getHistoryStoreOptions gets the history store options from the app config
+func getHistoryStoreOptions(appCfg *appConfig.AppConfig) []history.Option {
+	if appCfg == nil {
+		return []history.Option{
+			history.WithRetention(time.Hour),
+		}
+	}
+	return []history.Option{
+		history.WithRetention(time.Duration(appCfg.GetHistoryRetention()) * time.Second),
+		history.WithMaxSamples(int(appCfg.GetHistoryMaxSamples())),
+	}
+}
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var log = logging.GetLogger()
+
+// Exporter is a Prometheus collector exposing the latest value of each stored measurement as a gauge
+// The gauges are read from the measurement store on each scrape, so that the deleted and stale cells disappear
+// with their measurements. Each measurement name is a metric labeled with the E2 node ID, the cell object ID,
+// the cell global ID and the measurement labels of its records.
+type Exporter struct {
+	store       measurements.Store
+	options     Options
+	seriesDesc  *prometheus.Desc
+	droppedDesc *prometheus.Desc
+	// watcherDroppedDesc is the number of measurement events dropped for the slow store watchers
+	watcherDroppedDesc *prometheus.Desc
+}
+
+// NewExporter creates a new metrics exporter of a measurement store
+func NewExporter(store measurements.Store, opts ...Option) *Exporter {
+	options := Options{}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	return &Exporter{
+		store:   store,
+		options: options,
+		seriesDesc: prometheus.NewDesc(namespace+"_metrics_series",
+			"Number of exported measurement series", nil, nil),
+		droppedDesc: prometheus.NewDesc(namespace+"_metrics_dropped_series",
+			"Number of measurement series left out by the series limit", nil, nil),
+		watcherDroppedDesc: prometheus.NewDesc(namespace+"_store_watcher_dropped_events_total",
+			"Number of measurement store events dropped for the slow watchers", nil, nil),
+	}
+}
+
+// Describe sends no descriptor: the measurement metrics are only known once collected
+func (e *Exporter) Describe(chan<- *prometheus.Desc) {}
+
+// Collect collects the latest measurements of the cells that are not stale
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	result, err := e.store.Query(context.Background(), measurements.Query{
+		ExcludeStale: true,
+	})
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+
+	allSeries := getSeries(result.Entries)
+	dropped := 0
+	if e.options.MaxSeries > 0 && len(allSeries) > e.options.MaxSeries {
+		dropped = len(allSeries) - e.options.MaxSeries
+		allSeries = allSeries[:e.options.MaxSeries]
+		log.Warnf("Exporting %d measurement series, %d are left out by the series limit", len(allSeries), dropped)
+	}
+
+	// the metrics of a name share the help of the first measurement name they are exported for
+	helps := make(map[string]string)
+	descs := make(map[string]*prometheus.Desc)
+	for _, s := range allSeries {
+		help, ok := helps[s.name]
+		if !ok {
+			help = "Latest value of the KPM measurement " + s.measurementName
+			helps[s.name] = help
+		}
+		descKey := s.name + "\x00" + strings.Join(s.labelNames, "\x00")
+		desc, ok := descs[descKey]
+		if !ok {
+			desc = prometheus.NewDesc(s.name, help, s.labelNames, nil)
+			descs[descKey] = desc
+		}
+		metric, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, s.value, s.labelValues...)
+		if err != nil {
+			log.Warn(err)
+			continue
+		}
+		ch <- metric
+	}
+	ch <- prometheus.MustNewConstMetric(e.seriesDesc, prometheus.GaugeValue, float64(len(allSeries)))
+	ch <- prometheus.MustNewConstMetric(e.droppedDesc, prometheus.GaugeValue, float64(dropped))
+	ch <- prometheus.MustNewConstMetric(e.watcherDroppedDesc, prometheus.CounterValue, float64(e.store.DroppedEvents()))
+}
+
+// series is an exported measurement series
+type series struct {
+	name            string
+	measurementName string
+	labelNames      []string
+	labelValues     []string
+	value           float64
+	timestamp       uint64
+}
+
+// key identifies the series by its metric name and label set, once sanitized
+func (s *series) key() string {
+	var b strings.Builder
+	b.WriteString(s.name)
+	for i := range s.labelNames {
+		b.WriteString("\x00")
+		b.WriteString(s.labelNames[i])
+		b.WriteString("=")
+		b.WriteString(s.labelValues[i])
+	}
+	return b.String()
+}
+
+// getSeries gets the series of the latest record of each cell, measurement name and label set, in the order of the entries
+// The records without value are left out.
+func getSeries(entries []*measurements.Entry) []*series {
+	allSeries := make([]*series, 0)
+	index := make(map[string]*series)
+	for _, entry := range entries {
+		for _, measItem := range entry.Value {
+			for _, record := range measItem.MeasurementRecords {
+				s, ok := newSeries(entry, record)
+				if !ok {
+					continue
+				}
+				key := s.key()
+				if existing, ok := index[key]; ok {
+					if s.timestamp > existing.timestamp {
+						*existing = *s
+					}
+					continue
+				}
+				index[key] = s
+				allSeries = append(allSeries, s)
+			}
+		}
+	}
+	return allSeries
+}
+
+func newSeries(entry *measurements.Entry, record measurements.MeasurementRecord) (*series, bool) {
+	var value float64
+	switch val := record.MeasurementValue.(type) {
+	case int64:
+		value = float64(val)
+	case float64:
+		value = val
+	default:
+		return nil, false
+	}
+
+	labels := map[string]string{
+		nodeIDLabel:       entry.Key.NodeID,
+		cellObjectIDLabel: entry.Key.CellIdentity.CellID,
+		cellGlobalIDLabel: entry.CellGlobalID,
+	}
+	names := make([]string, 0, len(record.Labels))
+	for name := range record.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		// the first of the labels with the same sanitized name wins
+		if _, ok := labels[labelName(name)]; !ok {
+			labels[labelName(name)] = strings.ToValidUTF8(record.Labels[name], "?")
+		}
+	}
+
+	s := &series{
+		name:            metricName(record.MeasurementName),
+		measurementName: record.MeasurementName,
+		labelNames:      make([]string, 0, len(labels)),
+		labelValues:     make([]string, 0, len(labels)),
+		value:           value,
+		timestamp:       record.Timestamp,
+	}
+	for name := range labels {
+		s.labelNames = append(s.labelNames, name)
+	}
+	sort.Strings(s.labelNames)
+	for _, name := range s.labelNames {
+		s.labelValues = append(s.labelValues, labels[name])
+	}
+	return s, true
+}
+
+var _ prometheus.Collector = &Exporter{}
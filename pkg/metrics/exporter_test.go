@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func put(t *testing.T, store measurements.Store, cellID string, records ...measurements.MeasurementRecord) {
+	key := measurements.NewKey(measurements.CellIdentity{CellID: cellID}, "e2:1")
+	_, err := store.Put(context.Background(), key, []measurements.MeasurementItem{{MeasurementRecords: records}})
+	assert.NoError(t, err)
+}
+
+func TestExporter(t *testing.T) {
+	store := measurements.NewStore()
+	put(t, store, "1",
+		measurements.MeasurementRecord{Timestamp: 1, MeasurementName: "RRC.Conn.Avg", MeasurementValue: int64(1)},
+		// only the latest record of a series is exported
+		measurements.MeasurementRecord{Timestamp: 2, MeasurementName: "RRC.Conn.Avg", MeasurementValue: int64(2)},
+		// the records without value are left out
+		measurements.MeasurementRecord{Timestamp: 2, MeasurementName: "RRC.Conn.Max", MeasurementValue: int32(0)},
+		// the labels clashing with the cell labels are prefixed
+		measurements.MeasurementRecord{Timestamp: 1, MeasurementName: "DRB.UEThpDl", MeasurementValue: 2.5,
+			Labels: map[string]string{"node_id": "x", "slice-id": "1"}})
+	put(t, store, "2",
+		measurements.MeasurementRecord{Timestamp: 1, MeasurementName: "RRC.Conn.Avg", MeasurementValue: int64(5)})
+
+	expected := `
+# HELP kpimon_DRB_UEThpDl Latest value of the KPM measurement DRB.UEThpDl
+# TYPE kpimon_DRB_UEThpDl gauge
+kpimon_DRB_UEThpDl{cell_global_id="",cell_object_id="1",label_node_id="x",node_id="e2:1",slice_id="1"} 2.5
+# HELP kpimon_RRC_Conn_Avg Latest value of the KPM measurement RRC.Conn.Avg
+# TYPE kpimon_RRC_Conn_Avg gauge
+kpimon_RRC_Conn_Avg{cell_global_id="",cell_object_id="1",node_id="e2:1"} 2
+kpimon_RRC_Conn_Avg{cell_global_id="",cell_object_id="2",node_id="e2:1"} 5
+# HELP kpimon_metrics_series Number of exported measurement series
+# TYPE kpimon_metrics_series gauge
+kpimon_metrics_series 3
+# HELP kpimon_metrics_dropped_series Number of measurement series left out by the series limit
+# TYPE kpimon_metrics_dropped_series gauge
+kpimon_metrics_dropped_series 0
+`
+	assert.NoError(t, testutil.CollectAndCompare(NewExporter(store), strings.NewReader(expected),
+		"kpimon_DRB_UEThpDl", "kpimon_RRC_Conn_Avg", "kpimon_RRC_Conn_Max", "kpimon_metrics_series", "kpimon_metrics_dropped_series"))
+}
+
+func TestExporterMaxSeries(t *testing.T) {
+	store := measurements.NewStore()
+	put(t, store, "1",
+		measurements.MeasurementRecord{Timestamp: 1, MeasurementName: "A", MeasurementValue: int64(1)},
+		measurements.MeasurementRecord{Timestamp: 1, MeasurementName: "B", MeasurementValue: int64(2)},
+		measurements.MeasurementRecord{Timestamp: 1, MeasurementName: "C", MeasurementValue: int64(3)})
+
+	expected := `
+# HELP kpimon_metrics_series Number of exported measurement series
+# TYPE kpimon_metrics_series gauge
+kpimon_metrics_series 2
+# HELP kpimon_metrics_dropped_series Number of measurement series left out by the series limit
+# TYPE kpimon_metrics_dropped_series gauge
+kpimon_metrics_dropped_series 1
+`
+	exporter := NewExporter(store, WithMaxSeries(2))
+	assert.NoError(t, testutil.CollectAndCompare(exporter, strings.NewReader(expected),
+		"kpimon_metrics_series", "kpimon_metrics_dropped_series"))
+	// the series are kept in the order of the records
+	assert.Equal(t, 1, testutil.CollectAndCount(exporter, "kpimon_A"))
+	assert.Equal(t, 1, testutil.CollectAndCount(exporter, "kpimon_B"))
+	assert.Equal(t, 0, testutil.CollectAndCount(exporter, "kpimon_C"))
+}
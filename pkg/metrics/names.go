@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"strings"
+)
+
+const (
+	// namespace prefixes the names of the exported metrics
+	namespace = "kpimon"
+
+	nodeIDLabel       = "node_id"
+	cellObjectIDLabel = "cell_object_id"
+	cellGlobalIDLabel = "cell_global_id"
+	// measurementLabelPrefix prefixes the measurement labels clashing with the cell labels
+	measurementLabelPrefix = "label_"
+)
+
+// metricName gets the metric name of a measurement, e.g. kpimon_RRC_Conn_Avg for RRC.Conn.Avg
+func metricName(measurementName string) string {
+	return namespace + "_" + sanitize(measurementName)
+}
+
+// labelName gets the label name of a measurement label
+func labelName(name string) string {
+	name = sanitize(name)
+	switch name {
+	case nodeIDLabel, cellObjectIDLabel, cellGlobalIDLabel:
+		return measurementLabelPrefix + name
+	}
+	// the names starting with __ are reserved by Prometheus
+	if strings.HasPrefix(name, "__") {
+		return measurementLabelPrefix + strings.TrimLeft(name, "_")
+	}
+	return name
+}
+
+// sanitize replaces the characters that are not allowed in the Prometheus metric and label names with underscores
+// The result matches [a-zA-Z_][a-zA-Z0-9_]*.
+func sanitize(name string) string {
+	var b strings.Builder
+	b.Grow(len(name) + 1)
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteRune('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "_"
+	}
+	return b.String()
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricName(t *testing.T) {
+	tests := []struct {
+		measurementName string
+		metricName      string
+	}{
+		{"RRC.ConnEstabSucc.Sum", "kpimon_RRC_ConnEstabSucc_Sum"},
+		{"DRB.UEThpDl", "kpimon_DRB_UEThpDl"},
+		{"5QI-Count", "kpimon__5QI_Count"},
+		{"PDCP Vol:DL", "kpimon_PDCP_Vol_DL"},
+		{"débit", "kpimon_d_bit"},
+		{"", "kpimon__"},
+	}
+	for _, test := range tests {
+		t.Run(test.measurementName, func(t *testing.T) {
+			assert.Equal(t, test.metricName, metricName(test.measurementName))
+		})
+	}
+}
+
+func TestLabelName(t *testing.T) {
+	tests := []struct {
+		name      string
+		labelName string
+	}{
+		{"slice_id", "slice_id"},
+		{"fiveQI", "fiveQI"},
+		{"plmn-id", "plmn_id"},
+		{"1st", "_1st"},
+		// the labels clashing with the cell labels or reserved by Prometheus are prefixed
+		{"node_id", "label_node_id"},
+		{"cell.object.id", "label_cell_object_id"},
+		{"cell_global_id", "label_cell_global_id"},
+		{"__name__", "label_name__"},
+		{"..x", "label_x"},
+		{"", "_"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.labelName, labelName(test.name))
+		})
+	}
+}
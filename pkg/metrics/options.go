@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+// Options metrics exporter options
+type Options struct {
+	// MaxSeries is the maximum number of exported measurement series; zero means unbounded
+	MaxSeries int
+}
+
+// Option metrics exporter option interface
+type Option interface {
+	apply(*Options)
+}
+
+type funcOption struct {
+	f func(*Options)
+}
+
+func (f funcOption) apply(options *Options) {
+	f.f(options)
+}
+
+func newOption(f func(*Options)) Option {
+	return funcOption{
+		f: f,
+	}
+}
+
+// WithMaxSeries sets the maximum number of exported measurement series
+func WithMaxSeries(maxSeries int) Option {
+	return newOption(func(options *Options) {
+		options.MaxSeries = maxSeries
+	})
+}
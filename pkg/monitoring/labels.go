@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package monitoring
+
+import (
+	"encoding/hex"
+	"strings"
+
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// getLabels gets the labels of a measurement keyed by the KPM label field names, e.g. plmn_id, slice_id or five_qi
+// Byte values are hex encoded and the fields of structured values, e.g. the SST and SD of a slice ID, are joined with a "/".
+func getLabels(labelInfoList *e2smkpmv2.LabelInfoList) map[string]string {
+	var labels map[string]string
+	for _, labelInfoItem := range labelInfoList.GetValue() {
+		measLabel := labelInfoItem.GetMeasLabel()
+		if measLabel == nil {
+			continue
+		}
+		measLabel.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			labels[string(fd.Name())] = formatLabelValue(fd, v)
+			return true
+		})
+	}
+	return labels
+}
+
+func formatLabelValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		msg := v.Message()
+		fields := msg.Descriptor().Fields()
+		values := make([]string, 0, fields.Len())
+		for i := 0; i < fields.Len(); i++ {
+			if msg.Has(fields.Get(i)) {
+				values = append(values, formatLabelValue(fields.Get(i), msg.Get(fields.Get(i))))
+			}
+		}
+		return strings.Join(values, "/")
+	case protoreflect.BytesKind:
+		return hex.EncodeToString(v.Bytes())
+	case protoreflect.EnumKind:
+		if value := fd.Enum().Values().ByNumber(v.Enum()); value != nil {
+			return string(value.Name())
+		}
+		return v.String()
+	default:
+		return v.String()
+	}
+}
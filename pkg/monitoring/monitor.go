@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"github.com/onosproject/onos-kpimon/pkg/rnib"
+	"github.com/onosproject/onos-kpimon/pkg/utils"
 
 	e2api "github.com/onosproject/onos-api/go/onos/e2t/e2/v1beta1"
 	"github.com/onosproject/onos-kpimon/pkg/store/actions"
@@ -52,10 +53,19 @@ type Monitor struct {
 	streamReader     broker.StreamReader
 	measurementStore measurmentStore.Store
 	actionStore      actions.Store
-	appConfig        *appConfig.AppConfig
+	appConfig        appConfig.Config
 	measurements     []*topoapi.KPMMeasurement
 	nodeID           topoapi.ID
 	rnibClient       rnib.Client
+	nodeAggregates   nodeAggregates
+}
+
+// nodeAggregates are the E2 node aggregates parsed from their config value
+type nodeAggregates struct {
+	parsed     bool
+	config     string
+	aggregates []rnib.Aggregate
+	err        error
 }
 
 func (m *Monitor) processIndicationFormat1(ctx context.Context, indication e2api.Indication,
@@ -100,8 +110,7 @@ func (m *Monitor) processIndicationFormat1(ctx context.Context, indication e2api
 			return err
 		}
 
-		actionDefinition := response.Value.(*e2smkpmv2.E2SmKpmActionDefinitionFormat1)
-		cid = actionDefinition.GetCellObjId().GetValue()
+		cid = response.Value.GetCellObjId().GetValue()
 
 	} else {
 		cid = indMsgFormat1.GetCellObjId().Value
@@ -136,6 +145,7 @@ func (m *Monitor) processIndicationFormat1(ctx context.Context, indication e2api
 					Timestamp:        timeStamp,
 					MeasurementName:  measName,
 					MeasurementValue: measValue,
+					Labels:           getLabels(measInfoList[j].GetLabelInfoList()),
 				}
 				measRecords = append(measRecords, measRecord)
 			} else if measInfoList[j].GetMeasType().GetMeasId() != nil {
@@ -147,6 +157,7 @@ func (m *Monitor) processIndicationFormat1(ctx context.Context, indication e2api
 					Timestamp:        timeStamp,
 					MeasurementName:  measName,
 					MeasurementValue: measValue,
+					Labels:           getLabels(measInfoList[j].GetLabelInfoList()),
 				}
 				measRecords = append(measRecords, measRecord)
 			}
@@ -163,25 +174,80 @@ func (m *Monitor) processIndicationFormat1(ctx context.Context, indication e2api
 		CellID: cid,
 	}
 
+	// the measurements are stored even if the cell cannot be found in topo
+	cell, cellErr := m.rnibClient.GetCell(ctx, cellID.CellID, nodeID)
+	cellGlobalID := cell.GetCellGlobalID().GetValue()
+	plmnID, err := utils.DecodePlmnIDFromCellGlobalID(cellGlobalID)
+	if err != nil {
+		log.Debug(err)
+	}
 	measurementKey := measurmentStore.NewKey(cellID, string(nodeID))
-	_, err = m.measurementStore.Put(ctx, measurementKey, measItems)
+	_, err = m.measurementStore.Put(ctx, measurementKey, measItems,
+		measurmentStore.WithCellGlobalID(cellGlobalID),
+		measurmentStore.WithPlmnID(plmnID))
 	if err != nil {
 		log.Warn(err)
 		return err
 	}
+	if cellErr != nil {
+		return cellErr
+	}
 
-	cellTopoID, err := m.rnibClient.GetCellTopoID(ctx, cellID.CellID, nodeID)
+	err = m.rnibClient.UpdateCellAspects(ctx, rnib.NewCellTopoID(nodeID, cell), measItems, granularity)
 	if err != nil {
 		return err
 	}
-	err = m.rnibClient.UpdateCellAspects(ctx, cellTopoID, measItems)
+
+	err = m.updateE2NodeAspects(ctx, nodeID)
 	if err != nil {
+		log.Warn(err)
 		return err
 	}
 
 	return nil
 }
 
+// updateE2NodeAspects writes the configured aggregates of the measurements of the cells of an E2 node to topo
+func (m *Monitor) updateE2NodeAspects(ctx context.Context, nodeID topoapi.ID) error {
+	aggregates, err := m.getNodeAggregates()
+	if err != nil {
+		return err
+	}
+	if len(aggregates) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(aggregates))
+	for _, aggregate := range aggregates {
+		names = append(names, aggregate.MeasurementName)
+	}
+	// the stale cells are left out of the aggregates
+	result, err := m.measurementStore.Query(ctx, measurmentStore.Query{
+		NodeIDs:          []string{string(nodeID)},
+		MeasurementNames: names,
+		ExcludeStale:     true,
+	})
+	if err != nil {
+		return err
+	}
+	return m.rnibClient.UpdateE2NodeAspects(ctx, nodeID, rnib.NewE2NodeKPIs(result.Entries, aggregates))
+}
+
+// getNodeAggregates gets the configured E2 node aggregates; the config value is only parsed again once it changes
+func (m *Monitor) getNodeAggregates() ([]rnib.Aggregate, error) {
+	config := m.appConfig.GetNodeAggregates()
+	if !m.nodeAggregates.parsed || config != m.nodeAggregates.config {
+		aggregates, err := rnib.ParseAggregates(config)
+		m.nodeAggregates = nodeAggregates{
+			parsed:     true,
+			config:     config,
+			aggregates: aggregates,
+			err:        err,
+		}
+	}
+	return m.nodeAggregates.aggregates, m.nodeAggregates.err
+}
+
 func (m *Monitor) processIndication(ctx context.Context, indication e2api.Indication,
 	measurements []*topoapi.KPMMeasurement, nodeID topoapi.ID) error {
 	err := m.processIndicationFormat1(ctx, indication, measurements, nodeID)
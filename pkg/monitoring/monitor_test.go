@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package monitoring
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	e2api "github.com/onosproject/onos-api/go/onos/e2t/e2/v1beta1"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	appConfig "github.com/onosproject/onos-kpimon/pkg/config"
+	"github.com/onosproject/onos-kpimon/pkg/rnib"
+	"github.com/onosproject/onos-kpimon/pkg/rnib/fake"
+	"github.com/onosproject/onos-kpimon/pkg/store/actions"
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-kpimon/pkg/utils"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	testNodeID       = topoapi.ID("e2:1")
+	testCellGlobalID = "13842601454c001"
+)
+
+// testConfig is the part of the app configuration used by the monitor
+type testConfig struct {
+	appConfig.Config
+	aggregates string
+}
+
+func (c *testConfig) GetGranularityPeriod() (uint64, error) {
+	return 1000, nil
+}
+
+func (c *testConfig) GetNodeAggregates() string {
+	return c.aggregates
+}
+
+func newTestIndication(t *testing.T, coi string, startTime uint32, name string, value int64) e2api.Indication {
+	timeStamp := make([]byte, 4)
+	binary.BigEndian.PutUint32(timeStamp, startTime)
+	header, err := proto.Marshal(&e2smkpmv2.E2SmKpmIndicationHeader{
+		IndicationHeaderFormats: &e2smkpmv2.IndicationHeaderFormats{
+			E2SmKpmIndicationHeader: &e2smkpmv2.IndicationHeaderFormats_IndicationHeaderFormat1{
+				IndicationHeaderFormat1: &e2smkpmv2.E2SmKpmIndicationHeaderFormat1{
+					ColletStartTime: &e2smkpmv2.TimeStamp{Value: timeStamp},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	payload, err := proto.Marshal(&e2smkpmv2.E2SmKpmIndicationMessage{
+		IndicationMessageFormats: &e2smkpmv2.IndicationMessageFormats{
+			E2SmKpmIndicationMessage: &e2smkpmv2.IndicationMessageFormats_IndicationMessageFormat1{
+				IndicationMessageFormat1: &e2smkpmv2.E2SmKpmIndicationMessageFormat1{
+					CellObjId: &e2smkpmv2.CellObjectId{Value: coi},
+					MeasInfoList: &e2smkpmv2.MeasurementInfoList{
+						Value: []*e2smkpmv2.MeasurementInfoItem{{
+							MeasType: &e2smkpmv2.MeasurementType{
+								MeasurementType: &e2smkpmv2.MeasurementType_MeasName{
+									MeasName: &e2smkpmv2.MeasurementTypeName{Value: name},
+								},
+							},
+						}},
+					},
+					MeasData: &e2smkpmv2.MeasurementData{
+						Value: []*e2smkpmv2.MeasurementDataItem{{
+							MeasRecord: &e2smkpmv2.MeasurementRecord{
+								Value: []*e2smkpmv2.MeasurementRecordItem{{
+									MeasurementRecordItem: &e2smkpmv2.MeasurementRecordItem_Integer{Integer: value},
+								}},
+							},
+						}},
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	return e2api.Indication{Header: header, Payload: payload}
+}
+
+func TestProcessIndication(t *testing.T) {
+	plmnID, err := utils.DecodePlmnIDFromCellGlobalID(testCellGlobalID)
+	assert.NoError(t, err)
+	cellID := topoapi.ID(string(testNodeID) + "/" + testCellGlobalID)
+
+	tests := []struct {
+		name       string
+		coi        string
+		aggregates string
+		// notFound is set when the cell of the indication is not in topo
+		notFound   bool
+		e2NodeKPIs bool
+	}{
+		{
+			name: "cell aspects",
+			coi:  "1",
+		},
+		{
+			name:       "E2 node aggregates",
+			coi:        "1",
+			aggregates: "A:sum",
+			e2NodeKPIs: true,
+		},
+		{
+			name:     "unknown cell",
+			coi:      "2",
+			notFound: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			topo := fake.NewTopo()
+			topo.AddE2Node(testNodeID, &topoapi.E2Node{})
+			topo.AddCell(testNodeID, &topoapi.E2Cell{
+				CellObjectID: "1",
+				CellGlobalID: &topoapi.CellGlobalID{Value: testCellGlobalID},
+			})
+			store := measurements.NewStore()
+			m := NewMonitor(
+				WithAppConfig(&testConfig{aggregates: test.aggregates}),
+				WithMeasurementStore(store),
+				WithActionStore(actions.NewStore()),
+				WithRNIBClient(topo),
+				WithNodeID(testNodeID))
+
+			err := m.processIndication(ctx, newTestIndication(t, test.coi, 10, "A", 3), nil, testNodeID)
+			// the measurements are stored even if the cell is not in topo
+			key := measurements.NewKey(measurements.CellIdentity{CellID: test.coi}, string(testNodeID))
+			entry, getErr := store.Get(ctx, key)
+			assert.NoError(t, getErr)
+			record := entry.Value[0].MeasurementRecords[0]
+			assert.Equal(t, "A", record.MeasurementName)
+			assert.Equal(t, int64(3), record.MeasurementValue)
+			assert.Equal(t, uint64(toUnixNano(10)), record.Timestamp)
+			if test.notFound {
+				assert.True(t, errors.IsNotFound(err))
+				assert.Equal(t, "", entry.CellGlobalID)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCellGlobalID, entry.CellGlobalID)
+			assert.Equal(t, plmnID, entry.PlmnID)
+
+			reports, err := topo.GetKpiReports(cellID)
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]uint32{"A": 3}, reports)
+			cellKPIs, err := topo.GetCellKPIs(cellID)
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(1000), cellKPIs.GetGranularityPeriod())
+
+			e2NodeKPIs, err := topo.GetE2NodeKPIs(testNodeID)
+			if !test.e2NodeKPIs {
+				assert.True(t, errors.IsNotFound(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "A", e2NodeKPIs.GetAggregates()[0].GetName())
+			assert.Equal(t, float64(3), e2NodeKPIs.GetAggregates()[0].GetValue())
+		})
+	}
+}
+
+func TestGetNodeAggregates(t *testing.T) {
+	config := &testConfig{}
+	m := NewMonitor(WithAppConfig(config))
+
+	tests := []struct {
+		name       string
+		config     string
+		aggregates []rnib.Aggregate
+		// reused is set when the aggregates of the previous step are returned without parsing the config again
+		reused  bool
+		isError func(error) bool
+	}{
+		{
+			name: "no aggregate",
+		},
+		{
+			name:       "parsed",
+			config:     "A:sum, B:max",
+			aggregates: []rnib.Aggregate{{MeasurementName: "A", Function: rnib.Sum}, {MeasurementName: "B", Function: rnib.Max}},
+		},
+		{
+			name:       "unchanged",
+			config:     "A:sum, B:max",
+			aggregates: []rnib.Aggregate{{MeasurementName: "A", Function: rnib.Sum}, {MeasurementName: "B", Function: rnib.Max}},
+			reused:     true,
+		},
+		{
+			name:       "changed",
+			config:     "A:avg",
+			aggregates: []rnib.Aggregate{{MeasurementName: "A", Function: rnib.Avg}},
+		},
+		{
+			name:    "invalid",
+			config:  "A:median",
+			isError: errors.IsInvalid,
+		},
+	}
+	var previous []rnib.Aggregate
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config.aggregates = test.config
+			aggregates, err := m.getNodeAggregates()
+			if test.isError != nil {
+				assert.True(t, test.isError(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.aggregates, aggregates)
+			if len(aggregates) > 0 {
+				assert.Equal(t, test.reused, len(previous) > 0 && &previous[0] == &aggregates[0])
+			}
+			previous = aggregates
+		})
+	}
+}
@@ -23,7 +23,7 @@ type Options struct {
 
 // AppOptions application options
 type AppOptions struct {
-	AppConfig *appConfig.AppConfig
+	AppConfig appConfig.Config
 
 	ActionStore actions.Store
 
@@ -95,7 +95,7 @@ func WithMeasurementStore(measurmentStore measurmentStore.Store) Option {
 }
 
 // WithAppConfig sets app config
-func WithAppConfig(appConfig *appConfig.AppConfig) Option {
+func WithAppConfig(appConfig appConfig.Config) Option {
 	return newOption(func(options *Options) {
 		options.App.AppConfig = appConfig
 	})
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package northbound
+
+import (
+	"bytes"
+	"context"
+
+	adminapi "github.com/onosproject/onos-kpimon/api/admin/v1"
+	"github.com/onosproject/onos-kpimon/pkg/snapshot"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging/service"
+	"google.golang.org/grpc"
+)
+
+// NewAdminService returns a new kpimon admin service.
+func NewAdminService(snapshots *snapshot.Manager) service.Service {
+	return &AdminService{
+		snapshots: snapshots,
+	}
+}
+
+// AdminService is a service implementation for the kpimon admin API.
+type AdminService struct {
+	service.Service
+	snapshots *snapshot.Manager
+}
+
+// Register registers the AdminService with the gRPC server.
+func (s AdminService) Register(r *grpc.Server) {
+	server := &AdminServer{
+		snapshots: s.snapshots,
+	}
+	adminapi.RegisterAdminServer(r, server)
+}
+
+// AdminServer implements the kpimon admin gRPC service.
+type AdminServer struct {
+	adminapi.UnimplementedAdminServer
+	snapshots *snapshot.Manager
+}
+
+// CreateSnapshot creates a snapshot of the kpimon state
+// The snapshot is saved to the snapshot directory if a name is given or returned in the response otherwise.
+func (s *AdminServer) CreateSnapshot(ctx context.Context, request *adminapi.CreateSnapshotRequest) (*adminapi.CreateSnapshotResponse, error) {
+	if request.GetName() != "" {
+		snap, err := s.snapshots.Save(ctx, request.GetName())
+		if err != nil {
+			log.Warn(err)
+			return nil, errors.Status(err).Err()
+		}
+		return newCreateSnapshotResponse(snap, nil), nil
+	}
+
+	snap, err := s.snapshots.Create(ctx)
+	if err != nil {
+		log.Warn(err)
+		return nil, errors.Status(err).Err()
+	}
+	var buf bytes.Buffer
+	err = snapshot.Write(&buf, snap)
+	if err != nil {
+		log.Warn(err)
+		return nil, errors.Status(err).Err()
+	}
+	return newCreateSnapshotResponse(snap, buf.Bytes()), nil
+}
+
+// RestoreSnapshot replaces the kpimon state with a snapshot read from the snapshot directory or given in the request
+func (s *AdminServer) RestoreSnapshot(ctx context.Context, request *adminapi.RestoreSnapshotRequest) (*adminapi.RestoreSnapshotResponse, error) {
+	var snap *snapshot.Snapshot
+	var err error
+	switch source := request.GetSource().(type) {
+	case *adminapi.RestoreSnapshotRequest_Name:
+		snap, err = s.snapshots.Load(ctx, source.Name)
+	case *adminapi.RestoreSnapshotRequest_Data:
+		snap, err = snapshot.Read(bytes.NewReader(source.Data))
+		if err == nil {
+			err = s.snapshots.Restore(ctx, snap)
+		}
+	default:
+		err = errors.NewInvalid("a snapshot name or data is required")
+	}
+	if err != nil {
+		log.Warn(err)
+		return nil, errors.Status(err).Err()
+	}
+	log.Infof("Restored snapshot created at %s", snap.CreatedAt)
+	return &adminapi.RestoreSnapshotResponse{
+		Version:      int32(snap.Version),
+		Measurements: uint64(len(snap.Measurements)),
+		Actions:      uint64(len(snap.Actions)),
+	}, nil
+}
+
+func newCreateSnapshotResponse(snap *snapshot.Snapshot, data []byte) *adminapi.CreateSnapshotResponse {
+	return &adminapi.CreateSnapshotResponse{
+		Version:       int32(snap.Version),
+		Data:          data,
+		Measurements:  uint64(len(snap.Measurements)),
+		Actions:       uint64(len(snap.Actions)),
+		Subscriptions: uint64(len(snap.Subscriptions)),
+	}
+}
+
+var _ adminapi.AdminServer = &AdminServer{}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package northbound
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+
+	kpimonapi "github.com/onosproject/onos-api/go/onos/kpimon"
+	"github.com/onosproject/onos-kpimon/pkg/sharding"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-ric-sdk-go/pkg/utils/creds"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// FanOutMetadataKey is set to "true" by a ListMeasurements client to query all of the replicas of the replica set
+const FanOutMetadataKey = "kpimon-fan-out"
+
+// isFanOut returns true if the client requested a query of all of the replicas
+func isFanOut(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, value := range md.Get(FanOutMetadataKey) {
+		if value == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// newReplicaClients creates a pool of connections to the other replicas
+func newReplicaClients() *replicaClients {
+	return &replicaClients{
+		conns: make(map[string]*grpc.ClientConn),
+	}
+}
+
+type replicaClients struct {
+	conns map[string]*grpc.ClientConn
+	mu    sync.Mutex
+}
+
+// get gets the connection to a replica
+func (c *replicaClients) get(address string) (*grpc.ClientConn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if conn, ok := c.conns[address]; ok {
+		return conn, nil
+	}
+
+	tlsConfig, err := creds.GetClientCredentials()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		return nil, err
+	}
+	c.conns[address] = conn
+	return conn, nil
+}
+
+// listMeasurements lists the measurements of all of the replica set members and paginates the merged result
+// The query metadata is forwarded to the other replicas without the fan-out and pagination keys.
+func (s *Server) listMeasurements(ctx context.Context, local map[string]*kpimonapi.MeasurementItems, offset, limit int) (*kpimonapi.GetResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	md.Delete(FanOutMetadataKey)
+	md.Delete(OffsetMetadataKey)
+	md.Delete(LimitMetadataKey)
+	outgoingCtx := metadata.NewOutgoingContext(ctx, md)
+
+	merged := local
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var fanOutErr error
+	for _, member := range s.sharder.Ring().Members() {
+		if member.ID == s.sharder.Local() {
+			continue
+		}
+		wg.Add(1)
+		go func(member sharding.Member) {
+			defer wg.Done()
+			response, err := s.listReplicaMeasurements(outgoingCtx, member)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Warnf("Failed to list the measurements of replica %s: %v", member.ID, err)
+				fanOutErr = errors.NewUnavailable("replica %s is unavailable: %v", member.ID, err)
+				return
+			}
+			for key, items := range response.GetMeasurements() {
+				merged[key] = items
+			}
+		}(member)
+	}
+	wg.Wait()
+	if fanOutErr != nil {
+		return nil, fanOutErr
+	}
+
+	keys := make([]string, 0, len(merged))
+	for key := range merged {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	header := metadata.Pairs(TotalMetadataKey, strconv.Itoa(len(keys)))
+	measurements := make(map[string]*kpimonapi.MeasurementItems)
+	if offset < len(keys) {
+		end := len(keys)
+		if limit > 0 && offset+limit < end {
+			end = offset + limit
+			header.Set(NextOffsetMetadataKey, strconv.Itoa(end))
+		}
+		for _, key := range keys[offset:end] {
+			measurements[key] = merged[key]
+		}
+	}
+	err := grpc.SetHeader(ctx, header)
+	if err != nil {
+		log.Warn(err)
+	}
+	return &kpimonapi.GetResponse{
+		Measurements: measurements,
+	}, nil
+}
+
+func (s *Server) listReplicaMeasurements(ctx context.Context, member sharding.Member) (*kpimonapi.GetResponse, error) {
+	if member.Address == "" {
+		return nil, errors.NewInvalid("replica %s has no address", member.ID)
+	}
+	conn, err := s.replicas.get(member.Address)
+	if err != nil {
+		return nil, err
+	}
+	return kpimonapi.NewKpimonClient(conn).ListMeasurements(ctx, &kpimonapi.GetRequest{})
+}
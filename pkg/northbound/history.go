@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package northbound
+
+import (
+	"context"
+
+	kpimonv2api "github.com/onosproject/onos-kpimon/api/kpimon/v2"
+	"github.com/onosproject/onos-kpimon/pkg/export"
+	"github.com/onosproject/onos-kpimon/pkg/store/history"
+	measurementStore "github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// GetHistory gets the stored records of the cells matching a filter as time ordered series
+func (s *V2Server) GetHistory(ctx context.Context, request *kpimonv2api.GetHistoryRequest) (*kpimonv2api.GetHistoryResponse, error) {
+	result, err := s.historyStore.Query(ctx, newV2Query(request.GetFilter()))
+	if err != nil {
+		return nil, errors.Status(err).Err()
+	}
+
+	response := &kpimonv2api.GetHistoryResponse{
+		Series: make([]*kpimonv2api.Series, 0, len(result)),
+	}
+	cellIDs := newCellIDCache(s)
+	for _, series := range result {
+		samples := make([]*kpimonv2api.Sample, 0, len(series.Samples))
+		for _, sample := range series.Samples {
+			samples = append(samples, &kpimonv2api.Sample{
+				Timestamp: sample.Timestamp,
+				Value:     export.NewValue(sample.Value),
+			})
+		}
+		response.Series = append(response.Series, &kpimonv2api.Series{
+			Cell:    cellIDs.get(ctx, series),
+			Name:    series.Name,
+			Labels:  series.Labels,
+			Samples: samples,
+		})
+	}
+	return response, nil
+}
+
+// QueryRange aggregates the stored records of the cells matching a filter into time series aligned on a step
+func (s *V2Server) QueryRange(ctx context.Context, request *kpimonv2api.QueryRangeRequest) (*kpimonv2api.QueryRangeResponse, error) {
+	r := history.Range{
+		Start: request.GetFilter().GetStartTime(),
+		End:   request.GetFilter().GetEndTime(),
+		Step:  request.GetStep(),
+	}
+	err := r.Validate()
+	if err != nil {
+		return nil, errors.Status(err).Err()
+	}
+	function, err := newFunction(request.GetFunction())
+	if err != nil {
+		return nil, errors.Status(err).Err()
+	}
+	if function == history.Percentile && (request.GetPercentile() < 0 || request.GetPercentile() > 100) {
+		return nil, errors.Status(errors.NewInvalid("percentile must be between 0 and 100")).Err()
+	}
+
+	result, err := s.historyStore.Query(ctx, newV2Query(request.GetFilter()))
+	if err != nil {
+		return nil, errors.Status(err).Err()
+	}
+
+	response := &kpimonv2api.QueryRangeResponse{
+		Series: make([]*kpimonv2api.RangeSeries, 0, len(result)),
+	}
+	cellIDs := newCellIDCache(s)
+	for _, series := range result {
+		points := history.Aggregate(series.Samples, r, function, request.GetPercentile())
+		if len(points) == 0 {
+			continue
+		}
+		rangeSeries := &kpimonv2api.RangeSeries{
+			Cell:   cellIDs.get(ctx, series),
+			Name:   series.Name,
+			Labels: series.Labels,
+			Points: make([]*kpimonv2api.Point, 0, len(points)),
+		}
+		for _, point := range points {
+			rangeSeries.Points = append(rangeSeries.Points, &kpimonv2api.Point{
+				Timestamp: point.Timestamp,
+				Value:     point.Value,
+				Count:     uint32(point.Count),
+			})
+		}
+		response.Series = append(response.Series, rangeSeries)
+	}
+	return response, nil
+}
+
+func newFunction(function kpimonv2api.AggregateFunction) (history.Function, error) {
+	switch function {
+	case kpimonv2api.AggregateFunction_AVG:
+		return history.Avg, nil
+	case kpimonv2api.AggregateFunction_MIN:
+		return history.Min, nil
+	case kpimonv2api.AggregateFunction_MAX:
+		return history.Max, nil
+	case kpimonv2api.AggregateFunction_SUM:
+		return history.Sum, nil
+	case kpimonv2api.AggregateFunction_LAST:
+		return history.Last, nil
+	case kpimonv2api.AggregateFunction_PERCENTILE:
+		return history.Percentile, nil
+	default:
+		return 0, errors.NewInvalid("unknown aggregate function %s", function)
+	}
+}
+
+// cellIDCache creates the cell identities of the series of a response, looking the unknown cell global IDs up once per cell
+type cellIDCache struct {
+	server  *V2Server
+	cellIDs map[measurementStore.Key]*kpimonv2api.CellID
+}
+
+func newCellIDCache(server *V2Server) *cellIDCache {
+	return &cellIDCache{
+		server:  server,
+		cellIDs: make(map[measurementStore.Key]*kpimonv2api.CellID),
+	}
+}
+
+func (c *cellIDCache) get(ctx context.Context, series *history.Series) *kpimonv2api.CellID {
+	key := series.Key.Key
+	if cellID, ok := c.cellIDs[key]; ok {
+		return cellID
+	}
+	cellGlobalID := series.CellGlobalID
+	if cellGlobalID == "" {
+		cellGlobalID = getCellGlobalID(ctx, c.server.rnibClient, key.NodeID, key.CellIdentity.CellID)
+	}
+	cellID := &kpimonv2api.CellID{
+		NodeId:       key.NodeID,
+		CellObjectId: key.CellIdentity.CellID,
+		CellGlobalId: cellGlobalID,
+		PlmnId:       series.PlmnID,
+	}
+	c.cellIDs[key] = cellID
+	return cellID
+}
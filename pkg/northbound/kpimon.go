@@ -9,20 +9,27 @@ import (
 	"fmt"
 	topoapi "github.com/onosproject/onos-api/go/onos/topo"
 	"github.com/onosproject/onos-kpimon/pkg/rnib"
+	"github.com/onosproject/onos-kpimon/pkg/sharding"
 
 	"github.com/onosproject/onos-kpimon/pkg/utils"
 
 	kpimonapi "github.com/onosproject/onos-api/go/onos/kpimon"
-	"github.com/onosproject/onos-kpimon/pkg/store/event"
 	measurementStore "github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
 	"github.com/onosproject/onos-lib-go/pkg/logging/service"
 	"google.golang.org/grpc"
 )
 
+var log = logging.GetLogger()
+
 // NewService returns a new KPIMON interface service.
-func NewService(store measurementStore.Store) service.Service {
+// The sharder is used to query the other replicas of the replica set, if any.
+func NewService(store measurementStore.Store, sharder sharding.Sharder, rnibClient rnib.Client) service.Service {
 	return &Service{
 		measurementStore: store,
+		sharder:          sharder,
+		rnibClient:       rnibClient,
 	}
 }
 
@@ -30,12 +37,17 @@ func NewService(store measurementStore.Store) service.Service {
 type Service struct {
 	service.Service
 	measurementStore measurementStore.Store
+	sharder          sharding.Sharder
+	rnibClient       rnib.Client
 }
 
 // Register registers the Service with the gRPC server.
 func (s Service) Register(r *grpc.Server) {
 	server := &Server{
 		measurementStore: s.measurementStore,
+		sharder:          s.sharder,
+		replicas:         newReplicaClients(),
+		rnibClient:       s.rnibClient,
 	}
 	kpimonapi.RegisterKpimonServer(r, server)
 }
@@ -43,32 +55,51 @@ func (s Service) Register(r *grpc.Server) {
 // Server implements the KPIMON gRPC service for administrative facilities.
 type Server struct {
 	measurementStore measurementStore.Store
+	sharder          sharding.Sharder
+	replicas         *replicaClients
+	rnibClient       rnib.Client
 }
 
 // ListMeasurements get a snapshot of measurements
+// The snapshot can be filtered and paginated with the query gRPC metadata, and covers all of the
+// replicas of the replica set if the fan-out metadata is set.
 func (s *Server) ListMeasurements(ctx context.Context, _ *kpimonapi.GetRequest) (*kpimonapi.GetResponse, error) {
-	ch := make(chan *measurementStore.Entry)
-	done := make(chan bool)
+	query, err := getQuery(ctx)
+	if err != nil {
+		return nil, errors.Status(err).Err()
+	}
+	// the response cannot tell stale measurements apart
+	query.ExcludeStale = true
+
+	fanOut := isFanOut(ctx) && s.sharder != nil && s.sharder.Ring().Len() > 1
+	offset, limit := query.Offset, query.Limit
+	if fanOut {
+		query.Offset, query.Limit = 0, 0
+	}
+
+	result, err := s.measurementStore.Query(ctx, query)
+	if err != nil {
+		return nil, errors.Status(err).Err()
+	}
 
 	measurements := make(map[string]*kpimonapi.MeasurementItems)
-	go func(measurements map[string]*kpimonapi.MeasurementItems, ch chan *measurementStore.Entry, done chan bool) {
-		for entry := range ch {
-			measItems := utils.ParseEntry(entry)
-			cellID := entry.Key.CellIdentity.CellID
-			nodeID := entry.Key.NodeID
-			keyID := fmt.Sprintf("%s:%s:%s", nodeID, cellID, s.getCellGlobalID(ctx, nodeID, cellID))
-			measurements[keyID] = measItems
-		}
-		done <- true
-	}(measurements, ch, done)
+	for _, entry := range result.Entries {
+		measurements[s.getKeyID(ctx, entry)] = utils.ParseEntry(entry)
+	}
 
-	err := s.measurementStore.Entries(ctx, ch)
+	if fanOut {
+		response, err := s.listMeasurements(ctx, measurements, offset, limit)
+		if err != nil {
+			return nil, errors.Status(err).Err()
+		}
+		return response, nil
+	}
 
+	err = grpc.SetHeader(ctx, newQueryResultMetadata(result))
 	if err != nil {
-		return nil, err
+		log.Warn(err)
 	}
 
-	<-done
 	response := &kpimonapi.GetResponse{
 		Measurements: measurements,
 	}
@@ -77,40 +108,44 @@ func (s *Server) ListMeasurements(ctx context.Context, _ *kpimonapi.GetRequest)
 }
 
 // WatchMeasurements get measurements in a stream
+// The stream can be filtered, sampled and deduplicated with the query and watch gRPC metadata; pagination does not apply.
 func (s *Server) WatchMeasurements(_ *kpimonapi.GetRequest, server kpimonapi.Kpimon_WatchMeasurementsServer) error {
-	ch := make(chan event.Event)
-	err := s.measurementStore.Watch(server.Context(), ch)
+	query, err := getQuery(server.Context())
+	if err != nil {
+		return errors.Status(err).Err()
+	}
+	query.ExcludeStale = true
+	options, err := getWatchOptions(server.Context())
 	if err != nil {
-		return err
+		return errors.Status(err).Err()
 	}
+	options.query = query
 
-	for e := range ch {
+	return watchMeasurements(server.Context(), s.measurementStore, options, func(e measurementStore.Event) error {
 		measurements := make(map[string]*kpimonapi.MeasurementItems)
-		measEntry := e.Value.(*measurementStore.Entry)
-		// key := e.Key.(measurementStore.Key)
-		cellID := measEntry.Key.CellIdentity.CellID
-		nodeID := measEntry.Key.NodeID
-		keyID := fmt.Sprintf("%s:%s:%s", nodeID, cellID, s.getCellGlobalID(context.Background(), nodeID, cellID))
-
-		measItems := utils.ParseEntry(measEntry)
-		measurements[keyID] = measItems
-
-		err := server.Send(&kpimonapi.GetResponse{
+		measurements[s.getKeyID(context.Background(), e.Value)] = utils.ParseEntry(e.Value)
+		return server.Send(&kpimonapi.GetResponse{
 			Measurements: measurements,
 		})
-		if err != nil {
-			return err
-		}
+	})
+}
+
+// getKeyID gets the "node:cell:cgi" key of an entry in the responses
+func (s *Server) getKeyID(ctx context.Context, entry *measurementStore.Entry) string {
+	cellID := entry.Key.CellIdentity.CellID
+	nodeID := entry.Key.NodeID
+	cellGlobalID := entry.CellGlobalID
+	if cellGlobalID == "" {
+		cellGlobalID = getCellGlobalID(ctx, s.rnibClient, nodeID, cellID)
 	}
-	return nil
+	return fmt.Sprintf("%s:%s:%s", nodeID, cellID, cellGlobalID)
 }
 
-func (s *Server) getCellGlobalID(ctx context.Context, nodeID string, cellObjID string) string {
-	rnibClient, err := rnib.NewClient()
-	if err != nil {
+// getCellGlobalID looks the cell global ID of a cell up in topo
+func getCellGlobalID(ctx context.Context, rnibClient rnib.Client, nodeID string, cellObjID string) string {
+	if rnibClient == nil {
 		return ""
 	}
-
 	cells, err := rnibClient.GetCells(ctx, topoapi.ID(nodeID))
 	if err != nil {
 		return ""
@@ -0,0 +1,313 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package northbound
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	kpimonv2api "github.com/onosproject/onos-kpimon/api/kpimon/v2"
+	"github.com/onosproject/onos-kpimon/pkg/broker"
+	"github.com/onosproject/onos-kpimon/pkg/export"
+	"github.com/onosproject/onos-kpimon/pkg/rnib"
+	"github.com/onosproject/onos-kpimon/pkg/sharding"
+	"github.com/onosproject/onos-kpimon/pkg/southbound/e2/subscription"
+	"github.com/onosproject/onos-kpimon/pkg/store/generic"
+	"github.com/onosproject/onos-kpimon/pkg/store/history"
+	measurementStore "github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-kpimon/pkg/store/watcher"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging/service"
+	"google.golang.org/grpc"
+)
+
+// NewV2Service returns a new kpimon v2 API service.
+// The sharder is used to query the other replicas of the replica set, if any.
+func NewV2Service(store measurementStore.Store, historyStore history.Store, sharder sharding.Sharder, rnibClient rnib.Client,
+	streams broker.Broker, subManager subscription.SubManager) service.Service {
+	return &V2Service{
+		measurementStore: store,
+		historyStore:     historyStore,
+		sharder:          sharder,
+		rnibClient:       rnibClient,
+		streams:          streams,
+		subManager:       subManager,
+	}
+}
+
+// V2Service is a service implementation for the kpimon v2 API.
+type V2Service struct {
+	service.Service
+	measurementStore measurementStore.Store
+	historyStore     history.Store
+	sharder          sharding.Sharder
+	rnibClient       rnib.Client
+	streams          broker.Broker
+	subManager       subscription.SubManager
+}
+
+// Register registers the V2Service with the gRPC server.
+func (s V2Service) Register(r *grpc.Server) {
+	server := &V2Server{
+		measurementStore: s.measurementStore,
+		historyStore:     s.historyStore,
+		sharder:          s.sharder,
+		replicas:         newReplicaClients(),
+		rnibClient:       s.rnibClient,
+		streams:          s.streams,
+		subManager:       s.subManager,
+	}
+	kpimonv2api.RegisterKpimonServer(r, server)
+}
+
+// V2Server implements the kpimon v2 gRPC service.
+type V2Server struct {
+	kpimonv2api.UnimplementedKpimonServer
+	measurementStore measurementStore.Store
+	historyStore     history.Store
+	sharder          sharding.Sharder
+	replicas         *replicaClients
+	rnibClient       rnib.Client
+	streams          broker.Broker
+	subManager       subscription.SubManager
+}
+
+// ListMeasurements gets the latest measurements of the cells matching a filter, paginated
+func (s *V2Server) ListMeasurements(ctx context.Context, request *kpimonv2api.ListMeasurementsRequest) (*kpimonv2api.ListMeasurementsResponse, error) {
+	query := newV2Query(request.GetFilter())
+	query.Offset, query.Limit = int(request.GetOffset()), int(request.GetLimit())
+
+	fanOut := request.GetAllReplicas() && s.sharder != nil && s.sharder.Ring().Len() > 1
+	if fanOut {
+		query.Offset, query.Limit = 0, 0
+	}
+
+	result, err := s.measurementStore.Query(ctx, query)
+	if err != nil {
+		return nil, errors.Status(err).Err()
+	}
+
+	measurements := make([]*kpimonv2api.CellMeasurements, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		measurements = append(measurements, s.newCellMeasurements(ctx, entry))
+	}
+	if !fanOut {
+		return &kpimonv2api.ListMeasurementsResponse{
+			Measurements: measurements,
+			Total:        uint32(result.Total),
+			NextOffset:   uint32(result.NextOffset),
+		}, nil
+	}
+
+	response, err := s.listMeasurements(ctx, request, measurements)
+	if err != nil {
+		return nil, errors.Status(err).Err()
+	}
+	return response, nil
+}
+
+// listMeasurements lists the measurements of all of the replica set members and paginates the merged result
+func (s *V2Server) listMeasurements(ctx context.Context, request *kpimonv2api.ListMeasurementsRequest,
+	local []*kpimonv2api.CellMeasurements) (*kpimonv2api.ListMeasurementsResponse, error) {
+	replicaRequest := &kpimonv2api.ListMeasurementsRequest{
+		Filter: request.GetFilter(),
+	}
+
+	merged := local
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var fanOutErr error
+	for _, member := range s.sharder.Ring().Members() {
+		if member.ID == s.sharder.Local() {
+			continue
+		}
+		wg.Add(1)
+		go func(member sharding.Member) {
+			defer wg.Done()
+			response, err := s.listReplicaMeasurements(ctx, member, replicaRequest)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Warnf("Failed to list the measurements of replica %s: %v", member.ID, err)
+				fanOutErr = errors.NewUnavailable("replica %s is unavailable: %v", member.ID, err)
+				return
+			}
+			merged = append(merged, response.GetMeasurements()...)
+		}(member)
+	}
+	wg.Wait()
+	if fanOutErr != nil {
+		return nil, fanOutErr
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return lessCellID(merged[i].GetCell(), merged[j].GetCell())
+	})
+	response := &kpimonv2api.ListMeasurementsResponse{
+		Total: uint32(len(merged)),
+	}
+	offset, limit := int(request.GetOffset()), int(request.GetLimit())
+	if offset < len(merged) {
+		end := len(merged)
+		if limit > 0 && offset+limit < end {
+			end = offset + limit
+			response.NextOffset = uint32(end)
+		}
+		response.Measurements = merged[offset:end]
+	}
+	return response, nil
+}
+
+func (s *V2Server) listReplicaMeasurements(ctx context.Context, member sharding.Member,
+	request *kpimonv2api.ListMeasurementsRequest) (*kpimonv2api.ListMeasurementsResponse, error) {
+	if member.Address == "" {
+		return nil, errors.NewInvalid("replica %s has no address", member.ID)
+	}
+	conn, err := s.replicas.get(member.Address)
+	if err != nil {
+		return nil, err
+	}
+	return kpimonv2api.NewKpimonClient(conn).ListMeasurements(ctx, request)
+}
+
+// WatchMeasurements streams the measurement updates of the cells matching a filter
+// The updates of a cell can be rate limited with a minimum interval and restricted to the changed values.
+// A client can resume the watch after the sequence number of the last received response; if the missed updates
+// are no longer buffered, a RESYNC_REQUIRED response is sent followed by the current measurements.
+// A client too slow to keep up is disconnected with an Unavailable error rather than missing updates, and can resume.
+func (s *V2Server) WatchMeasurements(request *kpimonv2api.WatchMeasurementsRequest, server kpimonv2api.Kpimon_WatchMeasurementsServer) error {
+	options := watchOptions{
+		query:        newV2Query(request.GetFilter()),
+		minInterval:  time.Duration(request.GetMinIntervalMs()) * time.Millisecond,
+		changesOnly:  request.GetChangesOnly(),
+		deletes:      true,
+		storeOptions: []generic.WatchOption{generic.WithSlowConsumerPolicy(watcher.Disconnect)},
+	}
+	if request.ResumeFrom != nil {
+		options.storeOptions = append(options.storeOptions, generic.WithResumeFrom(request.GetResumeFrom()))
+	} else if request.GetReplay() {
+		options.storeOptions = append(options.storeOptions, generic.WithReplay())
+	}
+
+	err := watchMeasurements(server.Context(), s.measurementStore, options, func(e measurementStore.Event) error {
+		response := &kpimonv2api.WatchMeasurementsResponse{
+			Type:     newEventType(e.Type),
+			Sequence: e.Sequence,
+		}
+		if e.Type == measurementStore.Resync {
+			return server.Send(response)
+		}
+		if e.Type == measurementStore.Deleted {
+			response.Measurements = &kpimonv2api.CellMeasurements{
+				Cell: s.newCellID(server.Context(), e.Value),
+			}
+		} else {
+			response.Measurements = s.newCellMeasurements(server.Context(), e.Value)
+		}
+		return server.Send(response)
+	})
+	if err != nil {
+		return errors.Status(err).Err()
+	}
+	return nil
+}
+
+// ListSubscriptions lists the E2 subscriptions of this replica
+func (s *V2Server) ListSubscriptions(_ context.Context, request *kpimonv2api.ListSubscriptionsRequest) (*kpimonv2api.ListSubscriptionsResponse, error) {
+	nodeIDs := make(map[string]bool)
+	for _, nodeID := range request.GetNodeIds() {
+		nodeIDs[nodeID] = true
+	}
+
+	subscriptions := make([]*kpimonv2api.Subscription, 0)
+	for _, stream := range s.streams.Streams() {
+		nodeID := string(stream.Node().ID())
+		if len(nodeIDs) > 0 && !nodeIDs[nodeID] {
+			continue
+		}
+		subscriptions = append(subscriptions, &kpimonv2api.Subscription{
+			NodeId:    nodeID,
+			ChannelId: string(stream.ChannelID()),
+			Name:      stream.SubscriptionName(),
+			Actions:   uint32(len(stream.Subscription().Actions)),
+		})
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		if subscriptions[i].NodeId != subscriptions[j].NodeId {
+			return subscriptions[i].NodeId < subscriptions[j].NodeId
+		}
+		return subscriptions[i].ChannelId < subscriptions[j].ChannelId
+	})
+	return &kpimonv2api.ListSubscriptionsResponse{
+		Subscriptions: subscriptions,
+	}, nil
+}
+
+// Resubscribe closes the E2 subscriptions of an E2 node and subscribes to it again
+func (s *V2Server) Resubscribe(ctx context.Context, request *kpimonv2api.ResubscribeRequest) (*kpimonv2api.ResubscribeResponse, error) {
+	if request.GetNodeId() == "" {
+		return nil, errors.Status(errors.NewInvalid("node ID is required")).Err()
+	}
+	err := s.subManager.Resubscribe(ctx, topoapi.ID(request.GetNodeId()))
+	if err != nil {
+		log.Warn(err)
+		return nil, errors.Status(err).Err()
+	}
+	return &kpimonv2api.ResubscribeResponse{}, nil
+}
+
+// newCellID creates the identity of the cell of an entry; the cell global ID is looked up in topo if unknown
+func (s *V2Server) newCellID(ctx context.Context, entry *measurementStore.Entry) *kpimonv2api.CellID {
+	cellID := export.NewCellID(entry)
+	if cellID.CellGlobalId == "" {
+		cellID.CellGlobalId = getCellGlobalID(ctx, s.rnibClient, entry.Key.NodeID, entry.Key.CellIdentity.CellID)
+	}
+	return cellID
+}
+
+func (s *V2Server) newCellMeasurements(ctx context.Context, entry *measurementStore.Entry) *kpimonv2api.CellMeasurements {
+	measurements := export.NewCellMeasurements(entry)
+	measurements.Cell = s.newCellID(ctx, entry)
+	return measurements
+}
+
+// newV2Query creates the measurement store query of a v2 filter
+func newV2Query(filter *kpimonv2api.Filter) measurementStore.Query {
+	return measurementStore.Query{
+		NodeIDs:          filter.GetNodeIds(),
+		CellIDs:          filter.GetCellObjectIds(),
+		CellGlobalIDs:    filter.GetCellGlobalIds(),
+		PlmnIDs:          filter.GetPlmnIds(),
+		MeasurementNames: filter.GetMeasurementNames(),
+		StartTime:        filter.GetStartTime(),
+		EndTime:          filter.GetEndTime(),
+		ExcludeStale:     !filter.GetIncludeStale(),
+	}
+}
+
+func newEventType(eventType measurementStore.MeasurementEvent) kpimonv2api.EventType {
+	switch eventType {
+	case measurementStore.Created:
+		return kpimonv2api.EventType_CREATED
+	case measurementStore.Updated:
+		return kpimonv2api.EventType_UPDATED
+	case measurementStore.Deleted:
+		return kpimonv2api.EventType_DELETED
+	case measurementStore.Resync:
+		return kpimonv2api.EventType_RESYNC_REQUIRED
+	default:
+		return kpimonv2api.EventType_NONE
+	}
+}
+
+func lessCellID(a, b *kpimonv2api.CellID) bool {
+	if a.GetNodeId() != b.GetNodeId() {
+		return a.GetNodeId() < b.GetNodeId()
+	}
+	return a.GetCellObjectId() < b.GetCellObjectId()
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package northbound
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/generic"
+	measurementStore "github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+// The kpimon GetRequest has no fields, so the query parameters are passed as gRPC metadata.
+// Keys that select identities or names may be repeated.
+const (
+	// ReplayMetadataKey is set to "true" by a WatchMeasurements client
+	// to receive the current measurements before the live updates
+	ReplayMetadataKey = "kpimon-replay"
+	// NodeIDMetadataKey selects an E2 node ID
+	NodeIDMetadataKey = "kpimon-node-id"
+	// CellIDMetadataKey selects a cell object ID
+	CellIDMetadataKey = "kpimon-cell-id"
+	// CellGlobalIDMetadataKey selects a cell global ID
+	CellGlobalIDMetadataKey = "kpimon-cell-global-id"
+	// PlmnIDMetadataKey selects a PLMN ID in decimal
+	PlmnIDMetadataKey = "kpimon-plmn-id"
+	// MeasurementNameMetadataKey selects a measurement name
+	MeasurementNameMetadataKey = "kpimon-measurement-name"
+	// StartTimeMetadataKey selects the records from a timestamp in nanoseconds
+	StartTimeMetadataKey = "kpimon-start-time"
+	// EndTimeMetadataKey selects the records before a timestamp in nanoseconds
+	EndTimeMetadataKey = "kpimon-end-time"
+	// MinIntervalMetadataKey is the minimum interval in milliseconds between two WatchMeasurements updates of a cell
+	MinIntervalMetadataKey = "kpimon-min-interval-ms"
+	// ChangesOnlyMetadataKey is set to "true" by a WatchMeasurements client to skip the updates
+	// whose values are the same as the last update of the cell
+	ChangesOnlyMetadataKey = "kpimon-changes-only"
+	// OffsetMetadataKey is the number of entries to skip in ListMeasurements
+	OffsetMetadataKey = "kpimon-offset"
+	// LimitMetadataKey is the maximum number of entries returned by ListMeasurements
+	LimitMetadataKey = "kpimon-limit"
+	// TotalMetadataKey is the response header with the number of matching entries
+	TotalMetadataKey = "kpimon-total"
+	// NextOffsetMetadataKey is the response header with the offset of the next page, if any
+	NextOffsetMetadataKey = "kpimon-next-offset"
+)
+
+// getQuery gets the measurement store query requested through the gRPC metadata
+func getQuery(ctx context.Context) (measurementStore.Query, error) {
+	query := measurementStore.Query{}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return query, nil
+	}
+
+	query.NodeIDs = md.Get(NodeIDMetadataKey)
+	query.CellIDs = md.Get(CellIDMetadataKey)
+	query.CellGlobalIDs = md.Get(CellGlobalIDMetadataKey)
+	query.MeasurementNames = md.Get(MeasurementNameMetadataKey)
+
+	for _, value := range md.Get(PlmnIDMetadataKey) {
+		plmnID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return query, errors.NewInvalid("invalid %s value %s", PlmnIDMetadataKey, value)
+		}
+		query.PlmnIDs = append(query.PlmnIDs, uint32(plmnID))
+	}
+
+	var err error
+	if query.StartTime, err = getUint64(md, StartTimeMetadataKey); err != nil {
+		return query, err
+	}
+	if query.EndTime, err = getUint64(md, EndTimeMetadataKey); err != nil {
+		return query, err
+	}
+	if query.Offset, err = getInt(md, OffsetMetadataKey); err != nil {
+		return query, err
+	}
+	if query.Limit, err = getInt(md, LimitMetadataKey); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+// getWatchOptions gets the watch delivery options requested through the gRPC metadata
+func getWatchOptions(ctx context.Context) (watchOptions, error) {
+	options := watchOptions{}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return options, nil
+	}
+	if isTrue(md, ReplayMetadataKey) {
+		options.storeOptions = append(options.storeOptions, generic.WithReplay())
+	}
+	options.changesOnly = isTrue(md, ChangesOnlyMetadataKey)
+	minInterval, err := getUint64(md, MinIntervalMetadataKey)
+	if err != nil {
+		return options, err
+	}
+	options.minInterval = time.Duration(minInterval) * time.Millisecond
+	return options, nil
+}
+
+func isTrue(md metadata.MD, key string) bool {
+	for _, value := range md.Get(key) {
+		if value == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// newQueryResultMetadata creates the response header of a paginated query
+func newQueryResultMetadata(result *measurementStore.QueryResult) metadata.MD {
+	md := metadata.Pairs(TotalMetadataKey, strconv.Itoa(result.Total))
+	if result.NextOffset > 0 {
+		md.Set(NextOffsetMetadataKey, strconv.Itoa(result.NextOffset))
+	}
+	return md
+}
+
+func getUint64(md metadata.MD, key string) (uint64, error) {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return 0, nil
+	}
+	value, err := strconv.ParseUint(values[0], 10, 64)
+	if err != nil {
+		return 0, errors.NewInvalid("invalid %s value %s", key, values[0])
+	}
+	return value, nil
+}
+
+func getInt(md metadata.MD, key string) (int, error) {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return 0, nil
+	}
+	value, err := strconv.Atoi(values[0])
+	if err != nil || value < 0 {
+		return 0, errors.NewInvalid("invalid %s value %s", key, values[0])
+	}
+	return value, nil
+}
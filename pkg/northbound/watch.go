@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package northbound
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/generic"
+	measurementStore "github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// watchOptions are the delivery options of a measurement watch
+type watchOptions struct {
+	// query selects the cells and the records
+	query measurementStore.Query
+	// minInterval is the minimum interval between two updates of a cell; the updates in between are
+	// coalesced and the latest one is delivered once the interval has elapsed
+	minInterval time.Duration
+	// changesOnly skips the updates whose records have the same values as the last delivered update of the cell
+	changesOnly bool
+	// deletes delivers the deleted cells
+	deletes bool
+	// storeOptions are the options of the store watch, e.g. the replay
+	storeOptions []generic.WatchOption
+}
+
+// watchMeasurements watches the measurement store and sends the filtered, sampled and deduplicated events
+// until the context is done or the send fails; an Unavailable error is returned if the store disconnects the watch
+// The sequence number of a sent event is the resume point of the watch: every store event up to it has been
+// sent, superseded or filtered out, so that a watch resumed from it misses no update. This only holds if the
+// store never drops an event of the watch, hence the Disconnect policy of the resumable watches.
+func watchMeasurements(ctx context.Context, store measurementStore.Store, options watchOptions, send func(measurementStore.Event) error) error {
+	ch := make(chan measurementStore.Event)
+	err := store.Watch(ctx, ch, options.storeOptions...)
+	if err != nil {
+		return err
+	}
+	w := &measurementWatch{
+		options:  options,
+		send:     send,
+		lastSent: make(map[measurementStore.Key]time.Time),
+		last:     make(map[measurementStore.Key]*measurementStore.Entry),
+		pending:  make(map[measurementStore.Key]measurementStore.Event),
+	}
+	return w.run(ctx, ch)
+}
+
+type measurementWatch struct {
+	options  watchOptions
+	send     func(measurementStore.Event) error
+	lastSent map[measurementStore.Key]time.Time
+	// last holds the last delivered entry of each cell
+	last map[measurementStore.Key]*measurementStore.Entry
+	// pending holds the coalesced updates waiting for the minimum interval to elapse
+	pending map[measurementStore.Key]measurementStore.Event
+	// sequence is the sequence number of the last handled store event
+	sequence uint64
+}
+
+func (w *measurementWatch) run(ctx context.Context, ch <-chan measurementStore.Event) error {
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for {
+		var timerCh <-chan time.Time
+		if next, ok := w.nextDue(); ok {
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.NewTimer(time.Until(next))
+			timerCh = timer.C
+		}
+
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return errors.NewUnavailable("the watch fell behind the measurement updates and was disconnected")
+			}
+			if err := w.handle(e); err != nil {
+				return err
+			}
+		case <-timerCh:
+			if err := w.flush(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (w *measurementWatch) handle(e measurementStore.Event) error {
+	w.sequence = e.Sequence
+	if e.Type == measurementStore.Resync {
+		// the current entries follow, so the state of the previous events is moot
+		w.pending = make(map[measurementStore.Key]measurementStore.Event)
+		w.last = make(map[measurementStore.Key]*measurementStore.Entry)
+		w.lastSent = make(map[measurementStore.Key]time.Time)
+		return w.send(e)
+	}
+
+	key := e.Value.Key
+	if e.Type == measurementStore.Deleted {
+		if !w.options.query.MatchKey(e.Value) {
+			return nil
+		}
+		delete(w.pending, key)
+		delete(w.last, key)
+		delete(w.lastSent, key)
+		if !w.options.deletes {
+			return nil
+		}
+		e.Sequence = w.resumeSequence()
+		return w.send(e)
+	}
+
+	entry, ok := w.options.query.Filter(e.Value)
+	if !ok {
+		return nil
+	}
+	if w.options.changesOnly {
+		if last, ok := w.last[key]; ok && sameValues(last, entry) {
+			// back to the delivered values, so the pending update is moot
+			delete(w.pending, key)
+			return nil
+		}
+	}
+
+	filtered := measurementStore.Event{
+		Type:     e.Type,
+		Key:      e.Key,
+		Value:    entry,
+		Sequence: e.Sequence,
+	}
+	if w.options.minInterval > 0 {
+		if lastSent, ok := w.lastSent[key]; ok && time.Since(lastSent) < w.options.minInterval {
+			if pending, ok := w.pending[key]; ok && pending.Type == measurementStore.Created {
+				filtered.Type = measurementStore.Created
+			}
+			w.pending[key] = filtered
+			return nil
+		}
+	}
+	return w.deliver(filtered)
+}
+
+func (w *measurementWatch) deliver(e measurementStore.Event) error {
+	key := e.Value.Key
+	delete(w.pending, key)
+	w.lastSent[key] = time.Now()
+	w.last[key] = e.Value
+	e.Sequence = w.resumeSequence()
+	return w.send(e)
+}
+
+// resumeSequence gets the sequence number a watch can be resumed from without missing the pending updates
+func (w *measurementWatch) resumeSequence() uint64 {
+	sequence := w.sequence
+	for _, e := range w.pending {
+		if e.Sequence <= sequence {
+			sequence = e.Sequence - 1
+		}
+	}
+	return sequence
+}
+
+// flush delivers the pending updates whose minimum interval has elapsed
+func (w *measurementWatch) flush() error {
+	for key, e := range w.pending {
+		if time.Since(w.lastSent[key]) < w.options.minInterval {
+			continue
+		}
+		if err := w.deliver(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextDue gets the time the earliest pending update is due
+func (w *measurementWatch) nextDue() (time.Time, bool) {
+	var next time.Time
+	for key := range w.pending {
+		due := w.lastSent[key].Add(w.options.minInterval)
+		if next.IsZero() || due.Before(next) {
+			next = due
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// sameValues returns true if two entries have the same staleness and the same records regardless of their timestamps
+func sameValues(a, b *measurementStore.Entry) bool {
+	if a.Stale != b.Stale || len(a.Value) != len(b.Value) {
+		return false
+	}
+	for i := range a.Value {
+		recordsA, recordsB := a.Value[i].MeasurementRecords, b.Value[i].MeasurementRecords
+		if len(recordsA) != len(recordsB) {
+			return false
+		}
+		for j := range recordsA {
+			if recordsA[j].MeasurementName != recordsB[j].MeasurementName ||
+				recordsA[j].MeasurementValue != recordsB[j].MeasurementValue ||
+				!reflect.DeepEqual(recordsA[j].Labels, recordsB[j].Labels) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package northbound
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/generic"
+	measurementStore "github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-kpimon/pkg/store/watcher"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const testInterval = 100 * time.Millisecond
+
+func newTestKey(cellID string) measurementStore.Key {
+	return measurementStore.NewKey(measurementStore.CellIdentity{CellID: cellID}, "e2:1")
+}
+
+func newTestItems(name string, value int64) []measurementStore.MeasurementItem {
+	return []measurementStore.MeasurementItem{{
+		MeasurementRecords: []measurementStore.MeasurementRecord{{
+			Timestamp:        uint64(time.Now().UnixNano()),
+			MeasurementName:  name,
+			MeasurementValue: value,
+		}},
+	}}
+}
+
+func put(t *testing.T, store measurementStore.Store, cellID string, value int64) {
+	_, err := store.Put(context.Background(), newTestKey(cellID), newTestItems("A", value))
+	assert.NoError(t, err)
+}
+
+// startWatch watches the store and gets the channel of the sent events
+func startWatch(t *testing.T, ctx context.Context, store measurementStore.Store, options watchOptions) <-chan measurementStore.Event {
+	sent := make(chan measurementStore.Event, 100)
+	done := make(chan error, 1)
+	go func() {
+		done <- watchMeasurements(ctx, store, options, func(e measurementStore.Event) error {
+			sent <- e
+			return nil
+		})
+	}()
+	// the events put before the watch is started are not expected
+	time.Sleep(10 * time.Millisecond)
+	t.Cleanup(func() {
+		assert.NoError(t, <-done)
+	})
+	return sent
+}
+
+// sentEvent is the type, cell and value of a sent event
+type sentEvent struct {
+	eventType measurementStore.MeasurementEvent
+	cellID    string
+	value     interface{}
+}
+
+func nextEvents(t *testing.T, ch <-chan measurementStore.Event, n int) []sentEvent {
+	events := make([]sentEvent, 0, n)
+	for i := 0; i < n; i++ {
+		select {
+		case e := <-ch:
+			var value interface{}
+			if len(e.Value.Value) > 0 && len(e.Value.Value[0].MeasurementRecords) > 0 {
+				value = e.Value.Value[0].MeasurementRecords[0].MeasurementValue
+			}
+			events = append(events, sentEvent{e.Type, e.Value.Key.CellIdentity.CellID, value})
+		case <-time.After(time.Second):
+			t.Fatalf("received %d of %d events", i, n)
+		}
+	}
+	return events
+}
+
+func assertNoEvent(t *testing.T, ch <-chan measurementStore.Event, wait time.Duration) {
+	select {
+	case e := <-ch:
+		t.Fatalf("unexpected event %v", e)
+	case <-time.After(wait):
+	}
+}
+
+func TestWatchMinInterval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := measurementStore.NewStore()
+	ch := startWatch(t, ctx, store, watchOptions{minInterval: testInterval})
+
+	// the first update of a cell is sent at once
+	start := time.Now()
+	put(t, store, "1", 1)
+	assert.Equal(t, []sentEvent{{measurementStore.Created, "1", int64(1)}}, nextEvents(t, ch, 1))
+
+	// the updates within the interval are coalesced into the latest one; the other cells are not throttled
+	put(t, store, "1", 2)
+	put(t, store, "1", 3)
+	put(t, store, "2", 10)
+	assert.Equal(t, []sentEvent{{measurementStore.Created, "2", int64(10)}}, nextEvents(t, ch, 1))
+	assert.Equal(t, []sentEvent{{measurementStore.Updated, "1", int64(3)}}, nextEvents(t, ch, 1))
+	assert.GreaterOrEqual(t, time.Since(start), testInterval)
+	assertNoEvent(t, ch, 2*testInterval)
+}
+
+func TestWatchMinIntervalDelete(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := measurementStore.NewStore()
+	ch := startWatch(t, ctx, store, watchOptions{minInterval: testInterval, deletes: true})
+
+	put(t, store, "1", 1)
+	assert.Equal(t, []sentEvent{{measurementStore.Created, "1", int64(1)}}, nextEvents(t, ch, 1))
+	// the pending update of a deleted cell is dropped
+	put(t, store, "1", 2)
+	assert.NoError(t, store.Delete(ctx, newTestKey("1")))
+	assert.Equal(t, measurementStore.Deleted, nextEvents(t, ch, 1)[0].eventType)
+	assertNoEvent(t, ch, 2*testInterval)
+}
+
+func TestWatchFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		options watchOptions
+		update  func(t *testing.T, store measurementStore.Store)
+		events  []sentEvent
+	}{
+		{
+			name:    "all updates",
+			options: watchOptions{},
+			update: func(t *testing.T, store measurementStore.Store) {
+				put(t, store, "1", 1)
+				put(t, store, "1", 1)
+				assert.NoError(t, store.Delete(context.Background(), newTestKey("1")))
+			},
+			events: []sentEvent{
+				{measurementStore.Created, "1", int64(1)},
+				{measurementStore.Updated, "1", int64(1)},
+			},
+		},
+		{
+			name:    "changes only",
+			options: watchOptions{changesOnly: true},
+			update: func(t *testing.T, store measurementStore.Store) {
+				put(t, store, "1", 1)
+				put(t, store, "1", 1)
+				put(t, store, "1", 2)
+			},
+			events: []sentEvent{
+				{measurementStore.Created, "1", int64(1)},
+				{measurementStore.Updated, "1", int64(2)},
+			},
+		},
+		{
+			name:    "deletes",
+			options: watchOptions{deletes: true},
+			update: func(t *testing.T, store measurementStore.Store) {
+				put(t, store, "1", 1)
+				assert.NoError(t, store.Delete(context.Background(), newTestKey("1")))
+			},
+			events: []sentEvent{
+				{measurementStore.Created, "1", int64(1)},
+				{measurementStore.Deleted, "1", int64(1)},
+			},
+		},
+		{
+			name:    "cell query",
+			options: watchOptions{query: measurementStore.Query{CellIDs: []string{"2"}}, deletes: true},
+			update: func(t *testing.T, store measurementStore.Store) {
+				put(t, store, "1", 1)
+				put(t, store, "2", 2)
+				assert.NoError(t, store.Delete(context.Background(), newTestKey("1")))
+			},
+			events: []sentEvent{{measurementStore.Created, "2", int64(2)}},
+		},
+		{
+			name:    "measurement name query",
+			options: watchOptions{query: measurementStore.Query{MeasurementNames: []string{"B"}}},
+			update: func(t *testing.T, store measurementStore.Store) {
+				put(t, store, "1", 1)
+				_, err := store.Put(context.Background(), newTestKey("2"), newTestItems("B", 2))
+				assert.NoError(t, err)
+			},
+			events: []sentEvent{{measurementStore.Created, "2", int64(2)}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			store := measurementStore.NewStore()
+			ch := startWatch(t, ctx, store, test.options)
+			test.update(t, store)
+			assert.Equal(t, test.events, nextEvents(t, ch, len(test.events)))
+			assertNoEvent(t, ch, 50*time.Millisecond)
+		})
+	}
+}
+
+func TestWatchDisconnected(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := measurementStore.NewStore(measurementStore.WithWatcherQueueSize(1))
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	options := watchOptions{
+		storeOptions: []generic.WatchOption{generic.WithSlowConsumerPolicy(watcher.Disconnect)},
+	}
+	go func() {
+		done <- watchMeasurements(ctx, store, options, func(e measurementStore.Event) error {
+			<-release
+			return nil
+		})
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	// a watch falling behind is disconnected rather than skipping updates past its resume point
+	for i := int64(1); i <= 4; i++ {
+		put(t, store, "1", i)
+	}
+	close(release)
+	select {
+	case err := <-done:
+		assert.True(t, errors.IsUnavailable(err), err)
+	case <-time.After(time.Second):
+		t.Fatal("the watch was not disconnected")
+	}
+}
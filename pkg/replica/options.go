@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package replica
+
+import "time"
+
+// Options replicator options
+type Options struct {
+	// Interval is the interval at which the leader state is copied
+	Interval time.Duration
+}
+
+// Option replicator option interface
+type Option interface {
+	apply(*Options)
+}
+
+type funcOption struct {
+	f func(*Options)
+}
+
+func (f funcOption) apply(options *Options) {
+	f.f(options)
+}
+
+func newOption(f func(*Options)) Option {
+	return funcOption{
+		f: f,
+	}
+}
+
+// WithInterval sets the replication interval
+func WithInterval(interval time.Duration) Option {
+	return newOption(func(options *Options) {
+		if interval > 0 {
+			options.Interval = interval
+		}
+	})
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package replica
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	adminapi "github.com/onosproject/onos-kpimon/api/admin/v1"
+	"github.com/onosproject/onos-kpimon/pkg/snapshot"
+	"github.com/onosproject/onos-lib-go/pkg/grpc/retry"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/onos-ric-sdk-go/pkg/utils/creds"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var log = logging.GetLogger()
+
+const defaultInterval = time.Second
+
+// NewReplicator creates a replicator that copies the state of the leader into the local stores
+func NewReplicator(snapshots *snapshot.Manager, opts ...Option) *Replicator {
+	options := Options{
+		Interval: defaultInterval,
+	}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	return &Replicator{
+		snapshots: snapshots,
+		options:   options,
+	}
+}
+
+// Replicator keeps the stores of a standby replica up to date with the leader state,
+// so that the standby serves the northbound reads and can take over without a cold start
+type Replicator struct {
+	snapshots *snapshot.Manager
+	options   Options
+}
+
+// Replicate copies the state of the leader at a given northbound address every interval until the context is done
+func (r *Replicator) Replicate(ctx context.Context, address string) error {
+	tlsConfig, err := creds.GetClientCredentials()
+	if err != nil {
+		return err
+	}
+	conn, err := grpc.DialContext(ctx, address,
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithUnaryInterceptor(retry.RetryingUnaryClientInterceptor()))
+	if err != nil {
+		return err
+	}
+	client := adminapi.NewAdminClient(conn)
+
+	log.Infof("Replicating the leader state from %s", address)
+	go func() {
+		defer conn.Close()
+		ticker := time.NewTicker(r.options.Interval)
+		defer ticker.Stop()
+		for {
+			err := r.replicate(ctx, client)
+			if err != nil && ctx.Err() == nil {
+				log.Warnf("Failed to replicate the leader state from %s: %v", address, err)
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				log.Infof("Stopped replicating the leader state from %s", address)
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *Replicator) replicate(ctx context.Context, client adminapi.AdminClient) error {
+	response, err := client.CreateSnapshot(ctx, &adminapi.CreateSnapshotRequest{})
+	if err != nil {
+		return err
+	}
+	snap, err := snapshot.Read(bytes.NewReader(response.GetData()))
+	if err != nil {
+		return err
+	}
+	return r.snapshots.Restore(ctx, snap)
+}
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rnib
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	aspectsapi "github.com/onosproject/onos-kpimon/api/aspects/v1"
+	measurmentStore "github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	valueutils "github.com/onosproject/onos-kpimon/pkg/utils/values"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// E2NodeKPIsAspectType is the type of the E2NodeKPIs aspect written to the E2 node entities
+var E2NodeKPIsAspectType = string(proto.MessageName(&aspectsapi.E2NodeKPIs{}))
+
+// AggregateFunction is a function aggregating the values of a measurement over the cells of an E2 node
+type AggregateFunction string
+
+const (
+	// Sum sums the values
+	Sum AggregateFunction = "sum"
+	// Avg averages the values
+	Avg AggregateFunction = "avg"
+	// Min gets the minimum value
+	Min AggregateFunction = "min"
+	// Max gets the maximum value
+	Max AggregateFunction = "max"
+)
+
+// Aggregate is an E2 node level aggregate of a measurement
+type Aggregate struct {
+	MeasurementName string
+	Function        AggregateFunction
+}
+
+// ParseAggregates parses comma separated "name:function" aggregates, e.g. "RRC.ConnEstabSucc.Sum:sum,DRB.UEThpDl:avg"
+func ParseAggregates(aggregates string) ([]Aggregate, error) {
+	var result []Aggregate
+	for _, aggregate := range strings.Split(aggregates, ",") {
+		aggregate = strings.TrimSpace(aggregate)
+		if aggregate == "" {
+			continue
+		}
+		i := strings.LastIndex(aggregate, ":")
+		if i <= 0 {
+			return nil, errors.NewInvalid("invalid aggregate %s: expected name:function", aggregate)
+		}
+		function := AggregateFunction(strings.TrimSpace(aggregate[i+1:]))
+		switch function {
+		case Sum, Avg, Min, Max:
+		default:
+			return nil, errors.NewInvalid("invalid aggregate %s: unknown function %s", aggregate, function)
+		}
+		result = append(result, Aggregate{
+			MeasurementName: strings.TrimSpace(aggregate[:i]),
+			Function:        function,
+		})
+	}
+	return result, nil
+}
+
+// NewE2NodeKPIs creates the E2NodeKPIs aspect of an E2 node from the measurement entries of its cells
+// Each aggregate is computed over the latest record of each cell, per label set; the records without value are skipped.
+func NewE2NodeKPIs(entries []*measurmentStore.Entry, aggregates []Aggregate) *aspectsapi.E2NodeKPIs {
+	type group struct {
+		name      string
+		labels    map[string]string
+		values    []float64
+		timestamp uint64
+	}
+
+	// groups holds the latest cell values of each measurement name and label set
+	groups := make(map[string]*group)
+	for _, entry := range entries {
+		latest := latestRecords(entry.Value)
+		for key, record := range latest {
+			value, ok := valueutils.ToFloat64(record.MeasurementValue)
+			if !ok {
+				continue
+			}
+			g, ok := groups[key]
+			if !ok {
+				g = &group{
+					name:   record.MeasurementName,
+					labels: record.Labels,
+				}
+				groups[key] = g
+			}
+			g.values = append(g.values, value)
+			if record.Timestamp > g.timestamp {
+				g.timestamp = record.Timestamp
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	e2NodeKPIs := &aspectsapi.E2NodeKPIs{
+		UpdatedAt: uint64(time.Now().UnixNano()),
+	}
+	for _, aggregate := range aggregates {
+		for _, key := range keys {
+			g := groups[key]
+			if g.name != aggregate.MeasurementName {
+				continue
+			}
+			e2NodeKPIs.Aggregates = append(e2NodeKPIs.Aggregates, &aspectsapi.AggregateKPI{
+				Name:      aggregate.MeasurementName,
+				Function:  string(aggregate.Function),
+				Labels:    g.labels,
+				Value:     aggregate.Function.apply(g.values),
+				Cells:     uint32(len(g.values)),
+				Timestamp: g.timestamp,
+			})
+		}
+	}
+	return e2NodeKPIs
+}
+
+func (f AggregateFunction) apply(values []float64) float64 {
+	switch f {
+	case Sum:
+		return valueutils.Sum(values)
+	case Avg:
+		return valueutils.Avg(values)
+	case Min:
+		return valueutils.Min(values)
+	case Max:
+		return valueutils.Max(values)
+	default:
+		return 0
+	}
+}
+
+// marshalE2NodeKPIs encodes the E2NodeKPIs aspect as JSON, the encoding of the topo aspects
+func marshalE2NodeKPIs(e2NodeKPIs *aspectsapi.E2NodeKPIs) ([]byte, error) {
+	return protojson.Marshal(e2NodeKPIs)
+}
+
+// UnmarshalE2NodeKPIs decodes the E2NodeKPIs aspect of an E2 node entity
+func UnmarshalE2NodeKPIs(value []byte) (*aspectsapi.E2NodeKPIs, error) {
+	e2NodeKPIs := &aspectsapi.E2NodeKPIs{}
+	err := protojson.Unmarshal(value, e2NodeKPIs)
+	if err != nil {
+		return nil, err
+	}
+	return e2NodeKPIs, nil
+}
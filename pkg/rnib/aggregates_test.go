@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rnib
+
+import (
+	"testing"
+
+	aspectsapi "github.com/onosproject/onos-kpimon/api/aspects/v1"
+	measurmentStore "github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseAggregates(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		aggregates []Aggregate
+		isError    func(error) bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "aggregates",
+			value: " RRC.ConnEstabSucc.Sum:sum, DRB.UEThpDl : avg,,A:min,B:max",
+			aggregates: []Aggregate{
+				{MeasurementName: "RRC.ConnEstabSucc.Sum", Function: Sum},
+				{MeasurementName: "DRB.UEThpDl", Function: Avg},
+				{MeasurementName: "A", Function: Min},
+				{MeasurementName: "B", Function: Max},
+			},
+		},
+		{
+			name:       "name with a colon",
+			value:      "a:b:sum",
+			aggregates: []Aggregate{{MeasurementName: "a:b", Function: Sum}},
+		},
+		{
+			name:    "no function",
+			value:   "A",
+			isError: errors.IsInvalid,
+		},
+		{
+			name:    "no name",
+			value:   ":sum",
+			isError: errors.IsInvalid,
+		},
+		{
+			name:    "unknown function",
+			value:   "A:median",
+			isError: errors.IsInvalid,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			aggregates, err := ParseAggregates(test.value)
+			if test.isError != nil {
+				assert.True(t, test.isError(err), err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.aggregates, aggregates)
+		})
+	}
+}
+
+func newTestEntry(cellID string, records ...measurmentStore.MeasurementRecord) *measurmentStore.Entry {
+	return &measurmentStore.Entry{
+		Key:   measurmentStore.NewKey(measurmentStore.CellIdentity{CellID: cellID}, "e2:1"),
+		Value: newTestItems(records...),
+	}
+}
+
+func TestNewE2NodeKPIs(t *testing.T) {
+	entries := []*measurmentStore.Entry{
+		newTestEntry("1",
+			measurmentStore.MeasurementRecord{Timestamp: 1, MeasurementName: "A", MeasurementValue: int64(10)},
+			// only the latest record of a cell is aggregated
+			measurmentStore.MeasurementRecord{Timestamp: 2, MeasurementName: "A", MeasurementValue: int64(2)},
+			measurmentStore.MeasurementRecord{Timestamp: 1, MeasurementName: "B", MeasurementValue: 1.5, Labels: map[string]string{"slice_id": "1"}}),
+		newTestEntry("2",
+			measurmentStore.MeasurementRecord{Timestamp: 3, MeasurementName: "A", MeasurementValue: 4.0},
+			measurmentStore.MeasurementRecord{Timestamp: 1, MeasurementName: "B", MeasurementValue: 2.5, Labels: map[string]string{"slice_id": "1"}},
+			measurmentStore.MeasurementRecord{Timestamp: 1, MeasurementName: "B", MeasurementValue: 7.0, Labels: map[string]string{"slice_id": "2"}}),
+		// the records without value are skipped
+		newTestEntry("3",
+			measurmentStore.MeasurementRecord{Timestamp: 4, MeasurementName: "A", MeasurementValue: int32(0)}),
+	}
+
+	tests := []struct {
+		name       string
+		aggregates []Aggregate
+		kpis       []*aspectsapi.AggregateKPI
+	}{
+		{
+			name: "no aggregate",
+		},
+		{
+			name:       "sum",
+			aggregates: []Aggregate{{MeasurementName: "A", Function: Sum}},
+			kpis:       []*aspectsapi.AggregateKPI{{Name: "A", Function: "sum", Value: 6, Cells: 2, Timestamp: 3}},
+		},
+		{
+			name:       "avg",
+			aggregates: []Aggregate{{MeasurementName: "A", Function: Avg}},
+			kpis:       []*aspectsapi.AggregateKPI{{Name: "A", Function: "avg", Value: 3, Cells: 2, Timestamp: 3}},
+		},
+		{
+			name:       "min",
+			aggregates: []Aggregate{{MeasurementName: "A", Function: Min}},
+			kpis:       []*aspectsapi.AggregateKPI{{Name: "A", Function: "min", Value: 2, Cells: 2, Timestamp: 3}},
+		},
+		{
+			name:       "max per label set",
+			aggregates: []Aggregate{{MeasurementName: "B", Function: Max}},
+			kpis: []*aspectsapi.AggregateKPI{
+				{Name: "B", Function: "max", Labels: map[string]string{"slice_id": "1"}, Value: 2.5, Cells: 2, Timestamp: 1},
+				{Name: "B", Function: "max", Labels: map[string]string{"slice_id": "2"}, Value: 7, Cells: 1, Timestamp: 1},
+			},
+		},
+		{
+			name:       "unknown measurement",
+			aggregates: []Aggregate{{MeasurementName: "C", Function: Sum}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e2NodeKPIs := NewE2NodeKPIs(entries, test.aggregates)
+			assert.NotZero(t, e2NodeKPIs.GetUpdatedAt())
+			assert.Len(t, e2NodeKPIs.GetAggregates(), len(test.kpis))
+			for i, kpi := range test.kpis {
+				assert.True(t, proto.Equal(kpi, e2NodeKPIs.GetAggregates()[i]), "%v", e2NodeKPIs.GetAggregates()[i])
+			}
+
+			// the aspect round trips through its topo encoding
+			value, err := marshalE2NodeKPIs(e2NodeKPIs)
+			assert.NoError(t, err)
+			decoded, err := UnmarshalE2NodeKPIs(value)
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(e2NodeKPIs, decoded))
+		})
+	}
+}
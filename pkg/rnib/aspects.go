@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rnib
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	aspectsapi "github.com/onosproject/onos-kpimon/api/aspects/v1"
+	measurmentStore "github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// CellKPIsAspectType is the type of the CellKPIs aspect written to the cell entities
+var CellKPIsAspectType = string(proto.MessageName(&aspectsapi.CellKPIs{}))
+
+// NewKpiReports creates the KPI reports of a cell aspect from the latest records of the measurement items
+// The reports only hold unsigned 32 bit integers: real values are rounded and the values out of range are clamped.
+func NewKpiReports(measItems []measurmentStore.MeasurementItem) map[string]uint32 {
+	latest := make(map[string]measurmentStore.MeasurementRecord)
+	for _, measItem := range measItems {
+		for _, record := range measItem.MeasurementRecords {
+			if current, ok := latest[record.MeasurementName]; !ok || current.Timestamp <= record.Timestamp {
+				latest[record.MeasurementName] = record
+			}
+		}
+	}
+
+	kpiReports := make(map[string]uint32, len(latest))
+	for name, record := range latest {
+		switch val := record.MeasurementValue.(type) {
+		case int64:
+			kpiReports[name] = toUint32(float64(val))
+		case float64:
+			kpiReports[name] = toUint32(math.Round(val))
+		default:
+			kpiReports[name] = uint32(0)
+		}
+	}
+	return kpiReports
+}
+
+func toUint32(val float64) uint32 {
+	switch {
+	case math.IsNaN(val) || val < 0:
+		return 0
+	case val > math.MaxUint32:
+		return math.MaxUint32
+	default:
+		return uint32(val)
+	}
+}
+
+// NewCellKPIs creates the CellKPIs aspect from the latest records of the measurement items,
+// keeping one record per measurement name and label set
+func NewCellKPIs(measItems []measurmentStore.MeasurementItem, granularityPeriod uint64) *aspectsapi.CellKPIs {
+	latest := latestRecords(measItems)
+
+	keys := make([]string, 0, len(latest))
+	for key := range latest {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	cellKPIs := &aspectsapi.CellKPIs{
+		Kpis:              make([]*aspectsapi.KPI, 0, len(keys)),
+		GranularityPeriod: granularityPeriod,
+		UpdatedAt:         uint64(time.Now().UnixNano()),
+	}
+	for _, key := range keys {
+		record := latest[key]
+		kpi := &aspectsapi.KPI{
+			Name:      record.MeasurementName,
+			Labels:    record.Labels,
+			Timestamp: record.Timestamp,
+		}
+		switch val := record.MeasurementValue.(type) {
+		case int64:
+			kpi.Value = &aspectsapi.KPI_Integer{Integer: val}
+		case float64:
+			kpi.Value = &aspectsapi.KPI_Real{Real: val}
+		case int32:
+			kpi.Value = &aspectsapi.KPI_NoValue{NoValue: val}
+		}
+		cellKPIs.Kpis = append(cellKPIs.Kpis, kpi)
+	}
+	return cellKPIs
+}
+
+// latestRecords gets the latest record of each measurement name and label set keyed by their series key
+func latestRecords(measItems []measurmentStore.MeasurementItem) map[string]measurmentStore.MeasurementRecord {
+	latest := make(map[string]measurmentStore.MeasurementRecord)
+	for _, measItem := range measItems {
+		for _, record := range measItem.MeasurementRecords {
+			key := record.SeriesKey()
+			if current, ok := latest[key]; !ok || current.Timestamp <= record.Timestamp {
+				latest[key] = record
+			}
+		}
+	}
+	return latest
+}
+
+// marshalCellKPIs encodes the CellKPIs aspect as JSON, the encoding of the topo aspects
+func marshalCellKPIs(cellKPIs *aspectsapi.CellKPIs) ([]byte, error) {
+	return protojson.Marshal(cellKPIs)
+}
+
+// UnmarshalCellKPIs decodes the CellKPIs aspect of a cell entity
+func UnmarshalCellKPIs(value []byte) (*aspectsapi.CellKPIs, error) {
+	cellKPIs := &aspectsapi.CellKPIs{}
+	err := protojson.Unmarshal(value, cellKPIs)
+	if err != nil {
+		return nil, err
+	}
+	return cellKPIs, nil
+}
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rnib
+
+import (
+	"math"
+	"testing"
+
+	aspectsapi "github.com/onosproject/onos-kpimon/api/aspects/v1"
+	measurmentStore "github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestItems(records ...measurmentStore.MeasurementRecord) []measurmentStore.MeasurementItem {
+	return []measurmentStore.MeasurementItem{{MeasurementRecords: records}}
+}
+
+func TestNewKpiReports(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []measurmentStore.MeasurementRecord
+		reports map[string]uint32
+	}{
+		{
+			name: "integer and real",
+			records: []measurmentStore.MeasurementRecord{
+				{Timestamp: 1, MeasurementName: "A", MeasurementValue: int64(3)},
+				{Timestamp: 1, MeasurementName: "B", MeasurementValue: 2.6},
+			},
+			reports: map[string]uint32{"A": 3, "B": 3},
+		},
+		{
+			name: "latest record",
+			records: []measurmentStore.MeasurementRecord{
+				{Timestamp: 2, MeasurementName: "A", MeasurementValue: int64(5)},
+				{Timestamp: 1, MeasurementName: "A", MeasurementValue: int64(3)},
+			},
+			reports: map[string]uint32{"A": 5},
+		},
+		{
+			name: "clamped",
+			records: []measurmentStore.MeasurementRecord{
+				{Timestamp: 1, MeasurementName: "A", MeasurementValue: int64(-1)},
+				{Timestamp: 1, MeasurementName: "B", MeasurementValue: int64(math.MaxUint32 + 1)},
+				{Timestamp: 1, MeasurementName: "C", MeasurementValue: math.NaN()},
+			},
+			reports: map[string]uint32{"A": 0, "B": math.MaxUint32, "C": 0},
+		},
+		{
+			name: "no value",
+			records: []measurmentStore.MeasurementRecord{
+				{Timestamp: 1, MeasurementName: "A", MeasurementValue: int32(0)},
+			},
+			reports: map[string]uint32{"A": 0},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.reports, NewKpiReports(newTestItems(test.records...)))
+		})
+	}
+}
+
+func TestNewCellKPIs(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []measurmentStore.MeasurementRecord
+		kpis    []*aspectsapi.KPI
+	}{
+		{
+			name: "typed values",
+			records: []measurmentStore.MeasurementRecord{
+				{Timestamp: 1, MeasurementName: "C", MeasurementValue: int32(0)},
+				{Timestamp: 1, MeasurementName: "A", MeasurementValue: int64(math.MaxInt64)},
+				{Timestamp: 1, MeasurementName: "B", MeasurementValue: 2.5},
+			},
+			kpis: []*aspectsapi.KPI{
+				{Name: "A", Timestamp: 1, Value: &aspectsapi.KPI_Integer{Integer: math.MaxInt64}},
+				{Name: "B", Timestamp: 1, Value: &aspectsapi.KPI_Real{Real: 2.5}},
+				{Name: "C", Timestamp: 1, Value: &aspectsapi.KPI_NoValue{NoValue: 0}},
+			},
+		},
+		{
+			name: "latest record per label set",
+			records: []measurmentStore.MeasurementRecord{
+				{Timestamp: 1, MeasurementName: "A", MeasurementValue: int64(1), Labels: map[string]string{"slice_id": "1"}},
+				{Timestamp: 2, MeasurementName: "A", MeasurementValue: int64(2), Labels: map[string]string{"slice_id": "1"}},
+				{Timestamp: 1, MeasurementName: "A", MeasurementValue: int64(3), Labels: map[string]string{"slice_id": "2"}},
+			},
+			kpis: []*aspectsapi.KPI{
+				{Name: "A", Timestamp: 2, Labels: map[string]string{"slice_id": "1"}, Value: &aspectsapi.KPI_Integer{Integer: 2}},
+				{Name: "A", Timestamp: 1, Labels: map[string]string{"slice_id": "2"}, Value: &aspectsapi.KPI_Integer{Integer: 3}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cellKPIs := NewCellKPIs(newTestItems(test.records...), 1000)
+			assert.Equal(t, uint64(1000), cellKPIs.GetGranularityPeriod())
+			assert.NotZero(t, cellKPIs.GetUpdatedAt())
+			assert.Len(t, cellKPIs.GetKpis(), len(test.kpis))
+			for i, kpi := range test.kpis {
+				assert.True(t, proto.Equal(kpi, cellKPIs.GetKpis()[i]), "%v", cellKPIs.GetKpis()[i])
+			}
+
+			// the aspect round trips through its topo encoding
+			value, err := marshalCellKPIs(cellKPIs)
+			assert.NoError(t, err)
+			decoded, err := UnmarshalCellKPIs(value)
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(cellKPIs, decoded))
+		})
+	}
+}
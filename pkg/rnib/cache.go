@@ -0,0 +1,242 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rnib
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	toposdk "github.com/onosproject/onos-ric-sdk-go/pkg/topo"
+)
+
+const cacheRetryInterval = time.Second
+
+// relation is a cached topo relation
+type relation struct {
+	kind topoapi.ID
+	src  topoapi.ID
+	tgt  topoapi.ID
+}
+
+// Cache is a view of the E2 nodes, the E2 cells and their relations kept up to date by a topo watch
+// The cache is only used while it is synced; in between, for instance while the watch is
+// re-established, the lookups fall back to topo.
+type Cache struct {
+	client    toposdk.Client
+	mu        sync.RWMutex
+	synced    bool
+	e2Nodes   map[topoapi.ID]*topoapi.E2Node
+	cells     map[topoapi.ID]*topoapi.E2Cell
+	relations map[topoapi.ID]relation
+	// nodeCells indexes the cell entity IDs contained by each E2 node
+	nodeCells map[topoapi.ID]map[topoapi.ID]bool
+}
+
+// NewCache creates a new topo cache; it is filled once started
+func NewCache(client toposdk.Client) *Cache {
+	c := &Cache{
+		client: client,
+	}
+	c.reset()
+	return c
+}
+
+// Start fills the cache from topo and keeps it up to date until the context is done
+func (c *Cache) Start(ctx context.Context) error {
+	ch, err := c.sync(ctx)
+	if err != nil {
+		return err
+	}
+	go c.watch(ctx, ch)
+	return nil
+}
+
+// sync lists the topo objects and then watches their changes; the watch replay
+// re-applies the listed objects, which is harmless since events are idempotent
+func (c *Cache) sync(ctx context.Context) (chan topoapi.Event, error) {
+	objects, err := c.client.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan topoapi.Event)
+	err = c.client.Watch(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reset()
+	for i := range objects {
+		c.put(&objects[i])
+	}
+	c.synced = true
+	log.Infof("Topo cache synced with %d E2 nodes and %d cells", len(c.e2Nodes), len(c.cells))
+	return ch, nil
+}
+
+func (c *Cache) watch(ctx context.Context, ch chan topoapi.Event) {
+	for {
+		for event := range ch {
+			c.mu.Lock()
+			if event.Type == topoapi.EventType_REMOVED {
+				c.remove(&event.Object)
+			} else {
+				c.put(&event.Object)
+			}
+			c.mu.Unlock()
+		}
+
+		c.mu.Lock()
+		c.synced = false
+		c.mu.Unlock()
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warn("Topo cache watch closed, falling back to topo until it is synced again")
+
+		for {
+			select {
+			case <-time.After(cacheRetryInterval):
+			case <-ctx.Done():
+				return
+			}
+			var err error
+			ch, err = c.sync(ctx)
+			if err == nil {
+				break
+			}
+			log.Warn(err)
+		}
+	}
+}
+
+// reset must be called with the lock held
+func (c *Cache) reset() {
+	c.e2Nodes = make(map[topoapi.ID]*topoapi.E2Node)
+	c.cells = make(map[topoapi.ID]*topoapi.E2Cell)
+	c.relations = make(map[topoapi.ID]relation)
+	c.nodeCells = make(map[topoapi.ID]map[topoapi.ID]bool)
+}
+
+// put must be called with the lock held
+func (c *Cache) put(object *topoapi.Object) {
+	switch object.GetType() {
+	case topoapi.Object_ENTITY:
+		switch object.GetEntity().GetKindID() {
+		case topoapi.E2NODE:
+			e2Node := &topoapi.E2Node{}
+			if err := object.GetAspect(e2Node); err != nil {
+				log.Debugf("E2 node %s has no E2Node aspect", object.ID)
+			}
+			c.e2Nodes[object.ID] = e2Node
+		case topoapi.E2CELL:
+			cell := &topoapi.E2Cell{}
+			if err := object.GetAspect(cell); err != nil {
+				log.Debugf("Cell entity %s has no E2Cell aspect", object.ID)
+				delete(c.cells, object.ID)
+				return
+			}
+			c.cells[object.ID] = cell
+		}
+	case topoapi.Object_RELATION:
+		kind := object.GetRelation().GetKindID()
+		if kind != topoapi.CONTROLS && kind != topoapi.CONTAINS {
+			return
+		}
+		rel := relation{
+			kind: kind,
+			src:  object.GetRelation().GetSrcEntityID(),
+			tgt:  object.GetRelation().GetTgtEntityID(),
+		}
+		c.relations[object.ID] = rel
+		if kind == topoapi.CONTAINS {
+			if c.nodeCells[rel.src] == nil {
+				c.nodeCells[rel.src] = make(map[topoapi.ID]bool)
+			}
+			c.nodeCells[rel.src][rel.tgt] = true
+		}
+	}
+}
+
+// remove must be called with the lock held
+func (c *Cache) remove(object *topoapi.Object) {
+	switch object.GetType() {
+	case topoapi.Object_ENTITY:
+		delete(c.e2Nodes, object.ID)
+		delete(c.cells, object.ID)
+	case topoapi.Object_RELATION:
+		rel, ok := c.relations[object.ID]
+		if !ok {
+			return
+		}
+		delete(c.relations, object.ID)
+		if rel.kind == topoapi.CONTAINS {
+			delete(c.nodeCells[rel.src], rel.tgt)
+			if len(c.nodeCells[rel.src]) == 0 {
+				delete(c.nodeCells, rel.src)
+			}
+		}
+	}
+}
+
+// GetE2Node gets the E2Node aspect of an E2 node; false is returned if the cache is not synced
+// or does not know the E2 node
+func (c *Cache) GetE2Node(nodeID topoapi.ID) (*topoapi.E2Node, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.synced {
+		return nil, false
+	}
+	e2Node, ok := c.e2Nodes[nodeID]
+	return e2Node, ok
+}
+
+// GetCells gets the cells of an E2 node; false is returned if the cache is not synced
+func (c *Cache) GetCells(nodeID topoapi.ID) ([]*topoapi.E2Cell, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.synced {
+		return nil, false
+	}
+	cellIDs := make([]topoapi.ID, 0, len(c.nodeCells[nodeID]))
+	for cellID := range c.nodeCells[nodeID] {
+		if _, ok := c.cells[cellID]; ok {
+			cellIDs = append(cellIDs, cellID)
+		}
+	}
+	sort.Slice(cellIDs, func(i, j int) bool {
+		return cellIDs[i] < cellIDs[j]
+	})
+	cells := make([]*topoapi.E2Cell, 0, len(cellIDs))
+	for _, cellID := range cellIDs {
+		cells = append(cells, c.cells[cellID])
+	}
+	return cells, true
+}
+
+// GetControlledE2NodeIDs gets the IDs of the E2 nodes controlled by an E2T; false is returned if the cache is not synced
+func (c *Cache) GetControlledE2NodeIDs() ([]topoapi.ID, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.synced {
+		return nil, false
+	}
+	nodeIDs := make([]topoapi.ID, 0)
+	seen := make(map[topoapi.ID]bool)
+	for _, rel := range c.relations {
+		if rel.kind == topoapi.CONTROLS && !seen[rel.tgt] {
+			seen[rel.tgt] = true
+			nodeIDs = append(nodeIDs, rel.tgt)
+		}
+	}
+	sort.Slice(nodeIDs, func(i, j int) bool {
+		return nodeIDs[i] < nodeIDs[j]
+	})
+	return nodeIDs, true
+}
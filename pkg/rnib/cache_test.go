@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rnib_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-kpimon/pkg/rnib"
+	"github.com/onosproject/onos-kpimon/pkg/rnib/fake"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const kpmOID = "1.3.6.1.4.1.53148.1.2.2.2"
+
+func newTestE2Node() *topoapi.E2Node {
+	return &topoapi.E2Node{
+		ServiceModels: map[string]*topoapi.ServiceModelInfo{
+			kpmOID: {OID: kpmOID},
+		},
+	}
+}
+
+func newTestCell(coi string, cgi string) *topoapi.E2Cell {
+	return &topoapi.E2Cell{
+		CellObjectID: coi,
+		CellGlobalID: &topoapi.CellGlobalID{Value: cgi},
+	}
+}
+
+// cellIDs gets the cell object IDs of the cached cells of an E2 node
+func cellIDs(cache *rnib.Cache, nodeID topoapi.ID) ([]string, bool) {
+	cells, ok := cache.GetCells(nodeID)
+	if !ok {
+		return nil, false
+	}
+	ids := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		ids = append(ids, cell.CellObjectID)
+	}
+	return ids, true
+}
+
+func TestCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	topo := fake.NewTopo()
+	topo.AddE2Node("e2:1", newTestE2Node())
+	topo.AddCell("e2:1", newTestCell("1", "cgi-1"))
+
+	cache := rnib.NewCache(topo.Client())
+	// the lookups are not served until the cache is synced
+	_, ok := cache.GetCells("e2:1")
+	assert.False(t, ok)
+	_, ok = cache.GetControlledE2NodeIDs()
+	assert.False(t, ok)
+	assert.NoError(t, cache.Start(ctx))
+
+	var cellID topoapi.ID
+	tests := []struct {
+		name   string
+		update func()
+		nodeID topoapi.ID
+		cells  []string
+		// connected are the IDs of the connected E2 nodes
+		connected []topoapi.ID
+	}{
+		{
+			name:      "synced",
+			update:    func() {},
+			nodeID:    "e2:1",
+			cells:     []string{"1"},
+			connected: []topoapi.ID{},
+		},
+		{
+			name: "add a cell",
+			update: func() {
+				cellID = topo.AddCell("e2:1", newTestCell("2", "cgi-2"))
+			},
+			nodeID:    "e2:1",
+			cells:     []string{"1", "2"},
+			connected: []topoapi.ID{},
+		},
+		{
+			name: "connect E2 nodes",
+			update: func() {
+				topo.AddE2Node("e2:2", newTestE2Node())
+				assert.NoError(t, topo.Connect("e2:2"))
+				assert.NoError(t, topo.Connect("e2:1"))
+			},
+			nodeID:    "e2:2",
+			cells:     []string{},
+			connected: []topoapi.ID{"e2:1", "e2:2"},
+		},
+		{
+			name:      "remove a cell",
+			update:    func() { topo.RemoveCell(cellID) },
+			nodeID:    "e2:1",
+			cells:     []string{"1"},
+			connected: []topoapi.ID{"e2:1", "e2:2"},
+		},
+		{
+			name:      "remove an E2 node",
+			update:    func() { topo.RemoveE2Node("e2:1") },
+			nodeID:    "e2:1",
+			cells:     []string{},
+			connected: []topoapi.ID{"e2:2"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.update()
+			assert.Eventually(t, func() bool {
+				cells, ok := cellIDs(cache, test.nodeID)
+				if !ok || !assert.ObjectsAreEqual(test.cells, cells) {
+					return false
+				}
+				connected, ok := cache.GetControlledE2NodeIDs()
+				return ok && assert.ObjectsAreEqual(test.connected, connected)
+			}, time.Second, 10*time.Millisecond)
+		})
+	}
+	_, ok = cache.GetE2Node("e2:1")
+	assert.False(t, ok)
+	_, ok = cache.GetE2Node("e2:2")
+	assert.True(t, ok)
+
+	// the cache is no longer synced once its watch is closed
+	cancel()
+	assert.Eventually(t, func() bool {
+		_, ok := cache.GetCells("e2:2")
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestCachedClient(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	topo := fake.NewTopo()
+	topo.AddE2Node("e2:1", newTestE2Node())
+	topo.AddE2Node("e2:2", &topoapi.E2Node{})
+	topo.AddCell("e2:1", newTestCell("1", "cgi-1"))
+	assert.NoError(t, topo.Connect("e2:1"))
+	assert.NoError(t, topo.Connect("e2:2"))
+
+	client, err := rnib.NewCachedClient(ctx, rnib.WithTopoClient(topo.Client()))
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		nodeIDs, err := client.E2NodeIDs(ctx, kpmOID)
+		return err == nil && assert.ObjectsAreEqual([]topoapi.ID{"e2:1"}, nodeIDs)
+	}, time.Second, 10*time.Millisecond)
+
+	assert.True(t, client.HasKPMRanFunction(ctx, "e2:1", kpmOID))
+	assert.False(t, client.HasKPMRanFunction(ctx, "e2:2", kpmOID))
+	cellID, err := client.GetCellTopoID(ctx, "1", "e2:1")
+	assert.NoError(t, err)
+	assert.Equal(t, topoapi.ID("e2:1/cgi-1"), cellID)
+	_, err = client.GetCell(ctx, "2", "e2:1")
+	assert.True(t, errors.IsNotFound(err))
+	_, err = client.GetCells(ctx, "e2:2")
+	assert.True(t, errors.IsNotFound(err))
+}
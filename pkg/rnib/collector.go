@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rnib
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	updatesDesc = prometheus.NewDesc("kpimon_topo_writer_updates_total",
+		"Number of cell and E2 node aspect updates requested", nil, nil)
+	coalescedDesc = prometheus.NewDesc("kpimon_topo_writer_coalesced_updates_total",
+		"Number of aspect updates replaced by a newer update of the same object before being written", nil, nil)
+	writesDesc = prometheus.NewDesc("kpimon_topo_writer_writes_total",
+		"Number of successful aspect writes", nil, nil)
+	failuresDesc = prometheus.NewDesc("kpimon_topo_writer_failures_total",
+		"Number of aspect writes given up on", nil, nil)
+	conflictsDesc = prometheus.NewDesc("kpimon_topo_writer_conflicts_total",
+		"Number of aspect writes failing with a revision conflict", nil, nil)
+	pendingDesc = prometheus.NewDesc("kpimon_topo_writer_pending_updates",
+		"Number of objects waiting for their aspects to be written", nil, nil)
+	latencyDesc = prometheus.NewDesc("kpimon_topo_writer_write_latency_seconds_total",
+		"Total duration of the successful aspect writes, retries included", nil, nil)
+	maxLatencyDesc = prometheus.NewDesc("kpimon_topo_writer_max_write_latency_seconds",
+		"Maximum duration of a successful aspect write, retries included", nil, nil)
+)
+
+// Describe describes the writer metrics
+func (w *Writer) Describe(ch chan<- *prometheus.Desc) {
+	ch <- updatesDesc
+	ch <- coalescedDesc
+	ch <- writesDesc
+	ch <- failuresDesc
+	ch <- conflictsDesc
+	ch <- pendingDesc
+	ch <- latencyDesc
+	ch <- maxLatencyDesc
+}
+
+// Collect collects the counters of the writer
+func (w *Writer) Collect(ch chan<- prometheus.Metric) {
+	stats := w.Stats()
+	ch <- prometheus.MustNewConstMetric(updatesDesc, prometheus.CounterValue, float64(stats.Updates))
+	ch <- prometheus.MustNewConstMetric(coalescedDesc, prometheus.CounterValue, float64(stats.Coalesced))
+	ch <- prometheus.MustNewConstMetric(writesDesc, prometheus.CounterValue, float64(stats.Writes))
+	ch <- prometheus.MustNewConstMetric(failuresDesc, prometheus.CounterValue, float64(stats.Failures))
+	ch <- prometheus.MustNewConstMetric(conflictsDesc, prometheus.CounterValue, float64(stats.Conflicts))
+	ch <- prometheus.MustNewConstMetric(pendingDesc, prometheus.GaugeValue, float64(stats.Pending))
+	ch <- prometheus.MustNewConstMetric(latencyDesc, prometheus.CounterValue, stats.TotalLatency.Seconds())
+	ch <- prometheus.MustNewConstMetric(maxLatencyDesc, prometheus.GaugeValue, stats.MaxLatency.Seconds())
+}
+
+var _ prometheus.Collector = &Writer{}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/google/uuid"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-kpimon/pkg/rnib"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	toposdk "github.com/onosproject/onos-ric-sdk-go/pkg/topo"
+)
+
+// topoClient is a topo SDK client of the in-memory R-NIB
+type topoClient struct {
+	topo *Topo
+}
+
+// Create is not supported: the E2 nodes and cells are added with the Topo methods
+func (c *topoClient) Create(_ context.Context, object *topoapi.Object) error {
+	return errors.NewNotSupported("cannot create object %s", object.GetID())
+}
+
+// Update updates the aspects of an E2 node or a cell
+func (c *topoClient) Update(_ context.Context, object *topoapi.Object) error {
+	t := c.topo
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch object.GetEntity().GetKindID() {
+	case topoapi.E2NODE:
+		if _, ok := t.e2Nodes[object.ID]; !ok {
+			return errors.NewNotFound("E2 node %s not found", object.ID)
+		}
+		e2Node := &topoapi.E2Node{}
+		if err := object.GetAspect(e2Node); err == nil {
+			t.e2Nodes[object.ID] = e2Node
+		}
+		if value, err := object.GetAspectBytes(rnib.E2NodeKPIsAspectType); err == nil {
+			e2NodeKPIs, err := rnib.UnmarshalE2NodeKPIs(value)
+			if err != nil {
+				return errors.NewInvalid("invalid E2NodeKPIs aspect: %v", err)
+			}
+			t.nodeKPIs[object.ID] = e2NodeKPIs
+		}
+		t.send(topoapi.EventType_UPDATED, t.e2NodeObject(object.ID))
+	case topoapi.E2CELL:
+		current, ok := t.cells[object.ID]
+		if !ok {
+			return errors.NewNotFound("cell %s not found", object.ID)
+		}
+		e2Cell := &topoapi.E2Cell{}
+		if err := object.GetAspect(e2Cell); err == nil {
+			current.cell = e2Cell
+		}
+		if value, err := object.GetAspectBytes(rnib.CellKPIsAspectType); err == nil {
+			cellKPIs, err := rnib.UnmarshalCellKPIs(value)
+			if err != nil {
+				return errors.NewInvalid("invalid CellKPIs aspect: %v", err)
+			}
+			current.cellKPIs = cellKPIs
+		}
+		t.send(topoapi.EventType_UPDATED, t.cellObject(object.ID))
+	default:
+		return errors.NewNotSupported("cannot update object %s", object.ID)
+	}
+	return nil
+}
+
+// Get gets an E2 node, a cell or one of their relations
+func (c *topoClient) Get(_ context.Context, id topoapi.ID) (*topoapi.Object, error) {
+	t := c.topo
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, object := range t.topoObjects() {
+		if object.ID == id {
+			return proto.Clone(&object).(*topoapi.Object), nil
+		}
+	}
+	return nil, errors.NewNotFound("object %s not found", id)
+}
+
+// Watch watches the object changes; the current objects are replayed first as NONE events
+func (c *topoClient) Watch(ctx context.Context, ch chan<- topoapi.Event, _ ...toposdk.WatchOption) error {
+	t := c.topo
+	id := uuid.New()
+	t.mu.RLock()
+	objects := t.topoObjects()
+	replay := make([]topoapi.Event, 0, len(objects))
+	for _, object := range objects {
+		replay = append(replay, topoapi.Event{
+			Type:   topoapi.EventType_NONE,
+			Object: object,
+		})
+	}
+	err := t.objects.AddWatcher(id, ch, replay...)
+	t.mu.RUnlock()
+	if err != nil {
+		close(ch)
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		_ = t.objects.RemoveWatcher(id)
+	}()
+	return nil
+}
+
+// List lists all of the objects
+func (c *topoClient) List(_ context.Context, _ ...toposdk.ListOption) ([]topoapi.Object, error) {
+	t := c.topo
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.topoObjects(), nil
+}
+
+var _ toposdk.Client = &topoClient{}
@@ -0,0 +1,441 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fake provides an in-memory R-NIB for tests, whose E2 nodes, cells and E2 connections are scripted
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/google/uuid"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	aspectsapi "github.com/onosproject/onos-kpimon/api/aspects/v1"
+	"github.com/onosproject/onos-kpimon/pkg/rnib"
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-kpimon/pkg/store/watcher"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	toposdk "github.com/onosproject/onos-ric-sdk-go/pkg/topo"
+	"google.golang.org/protobuf/encoding/protojson"
+	gproto "google.golang.org/protobuf/proto"
+)
+
+// E2TID is the ID of the E2T controlling the connected E2 nodes
+const E2TID topoapi.ID = "e2t"
+
+// NewTopo creates a new empty in-memory R-NIB
+func NewTopo() *Topo {
+	return &Topo{
+		e2Nodes:   make(map[topoapi.ID]*topoapi.E2Node),
+		cells:     make(map[topoapi.ID]*cell),
+		connected: make(map[topoapi.ID]bool),
+		nodeKPIs:  make(map[topoapi.ID]*aspectsapi.E2NodeKPIs),
+		watchers:  watcher.NewWatchers[topoapi.Event](),
+		objects:   watcher.NewWatchers[topoapi.Event](),
+	}
+}
+
+// Topo is an in-memory R-NIB implementing the rnib.Client interface
+// The E2 nodes and cells are also exposed as topo objects through the topo SDK client returned by Client,
+// so that the components built on the topo SDK, such as rnib.Cache, can be driven by the same R-NIB.
+type Topo struct {
+	mu        sync.RWMutex
+	e2Nodes   map[topoapi.ID]*topoapi.E2Node
+	cells     map[topoapi.ID]*cell
+	connected map[topoapi.ID]bool
+	nodeKPIs  map[topoapi.ID]*aspectsapi.E2NodeKPIs
+	// watchers watch the E2 connections
+	watchers *watcher.Watchers[topoapi.Event]
+	// objects watch all of the topo object changes
+	objects *watcher.Watchers[topoapi.Event]
+}
+
+type cell struct {
+	nodeID   topoapi.ID
+	cell     *topoapi.E2Cell
+	cellKPIs *aspectsapi.CellKPIs
+}
+
+// AddE2Node adds or replaces an E2 node with its E2Node aspect
+func (t *Topo) AddE2Node(nodeID topoapi.ID, e2Node *topoapi.E2Node) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	eventType := topoapi.EventType_ADDED
+	if _, ok := t.e2Nodes[nodeID]; ok {
+		eventType = topoapi.EventType_UPDATED
+	}
+	t.e2Nodes[nodeID] = proto.Clone(e2Node).(*topoapi.E2Node)
+	t.send(eventType, t.e2NodeObject(nodeID))
+}
+
+// RemoveE2Node removes an E2 node, its cells and its E2 connection
+func (t *Topo) RemoveE2Node(nodeID topoapi.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.disconnect(nodeID)
+	for _, cellID := range sortedIDs(t.cells) {
+		if t.cells[cellID].nodeID == nodeID {
+			t.removeCell(cellID)
+		}
+	}
+	if _, ok := t.e2Nodes[nodeID]; !ok {
+		return
+	}
+	object := t.e2NodeObject(nodeID)
+	delete(t.e2Nodes, nodeID)
+	delete(t.nodeKPIs, nodeID)
+	t.send(topoapi.EventType_REMOVED, object)
+}
+
+// AddCell adds or replaces a cell of an E2 node and returns its topo ID
+func (t *Topo) AddCell(nodeID topoapi.ID, e2Cell *topoapi.E2Cell) topoapi.ID {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	c := proto.Clone(e2Cell).(*topoapi.E2Cell)
+	cellID := rnib.NewCellTopoID(nodeID, c)
+	if current, ok := t.cells[cellID]; ok {
+		current.cell = c
+		t.send(topoapi.EventType_UPDATED, t.cellObject(cellID))
+		return cellID
+	}
+	t.cells[cellID] = &cell{
+		nodeID: nodeID,
+		cell:   c,
+	}
+	t.send(topoapi.EventType_ADDED, t.cellObject(cellID))
+	t.send(topoapi.EventType_ADDED, newContainsObject(nodeID, cellID))
+	return cellID
+}
+
+// RemoveCell removes a cell of an E2 node
+func (t *Topo) RemoveCell(cellID topoapi.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.removeCell(cellID)
+}
+
+// removeCell must be called with the lock held
+func (t *Topo) removeCell(cellID topoapi.ID) {
+	c, ok := t.cells[cellID]
+	if !ok {
+		return
+	}
+	object := t.cellObject(cellID)
+	delete(t.cells, cellID)
+	t.send(topoapi.EventType_REMOVED, newContainsObject(c.nodeID, cellID))
+	t.send(topoapi.EventType_REMOVED, object)
+}
+
+// Connect connects an E2 node, which notifies the E2 connection watchers
+func (t *Topo) Connect(nodeID topoapi.ID) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.e2Nodes[nodeID]; !ok {
+		return errors.NewNotFound("E2 node %s not found", nodeID)
+	}
+	if t.connected[nodeID] {
+		return nil
+	}
+	t.connected[nodeID] = true
+	t.send(topoapi.EventType_ADDED, newConnectionObject(nodeID))
+	return nil
+}
+
+// Disconnect disconnects an E2 node, which notifies the E2 connection watchers
+func (t *Topo) Disconnect(nodeID topoapi.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.disconnect(nodeID)
+}
+
+// disconnect must be called with the lock held
+func (t *Topo) disconnect(nodeID topoapi.ID) {
+	if !t.connected[nodeID] {
+		return
+	}
+	delete(t.connected, nodeID)
+	t.send(topoapi.EventType_REMOVED, newConnectionObject(nodeID))
+}
+
+// send must be called with the lock held
+func (t *Topo) send(eventType topoapi.EventType, object *topoapi.Object) {
+	event := topoapi.Event{
+		Type:   eventType,
+		Object: *object,
+	}
+	t.objects.Send(event)
+	if object.GetRelation().GetKindID() == topoapi.CONTROLS {
+		t.watchers.Send(event)
+	}
+}
+
+// GetKpiReports gets the KPI reports last written to a cell
+func (t *Topo) GetKpiReports(cellID topoapi.ID) (map[string]uint32, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	c, ok := t.cells[cellID]
+	if !ok {
+		return nil, errors.NewNotFound("cell %s not found", cellID)
+	}
+	reports := make(map[string]uint32, len(c.cell.KpiReports))
+	for name, value := range c.cell.KpiReports {
+		reports[name] = value
+	}
+	return reports, nil
+}
+
+// GetCellKPIs gets the CellKPIs aspect last written to a cell
+func (t *Topo) GetCellKPIs(cellID topoapi.ID) (*aspectsapi.CellKPIs, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	c, ok := t.cells[cellID]
+	if !ok {
+		return nil, errors.NewNotFound("cell %s not found", cellID)
+	}
+	if c.cellKPIs == nil {
+		return nil, errors.NewNotFound("cell %s has no CellKPIs aspect", cellID)
+	}
+	return gproto.Clone(c.cellKPIs).(*aspectsapi.CellKPIs), nil
+}
+
+// GetE2NodeKPIs gets the E2NodeKPIs aspect last written to an E2 node
+func (t *Topo) GetE2NodeKPIs(nodeID topoapi.ID) (*aspectsapi.E2NodeKPIs, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	e2NodeKPIs, ok := t.nodeKPIs[nodeID]
+	if !ok {
+		return nil, errors.NewNotFound("E2 node %s has no E2NodeKPIs aspect", nodeID)
+	}
+	return gproto.Clone(e2NodeKPIs).(*aspectsapi.E2NodeKPIs), nil
+}
+
+// WatchE2Connections watches the E2 connections; the current connections are replayed first as NONE events
+func (t *Topo) WatchE2Connections(ctx context.Context, ch chan topoapi.Event) error {
+	id := uuid.New()
+	t.mu.RLock()
+	replay := make([]topoapi.Event, 0, len(t.connected))
+	for _, nodeID := range sortedIDs(t.connected) {
+		replay = append(replay, topoapi.Event{
+			Type:   topoapi.EventType_NONE,
+			Object: *newConnectionObject(nodeID),
+		})
+	}
+	err := t.watchers.AddWatcher(id, ch, replay...)
+	t.mu.RUnlock()
+	if err != nil {
+		close(ch)
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		_ = t.watchers.RemoveWatcher(id)
+	}()
+	return nil
+}
+
+// GetCells gets the cells of an E2 node
+func (t *Topo) GetCells(_ context.Context, nodeID topoapi.ID) ([]*topoapi.E2Cell, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	cellIDs := make(map[topoapi.ID]bool)
+	for cellID, c := range t.cells {
+		if c.nodeID == nodeID {
+			cellIDs[cellID] = true
+		}
+	}
+	if len(cellIDs) == 0 {
+		return nil, errors.New(errors.NotFound, "there is no cell to subscribe for e2 node %s", nodeID)
+	}
+	cells := make([]*topoapi.E2Cell, 0, len(cellIDs))
+	for _, cellID := range sortedIDs(cellIDs) {
+		cells = append(cells, proto.Clone(t.cells[cellID].cell).(*topoapi.E2Cell))
+	}
+	return cells, nil
+}
+
+// GetCell gets the E2 cell of an E2 node with cell object ID
+func (t *Topo) GetCell(ctx context.Context, coi string, nodeID topoapi.ID) (*topoapi.E2Cell, error) {
+	cells, err := t.GetCells(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cells {
+		if c.CellObjectID == coi {
+			return c, nil
+		}
+	}
+	return nil, errors.NewNotFound("E2Cell not found with CellObjectID")
+}
+
+// GetCellTopoID gets cell topo ID with cell object ID
+func (t *Topo) GetCellTopoID(ctx context.Context, coi string, nodeID topoapi.ID) (topoapi.ID, error) {
+	c, err := t.GetCell(ctx, coi, nodeID)
+	if err != nil {
+		return "", err
+	}
+	return rnib.NewCellTopoID(nodeID, c), nil
+}
+
+// GetE2NodeAspects gets E2 node aspects
+func (t *Topo) GetE2NodeAspects(_ context.Context, nodeID topoapi.ID) (*topoapi.E2Node, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	e2Node, ok := t.e2Nodes[nodeID]
+	if !ok {
+		return nil, errors.NewNotFound("E2 node %s not found", nodeID)
+	}
+	return proto.Clone(e2Node).(*topoapi.E2Node), nil
+}
+
+// HasKPMRanFunction returns true if an E2 node supports a service model
+func (t *Topo) HasKPMRanFunction(ctx context.Context, nodeID topoapi.ID, oid string) bool {
+	e2Node, err := t.GetE2NodeAspects(ctx, nodeID)
+	if err != nil {
+		return false
+	}
+	for _, sm := range e2Node.GetServiceModels() {
+		if sm.OID == oid {
+			return true
+		}
+	}
+	return false
+}
+
+// E2NodeIDs lists the connected E2 nodes supporting a service model
+func (t *Topo) E2NodeIDs(ctx context.Context, oid string) ([]topoapi.ID, error) {
+	t.mu.RLock()
+	nodeIDs := sortedIDs(t.connected)
+	t.mu.RUnlock()
+
+	e2NodeIDs := make([]topoapi.ID, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		if t.HasKPMRanFunction(ctx, nodeID, oid) {
+			e2NodeIDs = append(e2NodeIDs, nodeID)
+		}
+	}
+	return e2NodeIDs, nil
+}
+
+// UpdateCellAspects writes the latest measurement records of a cell as its KPI reports and its CellKPIs aspect
+func (t *Topo) UpdateCellAspects(_ context.Context, cellID topoapi.ID, measItems []measurements.MeasurementItem, granularityPeriod uint64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	c, ok := t.cells[cellID]
+	if !ok {
+		return errors.NewNotFound("cell %s not found", cellID)
+	}
+	c.cell.KpiReports = rnib.NewKpiReports(measItems)
+	c.cellKPIs = rnib.NewCellKPIs(measItems, granularityPeriod)
+	t.send(topoapi.EventType_UPDATED, t.cellObject(cellID))
+	return nil
+}
+
+// UpdateE2NodeAspects writes the aggregates of the KPIs of the cells of an E2 node as its E2NodeKPIs aspect
+func (t *Topo) UpdateE2NodeAspects(_ context.Context, nodeID topoapi.ID, e2NodeKPIs *aspectsapi.E2NodeKPIs) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.e2Nodes[nodeID]; !ok {
+		return errors.NewNotFound("E2 node %s not found", nodeID)
+	}
+	t.nodeKPIs[nodeID] = gproto.Clone(e2NodeKPIs).(*aspectsapi.E2NodeKPIs)
+	t.send(topoapi.EventType_UPDATED, t.e2NodeObject(nodeID))
+	return nil
+}
+
+// Client gets a topo SDK client of the R-NIB
+// The list and watch filters cannot be read outside of the topo SDK, so they are ignored and all of
+// the objects are listed and watched; the client suits the consumers of the whole topology such as rnib.Cache.
+func (t *Topo) Client() toposdk.Client {
+	return &topoClient{
+		topo: t,
+	}
+}
+
+// topoObjects must be called with the lock held
+func (t *Topo) topoObjects() []topoapi.Object {
+	objects := make([]topoapi.Object, 0, len(t.e2Nodes)+2*len(t.cells)+len(t.connected))
+	for _, nodeID := range sortedIDs(t.e2Nodes) {
+		objects = append(objects, *t.e2NodeObject(nodeID))
+	}
+	for _, cellID := range sortedIDs(t.cells) {
+		objects = append(objects, *t.cellObject(cellID), *newContainsObject(t.cells[cellID].nodeID, cellID))
+	}
+	for _, nodeID := range sortedIDs(t.connected) {
+		objects = append(objects, *newConnectionObject(nodeID))
+	}
+	return objects
+}
+
+// e2NodeObject must be called with the lock held
+func (t *Topo) e2NodeObject(nodeID topoapi.ID) *topoapi.Object {
+	object := newEntityObject(nodeID, topoapi.E2NODE)
+	_ = object.SetAspect(t.e2Nodes[nodeID])
+	if e2NodeKPIs, ok := t.nodeKPIs[nodeID]; ok {
+		value, _ := protojson.Marshal(e2NodeKPIs)
+		object.SetAspectBytes(rnib.E2NodeKPIsAspectType, value)
+	}
+	return object
+}
+
+// cellObject must be called with the lock held
+func (t *Topo) cellObject(cellID topoapi.ID) *topoapi.Object {
+	c := t.cells[cellID]
+	object := newEntityObject(cellID, topoapi.E2CELL)
+	_ = object.SetAspect(c.cell)
+	if c.cellKPIs != nil {
+		value, _ := protojson.Marshal(c.cellKPIs)
+		object.SetAspectBytes(rnib.CellKPIsAspectType, value)
+	}
+	return object
+}
+
+func newEntityObject(id topoapi.ID, kind topoapi.ID) *topoapi.Object {
+	return &topoapi.Object{
+		ID:   id,
+		Type: topoapi.Object_ENTITY,
+		Obj: &topoapi.Object_Entity{
+			Entity: &topoapi.Entity{
+				KindID: kind,
+			},
+		},
+	}
+}
+
+func newRelationObject(id topoapi.ID, kind topoapi.ID, src topoapi.ID, tgt topoapi.ID) *topoapi.Object {
+	return &topoapi.Object{
+		ID:   id,
+		Type: topoapi.Object_RELATION,
+		Obj: &topoapi.Object_Relation{
+			Relation: &topoapi.Relation{
+				KindID:      kind,
+				SrcEntityID: src,
+				TgtEntityID: tgt,
+			},
+		},
+	}
+}
+
+func newConnectionObject(nodeID topoapi.ID) *topoapi.Object {
+	return newRelationObject(topoapi.ID(fmt.Sprintf("uuid:%s-%s", E2TID, nodeID)), topoapi.CONTROLS, E2TID, nodeID)
+}
+
+func newContainsObject(nodeID topoapi.ID, cellID topoapi.ID) *topoapi.Object {
+	return newRelationObject(topoapi.ID(fmt.Sprintf("uuid:%s-%s", nodeID, cellID)), topoapi.CONTAINS, nodeID, cellID)
+}
+
+func sortedIDs[V any](m map[topoapi.ID]V) []topoapi.ID {
+	ids := make([]topoapi.ID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+var _ rnib.Client = &Topo{}
@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	aspectsapi "github.com/onosproject/onos-kpimon/api/aspects/v1"
+	"github.com/onosproject/onos-kpimon/pkg/rnib"
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCell(coi string, cgi string) *topoapi.E2Cell {
+	return &topoapi.E2Cell{
+		CellObjectID: coi,
+		CellGlobalID: &topoapi.CellGlobalID{Value: cgi},
+	}
+}
+
+// change is the type, kind and ID of the object of a topo event
+type change struct {
+	eventType topoapi.EventType
+	kind      topoapi.ID
+	id        topoapi.ID
+}
+
+func nextChanges(t *testing.T, ch <-chan topoapi.Event, n int) []change {
+	changes := make([]change, 0, n)
+	for i := 0; i < n; i++ {
+		select {
+		case e := <-ch:
+			kind := e.Object.GetEntity().GetKindID()
+			if e.Object.Type == topoapi.Object_RELATION {
+				kind = e.Object.GetRelation().GetKindID()
+			}
+			changes = append(changes, change{e.Type, kind, e.Object.ID})
+		case <-time.After(time.Second):
+			t.Fatalf("received %d of %d events", i, n)
+		}
+	}
+	return changes
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	topo := NewTopo()
+	topo.AddE2Node("e2:1", &topoapi.E2Node{})
+	cellID := topo.AddCell("e2:1", newTestCell("1", "cgi-1"))
+	containsID := topoapi.ID("uuid:e2:1-" + string(cellID))
+	controlsID := topoapi.ID("uuid:e2t-e2:2")
+
+	// the current objects are replayed first
+	ch := make(chan topoapi.Event)
+	assert.NoError(t, topo.Client().Watch(ctx, ch))
+	assert.Equal(t, []change{
+		{topoapi.EventType_NONE, topoapi.E2NODE, "e2:1"},
+		{topoapi.EventType_NONE, topoapi.E2CELL, cellID},
+		{topoapi.EventType_NONE, topoapi.CONTAINS, containsID},
+	}, nextChanges(t, ch, 3))
+
+	tests := []struct {
+		name    string
+		update  func()
+		changes []change
+	}{
+		{
+			name:    "add an E2 node",
+			update:  func() { topo.AddE2Node("e2:2", &topoapi.E2Node{}) },
+			changes: []change{{topoapi.EventType_ADDED, topoapi.E2NODE, "e2:2"}},
+		},
+		{
+			name:    "replace an E2 node",
+			update:  func() { topo.AddE2Node("e2:2", &topoapi.E2Node{}) },
+			changes: []change{{topoapi.EventType_UPDATED, topoapi.E2NODE, "e2:2"}},
+		},
+		{
+			name:    "connect an E2 node",
+			update:  func() { assert.NoError(t, topo.Connect("e2:2")) },
+			changes: []change{{topoapi.EventType_ADDED, topoapi.CONTROLS, controlsID}},
+		},
+		{
+			name:    "replace a cell",
+			update:  func() { topo.AddCell("e2:1", newTestCell("2", "cgi-1")) },
+			changes: []change{{topoapi.EventType_UPDATED, topoapi.E2CELL, cellID}},
+		},
+		{
+			name:   "remove a cell",
+			update: func() { topo.RemoveCell(cellID) },
+			changes: []change{
+				{topoapi.EventType_REMOVED, topoapi.CONTAINS, containsID},
+				{topoapi.EventType_REMOVED, topoapi.E2CELL, cellID},
+			},
+		},
+		{
+			name: "remove an E2 node with its cell and connection",
+			update: func() {
+				topo.AddCell("e2:2", newTestCell("1", "cgi-2"))
+				topo.RemoveE2Node("e2:2")
+			},
+			changes: []change{
+				{topoapi.EventType_ADDED, topoapi.E2CELL, "e2:2/cgi-2"},
+				{topoapi.EventType_ADDED, topoapi.CONTAINS, "uuid:e2:2-e2:2/cgi-2"},
+				{topoapi.EventType_REMOVED, topoapi.CONTROLS, controlsID},
+				{topoapi.EventType_REMOVED, topoapi.CONTAINS, "uuid:e2:2-e2:2/cgi-2"},
+				{topoapi.EventType_REMOVED, topoapi.E2CELL, "e2:2/cgi-2"},
+				{topoapi.EventType_REMOVED, topoapi.E2NODE, "e2:2"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.update()
+			assert.Equal(t, test.changes, nextChanges(t, ch, len(test.changes)))
+		})
+	}
+}
+
+func TestWatchE2Connections(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	topo := NewTopo()
+	topo.AddE2Node("e2:1", &topoapi.E2Node{})
+	topo.AddE2Node("e2:2", &topoapi.E2Node{})
+	assert.NoError(t, topo.Connect("e2:1"))
+	assert.True(t, errors.IsNotFound(topo.Connect("e2:3")))
+
+	ch := make(chan topoapi.Event)
+	assert.NoError(t, topo.WatchE2Connections(ctx, ch))
+	// only the E2 connections are watched
+	topo.AddCell("e2:2", newTestCell("1", "cgi-2"))
+	assert.NoError(t, topo.Connect("e2:2"))
+	topo.Disconnect("e2:1")
+	assert.Equal(t, []change{
+		{topoapi.EventType_NONE, topoapi.CONTROLS, "uuid:e2t-e2:1"},
+		{topoapi.EventType_ADDED, topoapi.CONTROLS, "uuid:e2t-e2:2"},
+		{topoapi.EventType_REMOVED, topoapi.CONTROLS, "uuid:e2t-e2:1"},
+	}, nextChanges(t, ch, 3))
+}
+
+func TestClient(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	topo := NewTopo()
+	topo.AddE2Node("e2:1", &topoapi.E2Node{})
+	cellID := topo.AddCell("e2:1", newTestCell("1", "cgi-1"))
+	assert.NoError(t, topo.Connect("e2:1"))
+
+	objects, err := topo.Client().List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, objects, 4)
+	_, err = topo.Client().Get(ctx, "e2:2")
+	assert.True(t, errors.IsNotFound(err))
+	assert.True(t, errors.IsNotSupported(topo.Client().Create(ctx, &topoapi.Object{ID: "e2:2"})))
+
+	// the list filters are ignored, so the lookups of the R-NIB client are served from its cache
+	rnibClient, err := rnib.NewCachedClient(ctx, rnib.WithTopoClient(topo.Client()))
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		cell, err := rnibClient.GetCell(ctx, "1", "e2:1")
+		return err == nil && cell.GetCellGlobalID().GetValue() == "cgi-1"
+	}, time.Second, 10*time.Millisecond)
+
+	measItems := []measurements.MeasurementItem{{
+		MeasurementRecords: []measurements.MeasurementRecord{{
+			Timestamp:        1,
+			MeasurementName:  "A",
+			MeasurementValue: int64(3),
+		}},
+	}}
+	assert.NoError(t, rnibClient.UpdateCellAspects(ctx, cellID, measItems, 1000))
+	reports, err := topo.GetKpiReports(cellID)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]uint32{"A": 3}, reports)
+	cellKPIs, err := topo.GetCellKPIs(cellID)
+	assert.NoError(t, err)
+	assert.Equal(t, "A", cellKPIs.GetKpis()[0].GetName())
+
+	e2NodeKPIs := &aspectsapi.E2NodeKPIs{Aggregates: []*aspectsapi.AggregateKPI{{Name: "A", Function: "sum", Value: 3, Cells: 1}}}
+	assert.NoError(t, rnibClient.UpdateE2NodeAspects(ctx, "e2:1", e2NodeKPIs))
+	written, err := topo.GetE2NodeKPIs("e2:1")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(3), written.GetAggregates()[0].GetValue())
+}
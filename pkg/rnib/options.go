@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rnib
+
+import (
+	"time"
+
+	toposdk "github.com/onosproject/onos-ric-sdk-go/pkg/topo"
+)
+
+// Options R-NIB client options
+type Options struct {
+	// TopoClient is the topo client used instead of a topo SDK client, e.g. an in-memory fake topo
+	TopoClient toposdk.Client
+	// WriteRate is the maximum number of aspect writes per second of a writer
+	WriteRate float64
+	// MaxWriteRetries is the number of times a writer retries an aspect write on a revision conflict
+	MaxWriteRetries int
+	// WriteRetryBackoff is the delay before the first retry of a conflicting aspect write, doubled on each retry
+	WriteRetryBackoff time.Duration
+}
+
+// Option R-NIB client option interface
+type Option interface {
+	apply(*Options)
+}
+
+type funcOption struct {
+	f func(*Options)
+}
+
+func (f funcOption) apply(options *Options) {
+	f.f(options)
+}
+
+func newOption(f func(*Options)) Option {
+	return funcOption{
+		f: f,
+	}
+}
+
+// WithTopoClient sets the topo client
+func WithTopoClient(topoClient toposdk.Client) Option {
+	return newOption(func(options *Options) {
+		options.TopoClient = topoClient
+	})
+}
+
+// WithWriteRate sets the maximum number of aspect writes per second of a writer
+func WithWriteRate(writeRate float64) Option {
+	return newOption(func(options *Options) {
+		options.WriteRate = writeRate
+	})
+}
+
+// WithMaxWriteRetries sets the number of times a writer retries an aspect write on a revision conflict
+func WithMaxWriteRetries(maxWriteRetries int) Option {
+	return newOption(func(options *Options) {
+		options.MaxWriteRetries = maxWriteRetries
+	})
+}
+
+// WithWriteRetryBackoff sets the delay before the first retry of a conflicting aspect write
+func WithWriteRetryBackoff(backoff time.Duration) Option {
+	return newOption(func(options *Options) {
+		options.WriteRetryBackoff = backoff
+	})
+}
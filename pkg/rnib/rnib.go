@@ -8,12 +8,14 @@ package rnib
 import (
 	"context"
 	"fmt"
+	"time"
 
 	measurmentStore "github.com/onosproject/onos-kpimon/pkg/store/measurements"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 
 	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	aspectsapi "github.com/onosproject/onos-kpimon/api/aspects/v1"
 	toposdk "github.com/onosproject/onos-ric-sdk-go/pkg/topo"
 )
 
@@ -27,24 +29,86 @@ type TopoClient interface {
 	E2NodeIDs(ctx context.Context, oid string) ([]topoapi.ID, error)
 }
 
-// NewClient creates a new topo SDK client
-func NewClient() (Client, error) {
-	sdkClient, err := toposdk.NewClient()
-	if err != nil {
-		return Client{}, err
+// Client is the R-NIB client used by kpimon
+type Client interface {
+	TopoClient
+
+	// HasKPMRanFunction returns true if an E2 node supports a service model
+	HasKPMRanFunction(ctx context.Context, nodeID topoapi.ID, oid string) bool
+
+	// GetCell gets the E2 cell of an E2 node with cell object ID
+	GetCell(ctx context.Context, coi string, nodeID topoapi.ID) (*topoapi.E2Cell, error)
+
+	// GetCellTopoID gets cell topo ID with cell object ID
+	GetCellTopoID(ctx context.Context, coi string, nodeID topoapi.ID) (topoapi.ID, error)
+
+	// UpdateCellAspects writes the latest measurement records of a cell to its E2Cell KPI reports and to its CellKPIs aspect
+	UpdateCellAspects(ctx context.Context, cellID topoapi.ID, measItems []measurmentStore.MeasurementItem, granularityPeriod uint64) error
+
+	// UpdateE2NodeAspects writes the aggregates of the KPIs of the cells of an E2 node to its E2NodeKPIs aspect
+	UpdateE2NodeAspects(ctx context.Context, nodeID topoapi.ID, e2NodeKPIs *aspectsapi.E2NodeKPIs) error
+}
+
+// NewClient creates a new R-NIB client
+// Unless a topo client is given with the WithTopoClient option, a topo SDK client is created.
+func NewClient(opts ...Option) (Client, error) {
+	options := Options{}
+	for _, opt := range opts {
+		opt.apply(&options)
 	}
-	cl := Client{
-		client: sdkClient,
+
+	topoClient := options.TopoClient
+	if topoClient == nil {
+		sdkClient, err := toposdk.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		topoClient = sdkClient
+	}
+	cl := &client{
+		client: topoClient,
 	}
 	return cl, nil
 }
 
-// Client topo SDK client
-type Client struct {
+// NewCachedClient creates a new R-NIB client whose lookups are served from a topo cache
+// The cache is kept up to date until the context is done; the client is meant to be shared by all of the components.
+func NewCachedClient(ctx context.Context, opts ...Option) (Client, error) {
+	rnibClient, err := NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	cl := rnibClient.(*client)
+	cl.cache = NewCache(cl.client)
+	// the lookups fall back to topo until the cache is synced
+	go cl.startCache(ctx)
+	return cl, nil
+}
+
+// client topo SDK client
+type client struct {
 	client toposdk.Client
+	cache  *Cache
+}
+
+// startCache starts the cache once topo is reachable
+func (c *client) startCache(ctx context.Context) {
+	for {
+		err := c.cache.Start(ctx)
+		if err == nil {
+			return
+		}
+		log.Warnf("Failed to sync the topo cache: %v", err)
+		select {
+		case <-time.After(cacheRetryInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
 }
 
-func (c *Client) HasKPMRanFunction(ctx context.Context, nodeID topoapi.ID, oid string) bool {
+// HasKPMRanFunction returns true if an E2 node supports a service model
+func (c *client) HasKPMRanFunction(ctx context.Context, nodeID topoapi.ID, oid string) bool {
 	e2Node, err := c.GetE2NodeAspects(ctx, nodeID)
 	if err != nil {
 		log.Warn(err)
@@ -59,8 +123,8 @@ func (c *Client) HasKPMRanFunction(ctx context.Context, nodeID topoapi.ID, oid s
 	return false
 }
 
-// UpdateCellAspects updates cell aspects
-func (c *Client) UpdateCellAspects(ctx context.Context, cellID topoapi.ID, measItems []measurmentStore.MeasurementItem) error {
+// UpdateCellAspects writes the latest measurement records of a cell to its E2Cell KPI reports and to its CellKPIs aspect
+func (c *client) UpdateCellAspects(ctx context.Context, cellID topoapi.ID, measItems []measurmentStore.MeasurementItem, granularityPeriod uint64) error {
 	object, err := c.client.Get(ctx, cellID)
 	if err != nil {
 		return err
@@ -72,31 +136,38 @@ func (c *Client) UpdateCellAspects(ctx context.Context, cellID topoapi.ID, measI
 		if err != nil {
 			return err
 		}
-		cellObject.KpiReports = make(map[string]uint32)
-
-		tmpTs := uint64(0)
-		for _, measItem := range measItems {
-			for _, record := range measItem.MeasurementRecords {
-				if tmpTs <= record.Timestamp {
-					tmpTs = record.Timestamp
-					switch record.MeasurementValue.(type) {
-					case int32:
-						cellObject.KpiReports[record.MeasurementName] = uint32(record.MeasurementValue.(int32))
+		cellObject.KpiReports = NewKpiReports(measItems)
 
-					case int64:
-						cellObject.KpiReports[record.MeasurementName] = uint32(record.MeasurementValue.(int64))
-
-					default:
-						cellObject.KpiReports[record.MeasurementName] = uint32(0)
-					}
-				}
-			}
+		err = object.SetAspect(cellObject)
+		if err != nil {
+			return err
+		}
+		cellKPIs, err := marshalCellKPIs(NewCellKPIs(measItems, granularityPeriod))
+		if err != nil {
+			return err
+		}
+		object.SetAspectBytes(CellKPIsAspectType, cellKPIs)
+		err = c.client.Update(ctx, object)
+		if err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		err = object.SetAspect(cellObject)
+// UpdateE2NodeAspects writes the aggregates of the KPIs of the cells of an E2 node to its E2NodeKPIs aspect
+func (c *client) UpdateE2NodeAspects(ctx context.Context, nodeID topoapi.ID, e2NodeKPIs *aspectsapi.E2NodeKPIs) error {
+	object, err := c.client.Get(ctx, nodeID)
+	if err != nil {
+		return err
+	}
+
+	if object != nil && object.GetEntity().GetKindID() == topoapi.E2NODE {
+		value, err := marshalE2NodeKPIs(e2NodeKPIs)
 		if err != nil {
 			return err
 		}
+		object.SetAspectBytes(E2NodeKPIsAspectType, value)
 		err = c.client.Update(ctx, object)
 		if err != nil {
 			return err
@@ -106,28 +177,54 @@ func (c *Client) UpdateCellAspects(ctx context.Context, cellID topoapi.ID, measI
 }
 
 // GetCellTopoID gets cell topo ID with cell object ID
-func (c *Client) GetCellTopoID(ctx context.Context, coi string, nodeID topoapi.ID) (topoapi.ID, error) {
-	cells, err := c.GetCells(ctx, nodeID)
+func (c *client) GetCellTopoID(ctx context.Context, coi string, nodeID topoapi.ID) (topoapi.ID, error) {
+	cell, err := c.GetCell(ctx, coi, nodeID)
 	if err != nil {
 		return "", err
 	}
+	return NewCellTopoID(nodeID, cell), nil
+}
+
+// NewCellTopoID creates the topo ID of an E2 cell
+func NewCellTopoID(nodeID topoapi.ID, cell *topoapi.E2Cell) topoapi.ID {
+	return topoapi.ID(fmt.Sprintf("%s/%s", string(nodeID), cell.GetCellGlobalID().GetValue()))
+}
+
+// GetCell gets the E2 cell of an E2 node with cell object ID
+func (c *client) GetCell(ctx context.Context, coi string, nodeID topoapi.ID) (*topoapi.E2Cell, error) {
+	cells, err := c.GetCells(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, cell := range cells {
 		if coi == cell.CellObjectID {
-			return topoapi.ID(fmt.Sprintf("%s/%s", string(nodeID), cell.CellGlobalID.Value)), nil
+			return cell, nil
 		}
 	}
-	return "", errors.NewNotFound("E2Cell not found with CellObjectID")
+	return nil, errors.NewNotFound("E2Cell not found with CellObjectID")
 }
 
 // E2NodeIDs lists all of connected E2 nodes
-func (c *Client) E2NodeIDs(ctx context.Context, oid string) ([]topoapi.ID, error) {
+func (c *client) E2NodeIDs(ctx context.Context, oid string) ([]topoapi.ID, error) {
+	if c.cache != nil {
+		if nodeIDs, ok := c.cache.GetControlledE2NodeIDs(); ok {
+			e2NodeIDs := make([]topoapi.ID, 0, len(nodeIDs))
+			for _, e2NodeID := range nodeIDs {
+				if c.HasKPMRanFunction(ctx, e2NodeID, oid) {
+					e2NodeIDs = append(e2NodeIDs, e2NodeID)
+				}
+			}
+			return e2NodeIDs, nil
+		}
+	}
+
 	objects, err := c.client.List(ctx, toposdk.WithListFilters(getControlRelationFilter()))
 	if err != nil {
 		return nil, err
 	}
 
-	e2NodeIDs := make([]topoapi.ID, len(objects))
+	e2NodeIDs := make([]topoapi.ID, 0, len(objects))
 	for _, object := range objects {
 		relation := object.Obj.(*topoapi.Object_Relation)
 		e2NodeID := relation.Relation.TgtEntityID
@@ -140,7 +237,12 @@ func (c *Client) E2NodeIDs(ctx context.Context, oid string) ([]topoapi.ID, error
 }
 
 // GetE2NodeAspects gets E2 node aspects
-func (c *Client) GetE2NodeAspects(ctx context.Context, nodeID topoapi.ID) (*topoapi.E2Node, error) {
+func (c *client) GetE2NodeAspects(ctx context.Context, nodeID topoapi.ID) (*topoapi.E2Node, error) {
+	if c.cache != nil {
+		if e2Node, ok := c.cache.GetE2Node(nodeID); ok {
+			return e2Node, nil
+		}
+	}
 	object, err := c.client.Get(ctx, nodeID)
 	if err != nil {
 		return nil, err
@@ -152,7 +254,15 @@ func (c *Client) GetE2NodeAspects(ctx context.Context, nodeID topoapi.ID) (*topo
 }
 
 // GetCells get list of cells for each E2 node
-func (c *Client) GetCells(ctx context.Context, nodeID topoapi.ID) ([]*topoapi.E2Cell, error) {
+func (c *client) GetCells(ctx context.Context, nodeID topoapi.ID) ([]*topoapi.E2Cell, error) {
+	if c.cache != nil {
+		if cells, ok := c.cache.GetCells(nodeID); ok {
+			if len(cells) == 0 {
+				return nil, errors.New(errors.NotFound, "there is no cell to subscribe for e2 node %s", nodeID)
+			}
+			return cells, nil
+		}
+	}
 	filter := &topoapi.Filters{
 		RelationFilter: &topoapi.RelationFilter{
 			SrcId:        string(nodeID),
@@ -195,7 +305,7 @@ func getControlRelationFilter() *topoapi.Filters {
 }
 
 // WatchE2Connections watch e2 node connection changes
-func (c *Client) WatchE2Connections(ctx context.Context, ch chan topoapi.Event) error {
+func (c *client) WatchE2Connections(ctx context.Context, ch chan topoapi.Event) error {
 	err := c.client.Watch(ctx, ch, toposdk.WithWatchFilters(getControlRelationFilter()))
 	if err != nil {
 		return err
@@ -203,4 +313,4 @@ func (c *Client) WatchE2Connections(ctx context.Context, ch chan topoapi.Event)
 	return nil
 }
 
-var _ TopoClient = &Client{}
+var _ Client = &client{}
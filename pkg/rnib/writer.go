@@ -0,0 +1,292 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rnib
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	aspectsapi "github.com/onosproject/onos-kpimon/api/aspects/v1"
+	measurmentStore "github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+const (
+	defaultWriteRate       = 10
+	defaultMaxWriteRetries = 3
+	defaultRetryBackoff    = 100 * time.Millisecond
+	writerStatsInterval    = time.Minute
+)
+
+// WriterStats are the counters of a writer
+type WriterStats struct {
+	// Updates is the number of cell and E2 node aspect updates requested
+	Updates uint64
+	// Coalesced is the number of updates replaced by a newer update of the same object before being written
+	Coalesced uint64
+	// Writes is the number of successful writes
+	Writes uint64
+	// Failures is the number of writes given up on
+	Failures uint64
+	// Conflicts is the number of revision conflicts, each followed by a retry unless the retries are exhausted
+	Conflicts uint64
+	// Pending is the number of objects waiting to be written
+	Pending int
+	// LastLatency, MaxLatency and TotalLatency are the durations of the successful writes, retries included
+	LastLatency  time.Duration
+	MaxLatency   time.Duration
+	TotalLatency time.Duration
+}
+
+// AverageLatency gets the average duration of the successful writes
+func (s WriterStats) AverageLatency() time.Duration {
+	if s.Writes == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Writes)
+}
+
+// update is the latest pending update of a topo object
+type update func(ctx context.Context) error
+
+// Writer is an R-NIB client whose cell and E2 node aspect updates are queued and written in the background
+// The pending updates of a topo object are coalesced so that only the latest one is written, the writes
+// do not exceed the write rate and the writes failing with a revision conflict are retried with a backoff,
+// the retries counting against the write rate.
+type Writer struct {
+	Client
+	interval     time.Duration
+	maxRetries   int
+	retryBackoff time.Duration
+	mu           sync.Mutex
+	pending      map[topoapi.ID]update
+	// queue holds the objects with a pending update in the order they were first updated
+	queue  []topoapi.ID
+	notify chan struct{}
+	stats  WriterStats
+}
+
+// NewWriter creates a writer of the cell and E2 node aspects of an R-NIB client; the updates are written until the context is done
+func NewWriter(ctx context.Context, client Client, opts ...Option) *Writer {
+	options := Options{
+		WriteRate:         defaultWriteRate,
+		MaxWriteRetries:   defaultMaxWriteRetries,
+		WriteRetryBackoff: defaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	if options.WriteRate <= 0 {
+		options.WriteRate = defaultWriteRate
+	}
+	if options.MaxWriteRetries < 0 {
+		options.MaxWriteRetries = 0
+	}
+	if options.WriteRetryBackoff < 0 {
+		options.WriteRetryBackoff = 0
+	}
+
+	w := &Writer{
+		Client:       client,
+		interval:     time.Duration(float64(time.Second) / options.WriteRate),
+		maxRetries:   options.MaxWriteRetries,
+		retryBackoff: options.WriteRetryBackoff,
+		pending:      make(map[topoapi.ID]update),
+		notify:       make(chan struct{}, 1),
+	}
+	go w.run(ctx)
+	go w.logStats(ctx)
+	return w
+}
+
+// UpdateCellAspects queues an update of the cell aspects, replacing the pending update of the cell if any
+func (w *Writer) UpdateCellAspects(_ context.Context, cellID topoapi.ID, measItems []measurmentStore.MeasurementItem, granularityPeriod uint64) error {
+	w.enqueue(cellID, func(ctx context.Context) error {
+		return w.Client.UpdateCellAspects(ctx, cellID, measItems, granularityPeriod)
+	})
+	return nil
+}
+
+// UpdateE2NodeAspects queues an update of the E2 node aspects, replacing the pending update of the E2 node if any
+func (w *Writer) UpdateE2NodeAspects(_ context.Context, nodeID topoapi.ID, e2NodeKPIs *aspectsapi.E2NodeKPIs) error {
+	w.enqueue(nodeID, func(ctx context.Context) error {
+		return w.Client.UpdateE2NodeAspects(ctx, nodeID, e2NodeKPIs)
+	})
+	return nil
+}
+
+func (w *Writer) enqueue(id topoapi.ID, u update) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stats.Updates++
+	if _, ok := w.pending[id]; ok {
+		w.stats.Coalesced++
+	} else {
+		w.queue = append(w.queue, id)
+	}
+	w.pending[id] = u
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Stats gets the counters of the writer
+func (w *Writer) Stats() WriterStats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	stats := w.stats
+	stats.Pending = len(w.pending)
+	return stats
+}
+
+func (w *Writer) run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		id, u, ok := w.next()
+		if !ok {
+			select {
+			case <-w.notify:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+		w.write(ctx, id, u, ticker.C)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// next dequeues the object updated first
+func (w *Writer) next() (topoapi.ID, update, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.queue) == 0 {
+		return "", nil, false
+	}
+	id := w.queue[0]
+	w.queue = w.queue[1:]
+	u := w.pending[id]
+	delete(w.pending, id)
+	return id, u, true
+}
+
+// superseded returns true if a newer update of the object was queued while it was being written
+func (w *Writer) superseded(id topoapi.ID) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, ok := w.pending[id]
+	return ok
+}
+
+// write writes an update, retrying it on revision conflicts; each retry waits for the backoff and then for the
+// next write slot of the throttle
+func (w *Writer) write(ctx context.Context, id topoapi.ID, u update, throttle <-chan time.Time) {
+	start := time.Now()
+	backoff := w.retryBackoff
+	for attempt := 0; ; attempt++ {
+		err := u(ctx)
+		if err == nil {
+			w.recordWrite(time.Since(start))
+			return
+		}
+		if !errors.IsConflict(err) {
+			w.recordFailure(false)
+			log.Warnf("Failed to write the aspects of %s: %v", id, err)
+			return
+		}
+		if w.superseded(id) {
+			// the newer update is written instead
+			w.recordConflict()
+			log.Debugf("Dropping conflicting write of %s superseded by a newer update", id)
+			return
+		}
+		if attempt >= w.maxRetries {
+			w.recordFailure(true)
+			log.Warnf("Failed to write the aspects of %s after %d retries: %v", id, attempt, err)
+			return
+		}
+		w.recordConflict()
+		log.Debugf("Retrying conflicting write of %s in %s: %v", id, backoff, err)
+		if !wait(ctx, backoff, throttle) {
+			return
+		}
+		backoff *= 2
+	}
+}
+
+// wait waits for the given delay and then for the throttle; it returns false if the context is done first
+func wait(ctx context.Context, delay time.Duration, throttle <-chan time.Time) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		return false
+	}
+	select {
+	case <-throttle:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (w *Writer) recordWrite(latency time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stats.Writes++
+	w.stats.LastLatency = latency
+	w.stats.TotalLatency += latency
+	if latency > w.stats.MaxLatency {
+		w.stats.MaxLatency = latency
+	}
+}
+
+func (w *Writer) recordConflict() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stats.Conflicts++
+}
+
+func (w *Writer) recordFailure(conflict bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stats.Failures++
+	if conflict {
+		w.stats.Conflicts++
+	}
+}
+
+// logStats periodically logs the counters of the writer whenever it was updated
+func (w *Writer) logStats(ctx context.Context) {
+	ticker := time.NewTicker(writerStatsInterval)
+	defer ticker.Stop()
+	var updates uint64
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		stats := w.Stats()
+		if stats.Updates == updates {
+			continue
+		}
+		updates = stats.Updates
+		log.Infof("Topo writer: %d updates, %d coalesced, %d writes, %d failures, %d conflicts, %d pending, average latency %s, max latency %s",
+			stats.Updates, stats.Coalesced, stats.Writes, stats.Failures, stats.Conflicts, stats.Pending,
+			stats.AverageLatency(), stats.MaxLatency)
+	}
+}
+
+var _ Client = &Writer{}
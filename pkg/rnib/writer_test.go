@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rnib
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	measurmentStore "github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// write is a cell aspects write received by the test client
+type write struct {
+	cellID topoapi.ID
+	value  int64
+}
+
+// testClient records the cell aspects writes, returning the scripted errors first
+type testClient struct {
+	Client
+	mu     sync.Mutex
+	writes []write
+	errs   []error
+	// onWrite is called before each write
+	onWrite func(w write)
+}
+
+func (c *testClient) UpdateCellAspects(_ context.Context, cellID topoapi.ID, measItems []measurmentStore.MeasurementItem, _ uint64) error {
+	w := write{cellID: cellID, value: measItems[0].MeasurementRecords[0].MeasurementValue.(int64)}
+	if c.onWrite != nil {
+		c.onWrite(w)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writes = append(c.writes, w)
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		return err
+	}
+	return nil
+}
+
+func (c *testClient) getWrites() []write {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]write{}, c.writes...)
+}
+
+func updateCell(t *testing.T, w *Writer, cellID topoapi.ID, value int64) {
+	assert.NoError(t, w.UpdateCellAspects(context.Background(), cellID,
+		newTestItems(measurmentStore.MeasurementRecord{MeasurementName: "A", MeasurementValue: value}), 1000))
+}
+
+// waitStats waits until the writer has no pending update and has given up on or written the given number of updates
+func waitStats(t *testing.T, w *Writer, done uint64) WriterStats {
+	assert.Eventually(t, func() bool {
+		stats := w.Stats()
+		return stats.Pending == 0 && stats.Writes+stats.Failures == done
+	}, time.Second, time.Millisecond)
+	return w.Stats()
+}
+
+func TestWriterCoalescing(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	blocked := make(chan struct{})
+	release := make(chan struct{})
+	client := &testClient{}
+	client.onWrite = func(w write) {
+		if w.value == 1 {
+			close(blocked)
+			<-release
+		}
+	}
+	w := NewWriter(ctx, client, WithWriteRate(1000))
+
+	// the updates queued while the first one is written are coalesced per cell
+	updateCell(t, w, "e2:1/1", 1)
+	<-blocked
+	updateCell(t, w, "e2:1/1", 2)
+	updateCell(t, w, "e2:1/2", 10)
+	updateCell(t, w, "e2:1/1", 3)
+	close(release)
+
+	stats := waitStats(t, w, 3)
+	assert.Equal(t, []write{{"e2:1/1", 1}, {"e2:1/1", 3}, {"e2:1/2", 10}}, client.getWrites())
+	assert.Equal(t, uint64(4), stats.Updates)
+	assert.Equal(t, uint64(1), stats.Coalesced)
+	assert.Equal(t, uint64(3), stats.Writes)
+	assert.Equal(t, uint64(0), stats.Failures)
+	assert.NotZero(t, stats.AverageLatency())
+}
+
+func TestWriterRetries(t *testing.T) {
+	conflict := errors.NewConflict("revision mismatch")
+	tests := []struct {
+		name      string
+		errs      []error
+		supersede bool
+		writes    []write
+		stats     WriterStats
+	}{
+		{
+			name:   "written",
+			writes: []write{{"e2:1/1", 1}},
+			stats:  WriterStats{Updates: 1, Writes: 1},
+		},
+		{
+			name:   "conflict retried",
+			errs:   []error{conflict, conflict},
+			writes: []write{{"e2:1/1", 1}, {"e2:1/1", 1}, {"e2:1/1", 1}},
+			stats:  WriterStats{Updates: 1, Writes: 1, Conflicts: 2},
+		},
+		{
+			name:   "retries exhausted",
+			errs:   []error{conflict, conflict, conflict},
+			writes: []write{{"e2:1/1", 1}, {"e2:1/1", 1}, {"e2:1/1", 1}},
+			stats:  WriterStats{Updates: 1, Failures: 1, Conflicts: 3},
+		},
+		{
+			name:   "other error not retried",
+			errs:   []error{errors.NewUnavailable("topo is unavailable")},
+			writes: []write{{"e2:1/1", 1}},
+			stats:  WriterStats{Updates: 1, Failures: 1},
+		},
+		{
+			name:      "conflict superseded by a newer update",
+			errs:      []error{conflict},
+			supersede: true,
+			writes:    []write{{"e2:1/1", 1}, {"e2:1/1", 2}},
+			stats:     WriterStats{Updates: 2, Writes: 1, Conflicts: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			client := &testClient{errs: test.errs}
+			w := NewWriter(ctx, client, WithWriteRate(1000), WithMaxWriteRetries(2), WithWriteRetryBackoff(time.Millisecond))
+			if test.supersede {
+				client.onWrite = func(write write) {
+					if write.value == 1 {
+						updateCell(t, w, "e2:1/1", 2)
+					}
+				}
+			}
+			updateCell(t, w, "e2:1/1", 1)
+
+			stats := waitStats(t, w, test.stats.Writes+test.stats.Failures)
+			assert.Equal(t, test.writes, client.getWrites())
+			assert.Equal(t, test.stats.Updates, stats.Updates)
+			assert.Equal(t, test.stats.Writes, stats.Writes)
+			assert.Equal(t, test.stats.Failures, stats.Failures)
+			assert.Equal(t, test.stats.Conflicts, stats.Conflicts)
+		})
+	}
+}
+
+func TestWriterRetryThrottled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conflict := errors.NewConflict("revision mismatch")
+	client := &testClient{errs: []error{conflict, conflict}}
+	var mu sync.Mutex
+	var times []time.Time
+	client.onWrite = func(write) {
+		mu.Lock()
+		defer mu.Unlock()
+		times = append(times, time.Now())
+	}
+	w := NewWriter(ctx, client, WithWriteRate(20), WithWriteRetryBackoff(10*time.Millisecond))
+	updateCell(t, w, "e2:1/1", 1)
+	waitStats(t, w, 1)
+
+	// the retries wait for their backoff and for the write rate
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, times, 3)
+	for i := 1; i < len(times); i++ {
+		assert.GreaterOrEqual(t, times[i].Sub(times[i-1]), 40*time.Millisecond)
+	}
+
+	expected := `
+# HELP kpimon_topo_writer_conflicts_total Number of aspect writes failing with a revision conflict
+# TYPE kpimon_topo_writer_conflicts_total counter
+kpimon_topo_writer_conflicts_total 2
+# HELP kpimon_topo_writer_writes_total Number of successful aspect writes
+# TYPE kpimon_topo_writer_writes_total counter
+kpimon_topo_writer_writes_total 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(w, strings.NewReader(expected),
+		"kpimon_topo_writer_conflicts_total", "kpimon_topo_writer_writes_total"))
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+const leaseFileSuffix = ".lease"
+
+// lease is the content of the lease file of a replica
+type lease struct {
+	ID        string    `json:"id"`
+	Address   string    `json:"address"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// JoinLeaseDir keeps the local replica in the replica set of a lease directory until the context is done
+// Each replica renews a lease file of the directory and the replica set is made of the replicas whose lease
+// has not expired, so that the E2 nodes of a replica that stops are handed over once its lease expires.
+// The replicas must share a file system and a clock.
+func JoinLeaseDir(ctx context.Context, sharder Sharder, dir string, address string, opts ...Option) error {
+	if dir == "" {
+		return errors.NewInvalid("lease directory is required")
+	}
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+	l := &leaseDir{
+		dir:     dir,
+		local:   Member{ID: sharder.Local(), Address: address},
+		options: newOptions(opts...),
+		sharder: sharder,
+	}
+	l.renew()
+	go func() {
+		ticker := time.NewTicker(l.options.RenewInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				l.renew()
+			case <-ctx.Done():
+				l.leave()
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+type leaseDir struct {
+	dir     string
+	local   Member
+	options Options
+	sharder Sharder
+}
+
+// renew renews the lease of the local replica and updates the replica set with the live replicas
+func (l *leaseDir) renew() {
+	now := time.Now()
+	err := l.write(lease{
+		ID:        string(l.local.ID),
+		Address:   l.local.Address,
+		ExpiresAt: now.Add(l.options.LeaseDuration),
+	})
+	if err != nil {
+		log.Warnf("Failed to renew the lease of replica %s: %v", l.local.ID, err)
+	}
+	members, err := l.members(now)
+	if err != nil {
+		log.Warnf("Failed to read the replica set leases: %v", err)
+		return
+	}
+	l.sharder.SetMembers(members)
+}
+
+// leave removes the lease of the local replica, so that the other replicas take its E2 nodes over right away
+func (l *leaseDir) leave() {
+	err := os.Remove(l.path())
+	if err != nil && !os.IsNotExist(err) {
+		log.Warnf("Failed to remove the lease of replica %s: %v", l.local.ID, err)
+	}
+}
+
+// members reads the replicas whose lease has not expired
+func (l *leaseDir) members(now time.Time) ([]Member, error) {
+	files, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]Member, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), leaseFileSuffix) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(l.dir, file.Name()))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		current := lease{}
+		if err := json.Unmarshal(data, &current); err != nil {
+			log.Warnf("Ignoring the invalid lease file %s: %v", file.Name(), err)
+			continue
+		}
+		if current.ID == "" || !now.Before(current.ExpiresAt) {
+			continue
+		}
+		members = append(members, Member{
+			ID:      MemberID(current.ID),
+			Address: current.Address,
+		})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].ID < members[j].ID
+	})
+	return members, nil
+}
+
+// write writes the lease to a temporary file that replaces the lease file, so that a reader never sees a partial lease
+func (l *leaseDir) write(current lease) error {
+	data, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	tmpPath := l.path() + ".tmp"
+	err = os.WriteFile(tmpPath, data, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, l.path())
+}
+
+// path gets the path of the lease file of the local replica
+func (l *leaseDir) path() string {
+	return filepath.Join(l.dir, url.PathEscape(string(l.local.ID))+leaseFileSuffix)
+}
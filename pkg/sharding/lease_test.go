@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const testLeaseDuration = 300 * time.Millisecond
+
+func hasMembers(s Sharder, ids ...MemberID) func() bool {
+	return func() bool {
+		members := s.Ring().Members()
+		if len(members) != len(ids) {
+			return false
+		}
+		for i, member := range members {
+			if member.ID != ids[i] {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func TestJoinLeaseDir(t *testing.T) {
+	dir := t.TempDir()
+	ctxA, cancelA := context.WithCancel(context.Background())
+	defer cancelA()
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+
+	a := NewSharder("a")
+	b := NewSharder("b")
+	assert.NoError(t, JoinLeaseDir(ctxA, a, dir, "a:5150", WithLeaseDuration(testLeaseDuration)))
+	assert.NoError(t, JoinLeaseDir(ctxB, b, dir, "b:5150", WithLeaseDuration(testLeaseDuration)))
+	assert.Eventually(t, hasMembers(a, "a", "b"), time.Second, 10*time.Millisecond)
+	assert.Eventually(t, hasMembers(b, "a", "b"), time.Second, 10*time.Millisecond)
+	assert.Equal(t, newTestMembers("a", "b"), a.Ring().Members())
+
+	// a replica that stops leaves the replica set
+	cancelB()
+	assert.Eventually(t, hasMembers(a, "a"), time.Second, 10*time.Millisecond)
+	cancelA()
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(filepath.Join(dir, "a"+leaseFileSuffix))
+		return os.IsNotExist(err)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestLeaseDirMembers(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	l := &leaseDir{dir: dir}
+	files := []struct {
+		name string
+		data string
+	}{
+		{"a.lease", `{"id": "a", "address": "a:5150", "expiresAt": "` + now.Add(time.Second).Format(time.RFC3339Nano) + `"}`},
+		// the lease of a replica that crashed has expired
+		{"b.lease", `{"id": "b", "address": "b:5150", "expiresAt": "` + now.Add(-time.Second).Format(time.RFC3339Nano) + `"}`},
+		{"c.lease", `invalid`},
+		{"d.lease.tmp", `{"id": "d", "address": "d:5150", "expiresAt": "` + now.Add(time.Second).Format(time.RFC3339Nano) + `"}`},
+	}
+	for _, file := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, file.name), []byte(file.data), 0644))
+	}
+	members, err := l.members(now)
+	assert.NoError(t, err)
+	assert.Equal(t, newTestMembers("a"), members)
+}
+
+func TestJoinLeaseDirInvalid(t *testing.T) {
+	err := JoinLeaseDir(context.Background(), NewSharder("a"), "", "a:5150")
+	assert.True(t, errors.IsInvalid(err))
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import "time"
+
+const defaultLeaseDuration = 10 * time.Second
+
+// Options sharder options
+type Options struct {
+	// VirtualNodes is the number of points of each member on the ring
+	VirtualNodes int
+	// LeaseDuration is how long a replica stays in a lease directory replica set without renewing its lease
+	LeaseDuration time.Duration
+	// RenewInterval is the interval at which the replicas renew their lease and read the others';
+	// it defaults to a third of the lease duration
+	RenewInterval time.Duration
+}
+
+// Option sharder option interface
+type Option interface {
+	apply(*Options)
+}
+
+type funcOption struct {
+	f func(*Options)
+}
+
+func (f funcOption) apply(options *Options) {
+	f.f(options)
+}
+
+func newOption(f func(*Options)) Option {
+	return funcOption{
+		f: f,
+	}
+}
+
+// WithVirtualNodes sets the number of points of each member on the ring
+func WithVirtualNodes(virtualNodes int) Option {
+	return newOption(func(options *Options) {
+		options.VirtualNodes = virtualNodes
+	})
+}
+
+// WithLeaseDuration sets how long a replica stays in a lease directory replica set without renewing its lease
+func WithLeaseDuration(duration time.Duration) Option {
+	return newOption(func(options *Options) {
+		options.LeaseDuration = duration
+	})
+}
+
+// WithRenewInterval sets the interval at which the replica leases are renewed and read
+func WithRenewInterval(interval time.Duration) Option {
+	return newOption(func(options *Options) {
+		options.RenewInterval = interval
+	})
+}
+
+func newOptions(opts ...Option) Options {
+	options := Options{}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	if options.LeaseDuration <= 0 {
+		options.LeaseDuration = defaultLeaseDuration
+	}
+	if options.RenewInterval <= 0 || options.RenewInterval >= options.LeaseDuration {
+		options.RenewInterval = options.LeaseDuration / 3
+	}
+	return options
+}
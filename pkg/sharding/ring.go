@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+const defaultVirtualNodes = 128
+
+// MemberID is the identifier of a kpimon replica in the replica set
+type MemberID string
+
+// Member is a kpimon replica of the replica set
+type Member struct {
+	ID MemberID
+	// Address is the northbound address of the replica, used to fan out the queries
+	Address string
+}
+
+// ParseMembers parses a comma separated list of "id=address" replica set members
+func ParseMembers(value string) ([]Member, error) {
+	members := make([]Member, 0)
+	ids := make(map[MemberID]bool)
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		id, address, _ := strings.Cut(field, "=")
+		member := Member{
+			ID:      MemberID(strings.TrimSpace(id)),
+			Address: strings.TrimSpace(address),
+		}
+		if member.ID == "" {
+			return nil, errors.NewInvalid("invalid replica set member %s", field)
+		}
+		if ids[member.ID] {
+			return nil, errors.NewInvalid("duplicate replica set member %s", member.ID)
+		}
+		ids[member.ID] = true
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+// Ring is a consistent hash ring of the replica set members
+// Each member is placed at several points of the ring, so that the keys are spread evenly and
+// only the keys of a joining or leaving member change owner.
+type Ring struct {
+	members map[MemberID]Member
+	points  []uint64
+	owners  map[uint64]MemberID
+}
+
+// NewRing creates a consistent hash ring of the given members
+func NewRing(members []Member, virtualNodes int) *Ring {
+	if virtualNodes <= 0 {
+		virtualNodes = defaultVirtualNodes
+	}
+	ring := &Ring{
+		members: make(map[MemberID]Member, len(members)),
+		points:  make([]uint64, 0, len(members)*virtualNodes),
+		owners:  make(map[uint64]MemberID, len(members)*virtualNodes),
+	}
+	for _, member := range members {
+		ring.members[member.ID] = member
+		for i := 0; i < virtualNodes; i++ {
+			point := hash(string(member.ID) + "#" + strconv.Itoa(i))
+			// on a collision the member with the lowest ID keeps the point, so that all replicas agree
+			if owner, ok := ring.owners[point]; ok && owner < member.ID {
+				continue
+			} else if !ok {
+				ring.points = append(ring.points, point)
+			}
+			ring.owners[point] = member.ID
+		}
+	}
+	sort.Slice(ring.points, func(i, j int) bool {
+		return ring.points[i] < ring.points[j]
+	})
+	return ring
+}
+
+// Owner gets the member owning a key; false is returned if the ring is empty
+func (r *Ring) Owner(key string) (Member, bool) {
+	if len(r.points) == 0 {
+		return Member{}, false
+	}
+	point := hash(key)
+	i := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i] >= point
+	})
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.members[r.owners[r.points[i]]], true
+}
+
+// Members gets the ring members sorted by ID
+func (r *Ring) Members() []Member {
+	members := make([]Member, 0, len(r.members))
+	for _, member := range r.members {
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].ID < members[j].ID
+	})
+	return members
+}
+
+// Len gets the number of ring members
+func (r *Ring) Len() int {
+	return len(r.members)
+}
+
+// hash hashes a key with FNV-1a followed by a 64-bit finalizer, since FNV alone spreads
+// keys that only differ by their last characters poorly
+func hash(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
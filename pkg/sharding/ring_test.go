@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMembers(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		members []Member
+		valid   bool
+	}{
+		{"empty", "", []Member{}, true},
+		{"members", "a=host-a:5150, b=host-b:5150", []Member{{"a", "host-a:5150"}, {"b", "host-b:5150"}}, true},
+		{"no address", "a", []Member{{"a", ""}}, true},
+		{"empty fields are skipped", "a=host-a:5150,,", []Member{{"a", "host-a:5150"}}, true},
+		{"missing ID", "=host-a:5150", nil, false},
+		{"duplicate ID", "a=host-a:5150,a=host-b:5150", nil, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			members, err := ParseMembers(test.value)
+			if !test.valid {
+				assert.True(t, errors.IsInvalid(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.members, members)
+		})
+	}
+}
+
+func newTestMembers(ids ...MemberID) []Member {
+	members := make([]Member, 0, len(ids))
+	for _, id := range ids {
+		members = append(members, Member{ID: id, Address: string(id) + ":5150"})
+	}
+	return members
+}
+
+func owners(ring *Ring, keys int) map[string]MemberID {
+	result := make(map[string]MemberID, keys)
+	for i := 0; i < keys; i++ {
+		key := fmt.Sprintf("e2:1/%d", i)
+		owner, _ := ring.Owner(key)
+		result[key] = owner.ID
+	}
+	return result
+}
+
+func TestRing(t *testing.T) {
+	_, ok := NewRing(nil, 0).Owner("e2:1/1")
+	assert.False(t, ok)
+
+	const keys = 3000
+	ring := NewRing(newTestMembers("a", "b", "c"), 0)
+	assert.Equal(t, 3, ring.Len())
+	assert.Equal(t, newTestMembers("a", "b", "c"), ring.Members())
+	before := owners(ring, keys)
+
+	// the keys are spread evenly
+	counts := make(map[MemberID]int)
+	for _, owner := range before {
+		counts[owner]++
+	}
+	for id, count := range counts {
+		assert.InDelta(t, keys/3, count, keys/6, "member %s owns %d keys", id, count)
+	}
+
+	// the order of the members does not matter
+	assert.Equal(t, before, owners(NewRing(newTestMembers("c", "a", "b"), 0), keys))
+
+	tests := []struct {
+		name    string
+		members []Member
+		// moved checks the owners of the keys that changed owner
+		moved func(before, after MemberID) bool
+	}{
+		{
+			name:    "leaving member",
+			members: newTestMembers("a", "b"),
+			moved: func(before, _ MemberID) bool {
+				return before == "c"
+			},
+		},
+		{
+			name:    "joining member",
+			members: newTestMembers("a", "b", "c", "d"),
+			moved: func(_, after MemberID) bool {
+				return after == "d"
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			after := owners(NewRing(test.members, 0), keys)
+			for key, owner := range after {
+				if owner != before[key] {
+					assert.True(t, test.moved(before[key], owner), "key %s moved from %s to %s", key, before[key], owner)
+				}
+			}
+		})
+	}
+}
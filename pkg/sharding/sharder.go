@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/onosproject/onos-kpimon/pkg/store/watcher"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+)
+
+var log = logging.GetLogger()
+
+// Sharder decides which E2 nodes are owned by the local replica
+type Sharder interface {
+	// Local gets the local replica
+	Local() MemberID
+
+	// Owns returns true if the local replica owns an E2 node
+	// All of the nodes are owned while sharding is disabled, i.e. while the replica set is empty.
+	Owns(nodeID string) bool
+
+	// Ring gets the current ring; it is empty while sharding is disabled
+	Ring() *Ring
+
+	// SetMembers sets the replica set members
+	SetMembers(members []Member)
+
+	// Watch watches the ring changes; the current ring is sent first
+	Watch(ctx context.Context, ch chan<- *Ring) error
+}
+
+// NewSharder creates a new sharder for a local replica
+func NewSharder(local MemberID, opts ...Option) Sharder {
+	options := newOptions(opts...)
+	return &sharder{
+		local:    local,
+		options:  options,
+		ring:     NewRing(nil, options.VirtualNodes),
+		watchers: watcher.NewWatchers[*Ring](),
+	}
+}
+
+type sharder struct {
+	local    MemberID
+	options  Options
+	ring     *Ring
+	mu       sync.RWMutex
+	watchers *watcher.Watchers[*Ring]
+}
+
+func (s *sharder) Local() MemberID {
+	return s.local
+}
+
+func (s *sharder) Owns(nodeID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.ring.Len() == 0 {
+		return true
+	}
+	owner, _ := s.ring.Owner(nodeID)
+	return owner.ID == s.local
+}
+
+func (s *sharder) Ring() *Ring {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ring
+}
+
+func (s *sharder) SetMembers(members []Member) {
+	ring := NewRing(members, s.options.VirtualNodes)
+	if _, ok := ring.members[s.local]; !ok && ring.Len() > 0 {
+		log.Warnf("Replica %s is not a member of the replica set and owns no E2 node", s.local)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sameMembers(s.ring, ring) {
+		return
+	}
+	log.Infof("Replica set changed to %v", ring.Members())
+	s.ring = ring
+	s.watchers.Send(ring)
+}
+
+func (s *sharder) Watch(ctx context.Context, ch chan<- *Ring) error {
+	id := uuid.New()
+	s.mu.RLock()
+	err := s.watchers.AddWatcher(id, ch, s.ring)
+	s.mu.RUnlock()
+	if err != nil {
+		close(ch)
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		err := s.watchers.RemoveWatcher(id)
+		if err != nil && !errors.IsNotFound(err) {
+			log.Error(err)
+		}
+	}()
+	return nil
+}
+
+func sameMembers(a, b *Ring) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for id, member := range a.members {
+		if b.members[id] != member {
+			return false
+		}
+	}
+	return true
+}
+
+var _ Sharder = &sharder{}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func nextRing(t *testing.T, ch <-chan *Ring) *Ring {
+	select {
+	case ring := <-ch:
+		return ring
+	case <-time.After(time.Second):
+		t.Fatal("no ring received")
+		return nil
+	}
+}
+
+func TestSharder(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := NewSharder("a")
+	assert.Equal(t, MemberID("a"), s.Local())
+	// all of the nodes are owned while sharding is disabled
+	assert.True(t, s.Owns("e2:1/1"))
+
+	ch := make(chan *Ring)
+	assert.NoError(t, s.Watch(ctx, ch))
+	assert.Equal(t, 0, nextRing(t, ch).Len())
+
+	s.SetMembers(newTestMembers("a", "b"))
+	ring := nextRing(t, ch)
+	assert.Equal(t, 2, ring.Len())
+	for _, key := range []string{"e2:1/1", "e2:1/2", "e2:1/3", "e2:1/4"} {
+		owner, _ := ring.Owner(key)
+		assert.Equal(t, owner.ID == "a", s.Owns(key))
+	}
+
+	// setting the same members again is not a change
+	s.SetMembers(newTestMembers("b", "a"))
+	s.SetMembers(newTestMembers("b"))
+	assert.Equal(t, newTestMembers("b"), nextRing(t, ch).Members())
+	// a replica outside of the replica set owns no node
+	assert.False(t, s.Owns("e2:1/1"))
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		_, ok := <-ch
+		return !ok
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,272 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"github.com/onosproject/onos-kpimon/pkg/broker"
+	"github.com/onosproject/onos-kpimon/pkg/store/actions"
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"google.golang.org/protobuf/proto"
+)
+
+var log = logging.GetLogger()
+
+// NewManager creates a new snapshot manager
+func NewManager(dir string, measurementStore measurements.Store, actionStore actions.Store, streams broker.Broker) *Manager {
+	return &Manager{
+		dir:              dir,
+		measurementStore: measurementStore,
+		actionStore:      actionStore,
+		streams:          streams,
+	}
+}
+
+// Manager creates and restores snapshots of the kpimon state
+type Manager struct {
+	dir              string
+	measurementStore measurements.Store
+	actionStore      actions.Store
+	streams          broker.Broker
+}
+
+// Create creates a snapshot of the current state
+func (m *Manager) Create(ctx context.Context) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		Version:       Version,
+		CreatedAt:     time.Now(),
+		Measurements:  make([]Measurement, 0),
+		Actions:       make([]Action, 0),
+		Subscriptions: make([]Subscription, 0),
+	}
+
+	measEntries, err := m.measurementStore.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range measEntries {
+		snapshot.Measurements = append(snapshot.Measurements, newMeasurement(entry))
+	}
+
+	actionEntries, err := m.actionStore.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range actionEntries {
+		definition, err := proto.Marshal(entry.Value)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Actions = append(snapshot.Actions, Action{
+			SubID:      entry.Key.SubscriptionID.SubID,
+			Definition: definition,
+		})
+	}
+
+	for _, stream := range m.streams.Streams() {
+		subSpec := stream.Subscription()
+		spec, err := subSpec.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Subscriptions = append(snapshot.Subscriptions, Subscription{
+			NodeID:    string(stream.Node().ID()),
+			ChannelID: string(stream.ChannelID()),
+			Name:      stream.SubscriptionName(),
+			Spec:      spec,
+		})
+	}
+	return snapshot, nil
+}
+
+// Restore replaces the stored measurements and action definitions with the ones of a snapshot
+// The subscriptions of the snapshot are not restored since they are created from topo.
+func (m *Manager) Restore(ctx context.Context, snapshot *Snapshot) error {
+	measEntries := make([]*measurements.Entry, 0, len(snapshot.Measurements))
+	for _, measurement := range snapshot.Measurements {
+		measEntries = append(measEntries, measurement.toEntry())
+	}
+
+	actionEntries := make([]*actions.Entry, 0, len(snapshot.Actions))
+	for _, action := range snapshot.Actions {
+		definition := &e2smkpmv2.E2SmKpmActionDefinitionFormat1{}
+		err := proto.Unmarshal(action.Definition, definition)
+		if err != nil {
+			return errors.NewInvalid("invalid action definition for subscription %d: %v", action.SubID, err)
+		}
+		actionEntries = append(actionEntries, &actions.Entry{
+			Key: actions.NewKey(actions.SubscriptionID{
+				SubID: action.SubID,
+			}),
+			Value: definition,
+		})
+	}
+
+	err := m.actionStore.Restore(ctx, actionEntries)
+	if err != nil {
+		return err
+	}
+	err = m.measurementStore.Restore(ctx, measEntries)
+	if err != nil {
+		return err
+	}
+	log.Debugf("Restored snapshot created at %s with %d measurements and %d actions",
+		snapshot.CreatedAt, len(measEntries), len(actionEntries))
+	return nil
+}
+
+// Save creates a snapshot of the current state and writes it to a file of the snapshot directory
+func (m *Manager) Save(ctx context.Context, name string) (*Snapshot, error) {
+	path, err := m.path(name)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := m.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(m.dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	// the snapshot is written to a temporary file first so that an existing snapshot is never left truncated
+	file, err := os.CreateTemp(m.dir, name+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+	err = Write(file, snapshot)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	err = file.Close()
+	if err != nil {
+		return nil, err
+	}
+	err = os.Rename(file.Name(), path)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Saved snapshot %s", path)
+	return snapshot, nil
+}
+
+// Load reads a snapshot from a file of the snapshot directory and restores it
+func (m *Manager) Load(ctx context.Context, name string) (*Snapshot, error) {
+	path, err := m.path(name)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.NewNotFound("snapshot %s not found", name)
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	snapshot, err := Read(file)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, m.Restore(ctx, snapshot)
+}
+
+// path gets the path of a snapshot file; the name must not refer to another directory
+func (m *Manager) path(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return "", errors.NewInvalid("invalid snapshot name %s", name)
+	}
+	return filepath.Join(m.dir, name), nil
+}
+
+func newMeasurement(entry *measurements.Entry) Measurement {
+	measurement := Measurement{
+		NodeID:       entry.Key.NodeID,
+		CellID:       entry.Key.CellIdentity.CellID,
+		CellGlobalID: entry.CellGlobalID,
+		PlmnID:       entry.PlmnID,
+		UpdatedAt:    entry.UpdatedAt,
+		Stale:        entry.Stale,
+		Items:        make([][]Record, 0, len(entry.Value)),
+	}
+	for _, item := range entry.Value {
+		records := make([]Record, 0, len(item.MeasurementRecords))
+		for _, measRecord := range item.MeasurementRecords {
+			record := Record{
+				Timestamp: measRecord.Timestamp,
+				Name:      measRecord.MeasurementName,
+				Labels:    measRecord.Labels,
+			}
+			switch val := measRecord.MeasurementValue.(type) {
+			case int64:
+				record.Type = IntegerValueType
+				record.Integer = val
+			case float64:
+				record.Type = RealValueType
+				record.Real = val
+			case int32:
+				record.Type = NoValueType
+				record.NoValue = val
+			default:
+				log.Warnf("Skipping record %s with unsupported value type %T", measRecord.MeasurementName, val)
+				continue
+			}
+			records = append(records, record)
+		}
+		measurement.Items = append(measurement.Items, records)
+	}
+	return measurement
+}
+
+func (m Measurement) toEntry() *measurements.Entry {
+	items := make([]measurements.MeasurementItem, 0, len(m.Items))
+	for _, records := range m.Items {
+		item := measurements.MeasurementItem{
+			MeasurementRecords: make([]measurements.MeasurementRecord, 0, len(records)),
+		}
+		for _, record := range records {
+			measRecord := measurements.MeasurementRecord{
+				Timestamp:       record.Timestamp,
+				MeasurementName: record.Name,
+				Labels:          record.Labels,
+			}
+			switch record.Type {
+			case IntegerValueType:
+				measRecord.MeasurementValue = record.Integer
+			case RealValueType:
+				measRecord.MeasurementValue = record.Real
+			case NoValueType:
+				measRecord.MeasurementValue = record.NoValue
+			default:
+				log.Warnf("Skipping record %s with unknown value type %s", record.Name, record.Type)
+				continue
+			}
+			item.MeasurementRecords = append(item.MeasurementRecords, measRecord)
+		}
+		items = append(items, item)
+	}
+
+	return &measurements.Entry{
+		Key: measurements.NewKey(measurements.CellIdentity{
+			CellID: m.CellID,
+		}, m.NodeID),
+		Value:        items,
+		CellGlobalID: m.CellGlobalID,
+		PlmnID:       m.PlmnID,
+		UpdatedAt:    m.UpdatedAt,
+		Stale:        m.Stale,
+	}
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// Version is the version of the snapshot format written by this package
+// Snapshots of a newer version are rejected when they are read.
+const Version = 1
+
+// Value types of the measurement records
+const (
+	IntegerValueType = "integer"
+	RealValueType    = "real"
+	NoValueType      = "noValue"
+)
+
+// Snapshot is the kpimon state
+type Snapshot struct {
+	Version       int            `json:"version"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	Measurements  []Measurement  `json:"measurements"`
+	Actions       []Action       `json:"actions"`
+	Subscriptions []Subscription `json:"subscriptions"`
+}
+
+// Measurement is a measurement store entry
+type Measurement struct {
+	NodeID       string     `json:"nodeId"`
+	CellID       string     `json:"cellId"`
+	CellGlobalID string     `json:"cellGlobalId,omitempty"`
+	PlmnID       uint32     `json:"plmnId,omitempty"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+	Stale        bool       `json:"stale,omitempty"`
+	Items        [][]Record `json:"items"`
+}
+
+// Record is a measurement record with a typed value
+type Record struct {
+	Timestamp uint64            `json:"timestamp"`
+	Name      string            `json:"name"`
+	Type      string            `json:"type"`
+	Integer   int64             `json:"integer,omitempty"`
+	Real      float64           `json:"real,omitempty"`
+	NoValue   int32             `json:"noValue,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// Action is an action store entry holding a protobuf encoded KPM action definition format 1
+type Action struct {
+	SubID      int64  `json:"subId"`
+	Definition []byte `json:"definition"`
+}
+
+// Subscription is an E2 subscription of kpimon
+// The subscriptions are informational: they are re-created from topo once kpimon runs.
+type Subscription struct {
+	NodeID    string `json:"nodeId"`
+	ChannelID string `json:"channelId"`
+	Name      string `json:"name"`
+	// Spec is the protobuf encoded E2 subscription spec
+	Spec []byte `json:"spec"`
+}
+
+// Write writes a snapshot
+func Write(w io.Writer, snapshot *Snapshot) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(snapshot)
+}
+
+// Read reads a snapshot and checks its version
+func Read(r io.Reader) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	err := json.NewDecoder(r).Decode(snapshot)
+	if err != nil {
+		return nil, errors.NewInvalid("cannot decode snapshot: %v", err)
+	}
+	if snapshot.Version < 1 || snapshot.Version > Version {
+		return nil, errors.NewInvalid("unsupported snapshot version %d", snapshot.Version)
+	}
+	return snapshot, nil
+}
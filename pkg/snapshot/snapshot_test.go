@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"github.com/onosproject/onos-kpimon/pkg/broker"
+	"github.com/onosproject/onos-kpimon/pkg/store/actions"
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"current version", `{"version": 1}`, true},
+		{"missing version", `{}`, false},
+		{"newer version", `{"version": 2}`, false},
+		{"invalid JSON", `{"version":`, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(test.input))
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.IsInvalid(err))
+			}
+		})
+	}
+}
+
+func newTestManager(dir string) (*Manager, measurements.Store, actions.Store) {
+	measurementStore := measurements.NewStore()
+	actionStore := actions.NewStore()
+	return NewManager(dir, measurementStore, actionStore, broker.NewBroker()), measurementStore, actionStore
+}
+
+func TestRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	m, measurementStore, actionStore := newTestManager(dir)
+
+	key := measurements.NewKey(measurements.CellIdentity{CellID: "cell1"}, "node1")
+	records := []measurements.MeasurementRecord{
+		{Timestamp: 1, MeasurementName: "A", MeasurementValue: int64(1), Labels: map[string]string{"qci": "1"}},
+		{Timestamp: 1, MeasurementName: "B", MeasurementValue: 2.5},
+		{Timestamp: 1, MeasurementName: "C", MeasurementValue: int32(0)},
+	}
+	_, err := measurementStore.Put(ctx, key, []measurements.MeasurementItem{{MeasurementRecords: records}},
+		measurements.WithCellGlobalID("cgi-1"), measurements.WithPlmnID(0x138426))
+	assert.NoError(t, err)
+	actionKey := actions.NewKey(actions.SubscriptionID{SubID: 7})
+	definition := &e2smkpmv2.E2SmKpmActionDefinitionFormat1{GranulPeriod: &e2smkpmv2.GranularityPeriod{Value: 1000}}
+	_, err = actionStore.Put(ctx, actionKey, definition)
+	assert.NoError(t, err)
+
+	saved, err := m.Save(ctx, "snapshot")
+	assert.NoError(t, err)
+	assert.Len(t, saved.Measurements, 1)
+	assert.Len(t, saved.Actions, 1)
+
+	// the snapshot is restored into empty stores
+	restored, restoredMeasurements, restoredActions := newTestManager(dir)
+	loaded, err := restored.Load(ctx, "snapshot")
+	assert.NoError(t, err)
+	assert.True(t, saved.CreatedAt.Equal(loaded.CreatedAt))
+
+	entry, err := restoredMeasurements.Get(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, "cgi-1", entry.CellGlobalID)
+	assert.Equal(t, uint32(0x138426), entry.PlmnID)
+	assert.Equal(t, records, entry.Value[0].MeasurementRecords)
+
+	actionEntry, err := restoredActions.Get(ctx, actionKey)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(definition, actionEntry.Value))
+}
+
+func TestRestoreReplacesEntries(t *testing.T) {
+	ctx := context.Background()
+	m, measurementStore, _ := newTestManager(t.TempDir())
+	oldKey := measurements.NewKey(measurements.CellIdentity{CellID: "cell1"}, "node1")
+	_, err := measurementStore.Put(ctx, oldKey, nil)
+	assert.NoError(t, err)
+
+	snapshot := &Snapshot{
+		Version:   Version,
+		CreatedAt: time.Now(),
+		Measurements: []Measurement{{
+			NodeID: "node2",
+			CellID: "cell2",
+			Items: [][]Record{{
+				{Timestamp: 1, Name: "A", Type: IntegerValueType, Integer: 1},
+				{Timestamp: 1, Name: "B", Type: "unknown"},
+			}},
+		}},
+	}
+	assert.NoError(t, m.Restore(ctx, snapshot))
+
+	_, err = measurementStore.Get(ctx, oldKey)
+	assert.True(t, errors.IsNotFound(err))
+	entry, err := measurementStore.Get(ctx, measurements.NewKey(measurements.CellIdentity{CellID: "cell2"}, "node2"))
+	assert.NoError(t, err)
+	// the records of an unknown type are skipped
+	assert.Len(t, entry.Value[0].MeasurementRecords, 1)
+
+	snapshot.Actions = []Action{{SubID: 1, Definition: []byte{0xff}}}
+	assert.True(t, errors.IsInvalid(m.Restore(ctx, snapshot)))
+}
+
+func TestWriteRead(t *testing.T) {
+	snapshot := &Snapshot{
+		Version:       Version,
+		CreatedAt:     time.Unix(1, 0).UTC(),
+		Measurements:  []Measurement{{NodeID: "node1", CellID: "cell1", Items: [][]Record{}}},
+		Actions:       []Action{{SubID: 1, Definition: []byte{1, 2}}},
+		Subscriptions: []Subscription{{NodeID: "node1", ChannelID: "channel1", Name: "sub1", Spec: []byte{3}}},
+	}
+	buf := &bytes.Buffer{}
+	assert.NoError(t, Write(buf, snapshot))
+	read, err := Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, snapshot, read)
+}
+
+func TestSnapshotName(t *testing.T) {
+	ctx := context.Background()
+	m, _, _ := newTestManager(t.TempDir())
+	for _, name := range []string{"", ".", "..", "../snapshot", "dir/snapshot"} {
+		_, err := m.Save(ctx, name)
+		assert.True(t, errors.IsInvalid(err), name)
+		_, err = m.Load(ctx, name)
+		assert.True(t, errors.IsInvalid(err), name)
+	}
+	_, err := m.Load(ctx, "missing")
+	assert.True(t, errors.IsNotFound(err))
+}
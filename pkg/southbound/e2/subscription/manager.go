@@ -8,8 +8,11 @@ package subscription
 import (
 	"context"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/onosproject/onos-kpimon/pkg/monitoring"
+	"github.com/onosproject/onos-kpimon/pkg/sharding"
 	"github.com/onosproject/onos-kpimon/pkg/store/actions"
 	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
 
@@ -37,12 +40,16 @@ var log = logging.GetLogger()
 
 const (
 	kpmServiceModelOID = "1.3.6.1.4.1.53148.1.2.2.2"
+	// unsubscribeTimeout bounds the time spent closing the subscriptions when the manager stops
+	unsubscribeTimeout = 10 * time.Second
 )
 
 // SubManager subscription manager interface
 type SubManager interface {
 	Start() error
 	Stop() error
+	// Resubscribe closes the subscriptions of an E2 node and subscribes to it again
+	Resubscribe(ctx context.Context, e2NodeID topoapi.ID) error
 }
 
 // Manager subscription manager
@@ -54,10 +61,16 @@ type Manager struct {
 	streams          broker.Broker
 	actionStore      actions.Store
 	measurementStore measurements.Store
+	sharder          sharding.Sharder
+	failureHandler   FailureHandler
+	// ctx is the context of the subscriptions; it is cancelled once the manager stops
+	ctx    context.Context
+	cancel context.CancelFunc
+	mu     sync.RWMutex
 }
 
 // NewManager creates a new subscription manager
-func NewManager(opts ...Option) (Manager, error) {
+func NewManager(opts ...Option) (*Manager, error) {
 	options := Options{}
 
 	for _, opt := range opts {
@@ -72,12 +85,16 @@ func NewManager(opts ...Option) (Manager, error) {
 		e2client.WithAppID(appID),
 		e2client.WithE2TAddress(options.E2TService.Host, options.E2TService.Port))
 
-	rnibClient, err := rnib.NewClient()
-	if err != nil {
-		return Manager{}, err
+	rnibClient := options.App.RNIBClient
+	if rnibClient == nil {
+		var err error
+		rnibClient, err = rnib.NewClient()
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return Manager{
+	return &Manager{
 		e2client:   e2Client,
 		rnibClient: rnibClient,
 		serviceModel: ServiceModelOptions{
@@ -88,15 +105,25 @@ func NewManager(opts ...Option) (Manager, error) {
 		streams:          options.App.Broker,
 		actionStore:      options.App.ActionStore,
 		measurementStore: options.App.MeasurementStore,
+		sharder:          options.App.Sharder,
+		failureHandler:   options.App.FailureHandler,
 	}, nil
 
 }
 
 // Start starts subscription manager
+// The subscriptions are created until the manager is stopped; starting a started manager has no effect.
 func (m *Manager) Start() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.cancel != nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.ctx = ctx
+	m.cancel = cancel
+
 	go func() {
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
 		err := m.watchE2Connections(ctx)
 		if err != nil {
 			return
@@ -104,13 +131,20 @@ func (m *Manager) Start() error {
 	}()
 
 	go func() {
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
 		err := m.watchConfigChanges(ctx)
 		if err != nil {
 			return
 		}
 	}()
+
+	if m.sharder != nil {
+		go func() {
+			err := m.watchShardChanges(ctx)
+			if err != nil {
+				return
+			}
+		}()
+	}
 	return nil
 }
 
@@ -145,7 +179,7 @@ func (m *Manager) watchConfigChanges(ctx context.Context) error {
 	}
 
 	for _, e2NodeID := range e2NodeIDs {
-		if !m.rnibClient.HasKPMRanFunction(ctx, e2NodeID, kpmServiceModelOID) {
+		if !m.rnibClient.HasKPMRanFunction(ctx, e2NodeID, kpmServiceModelOID) || !m.owns(e2NodeID) {
 			continue
 		}
 		go func(e2NodeID topoapi.ID) {
@@ -285,6 +319,9 @@ func (m *Manager) createSubscription(ctx context.Context, e2nodeID topoapi.ID) e
 
 func (m *Manager) newSubscription(ctx context.Context, e2NodeID topoapi.ID) error {
 	err := m.createSubscription(ctx, e2NodeID)
+	if err != nil && m.failureHandler != nil {
+		m.failureHandler(e2NodeID, err)
+	}
 	return err
 }
 
@@ -307,6 +344,10 @@ func (m *Manager) watchE2Connections(ctx context.Context) error {
 				log.Debugf("Received topo event does not have KPM RAN function - %v", topoEvent)
 				continue
 			}
+			if !m.owns(e2NodeID) {
+				log.Debugf("E2 node %s is owned by another replica", e2NodeID)
+				continue
+			}
 
 			go func(t topoapi.Event) {
 				log.Debugf("start creating subscriptions %v", t)
@@ -322,31 +363,77 @@ func (m *Manager) watchE2Connections(ctx context.Context) error {
 			if !m.rnibClient.HasKPMRanFunction(ctx, e2NodeID, kpmServiceModelOID) {
 				continue
 			}
-			cellIDs, err := m.rnibClient.GetCells(ctx, e2NodeID)
+			err := m.deleteMeasurements(ctx, e2NodeID)
 			if err != nil {
 				return err
 			}
-			for _, coi := range cellIDs {
-				key := measurements.Key{
-					NodeID: string(e2NodeID),
-					CellIdentity: measurements.CellIdentity{
-						CellID: coi.CellObjectID,
-					},
-				}
-				err = m.measurementStore.Delete(ctx, key)
-				if err != nil {
-					log.Warn(err)
-				}
-			}
 		}
 
 	}
 	return nil
 }
 
-// Stop stops the subscription manager
+// Resubscribe closes the subscriptions of an E2 node and subscribes to it again
+func (m *Manager) Resubscribe(ctx context.Context, e2NodeID topoapi.ID) error {
+	subCtx, ok := m.context()
+	if !ok {
+		return errors.NewUnavailable("the subscriptions are not started")
+	}
+	if !m.owns(e2NodeID) {
+		return errors.NewUnavailable("E2 node %s is owned by replica %s", e2NodeID, m.owner(e2NodeID))
+	}
+	if !m.rnibClient.HasKPMRanFunction(ctx, e2NodeID, kpmServiceModelOID) {
+		return errors.NewNotFound("E2 node %s does not support the KPM service model", e2NodeID)
+	}
+	for _, stream := range m.streams.Streams() {
+		if topoapi.ID(stream.Node().ID()) != e2NodeID {
+			continue
+		}
+		_, err := m.streams.CloseStream(ctx, stream.ChannelID())
+		if err != nil {
+			log.Warn(err)
+			return err
+		}
+	}
+	log.Infof("Resubscribing to E2 node %s", e2NodeID)
+	// the subscription outlives the request
+	go func() {
+		err := m.newSubscription(subCtx, e2NodeID)
+		if err != nil {
+			log.Warn(err)
+		}
+	}()
+	return nil
+}
+
+// Stop stops creating subscriptions and closes the open ones; the manager can be started again
 func (m *Manager) Stop() error {
-	panic("implement me")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.cancel == nil {
+		return nil
+	}
+	m.cancel()
+	m.ctx = nil
+	m.cancel = nil
+
+	ctx, cancel := context.WithTimeout(context.Background(), unsubscribeTimeout)
+	defer cancel()
+	var err error
+	for _, stream := range m.streams.Streams() {
+		if _, e := m.streams.CloseStream(ctx, stream.ChannelID()); e != nil {
+			log.Warn(e)
+			err = e
+		}
+	}
+	return err
+}
+
+// context gets the context of the subscriptions if the manager is started
+func (m *Manager) context() (context.Context, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.ctx, m.ctx != nil
 }
 
 var _ SubManager = &Manager{}
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package subscription
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-kpimon/pkg/rnib/fake"
+	"github.com/onosproject/onos-kpimon/pkg/sharding"
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestE2Node creates an E2 node supporting the KPM service model without any report style
+func newTestE2Node() *topoapi.E2Node {
+	return &topoapi.E2Node{
+		ServiceModels: map[string]*topoapi.ServiceModelInfo{
+			kpmServiceModelOID: {OID: kpmServiceModelOID, Name: "oran-e2sm-kpm"},
+		},
+	}
+}
+
+func TestWatchE2Connections(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	topo := fake.NewTopo()
+	topo.AddE2Node("e2:1", newTestE2Node())
+	// an E2 node without the KPM service model is ignored
+	topo.AddE2Node("e2:2", &topoapi.E2Node{})
+	store := measurements.NewStore()
+	failures := make(chan topoapi.ID, 2)
+	m := &Manager{
+		rnibClient:       topo,
+		serviceModel:     ServiceModelOptions{Name: "oran-e2sm-kpm"},
+		measurementStore: store,
+		failureHandler: func(e2NodeID topoapi.ID, err error) {
+			assert.True(t, errors.IsNotFound(err))
+			failures <- e2NodeID
+		},
+	}
+	go func() {
+		assert.NoError(t, m.watchE2Connections(ctx))
+	}()
+
+	// the subscription to a connected E2 node without report style fails
+	assert.NoError(t, topo.Connect("e2:2"))
+	assert.NoError(t, topo.Connect("e2:1"))
+	select {
+	case e2NodeID := <-failures:
+		assert.Equal(t, topoapi.ID("e2:1"), e2NodeID)
+	case <-time.After(time.Second):
+		t.Fatal("the failure handler was not called")
+	}
+
+	// the measurements of a disconnected E2 node are deleted
+	for _, key := range []measurements.Key{
+		measurements.NewKey(measurements.CellIdentity{CellID: "1"}, "e2:1"),
+		measurements.NewKey(measurements.CellIdentity{CellID: "1"}, "e2:2"),
+	} {
+		_, err := store.Put(ctx, key, []measurements.MeasurementItem{})
+		assert.NoError(t, err)
+	}
+	topo.Disconnect("e2:1")
+	assert.Eventually(t, func() bool {
+		result, err := store.Query(ctx, measurements.Query{})
+		return err == nil && len(result.Entries) == 1 && result.Entries[0].Key.NodeID == "e2:2"
+	}, time.Second, 10*time.Millisecond)
+	assert.Len(t, failures, 0)
+}
+
+func TestResubscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	topo := fake.NewTopo()
+	topo.AddE2Node("e2:1", newTestE2Node())
+	topo.AddE2Node("e2:2", &topoapi.E2Node{})
+	sharder := sharding.NewSharder("a")
+	sharder.SetMembers([]sharding.Member{{ID: "b", Address: "b:5150"}})
+
+	tests := []struct {
+		name    string
+		m       *Manager
+		nodeID  topoapi.ID
+		isError func(error) bool
+	}{
+		{
+			name:    "not started",
+			m:       &Manager{rnibClient: topo},
+			nodeID:  "e2:1",
+			isError: errors.IsUnavailable,
+		},
+		{
+			name:    "owned by another replica",
+			m:       &Manager{rnibClient: topo, ctx: ctx, sharder: sharder},
+			nodeID:  "e2:1",
+			isError: errors.IsUnavailable,
+		},
+		{
+			name:    "no KPM service model",
+			m:       &Manager{rnibClient: topo, ctx: ctx},
+			nodeID:  "e2:2",
+			isError: errors.IsNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.m.Resubscribe(ctx, test.nodeID)
+			assert.True(t, test.isError(err), err)
+		})
+	}
+}
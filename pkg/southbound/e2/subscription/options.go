@@ -5,9 +5,12 @@
 package subscription
 
 import (
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
 	"github.com/onosproject/onos-kpimon/pkg/broker"
 	appConfig "github.com/onosproject/onos-kpimon/pkg/config"
 	"github.com/onosproject/onos-kpimon/pkg/monitoring"
+	"github.com/onosproject/onos-kpimon/pkg/rnib"
+	"github.com/onosproject/onos-kpimon/pkg/sharding"
 	"github.com/onosproject/onos-kpimon/pkg/store/actions"
 	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
 )
@@ -36,8 +39,17 @@ type AppOptions struct {
 	ActionStore actions.Store
 
 	MeasurementStore measurements.Store
+
+	Sharder sharding.Sharder
+
+	RNIBClient rnib.Client
+
+	FailureHandler FailureHandler
 }
 
+// FailureHandler is called when the subscription to an E2 node fails
+type FailureHandler func(e2NodeID topoapi.ID, err error)
+
 // E2TServiceOptions are the options for a E2T service
 type E2TServiceOptions struct {
 	// Host is the service host
@@ -176,3 +188,24 @@ func WithMeasurementStore(measurementStore measurements.Store) Option {
 		options.App.MeasurementStore = measurementStore
 	})
 }
+
+// WithSharder sets the sharder deciding which E2 nodes are subscribed by this replica
+func WithSharder(sharder sharding.Sharder) Option {
+	return newOption(func(options *Options) {
+		options.App.Sharder = sharder
+	})
+}
+
+// WithRNIBClient sets the shared R-NIB client; a dedicated client is created otherwise
+func WithRNIBClient(rnibClient rnib.Client) Option {
+	return newOption(func(options *Options) {
+		options.App.RNIBClient = rnibClient
+	})
+}
+
+// WithFailureHandler sets the handler called when the subscription to an E2 node fails
+func WithFailureHandler(handler FailureHandler) Option {
+	return newOption(func(options *Options) {
+		options.App.FailureHandler = handler
+	})
+}
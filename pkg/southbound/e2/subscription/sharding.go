@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package subscription
+
+import (
+	"context"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-kpimon/pkg/sharding"
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+)
+
+// owns returns true if the E2 node is owned by this replica
+func (m *Manager) owns(e2NodeID topoapi.ID) bool {
+	return m.sharder == nil || m.sharder.Owns(string(e2NodeID))
+}
+
+// watchShardChanges hands the E2 nodes over whenever replicas join or leave the replica set
+func (m *Manager) watchShardChanges(ctx context.Context) error {
+	ch := make(chan *sharding.Ring)
+	err := m.sharder.Watch(ctx, ch)
+	if err != nil {
+		log.Warn(err)
+		return err
+	}
+
+	// the current ring is handled by watchE2Connections
+	<-ch
+	for range ch {
+		err := m.rebalance(ctx)
+		if err != nil {
+			log.Warn(err)
+		}
+	}
+	return nil
+}
+
+// rebalance closes the subscriptions of the E2 nodes this replica no longer owns
+// and subscribes to the E2 nodes it now owns
+func (m *Manager) rebalance(ctx context.Context) error {
+	subscribed := make(map[topoapi.ID]bool)
+	for _, stream := range m.streams.Streams() {
+		e2NodeID := topoapi.ID(stream.Node().ID())
+		if m.owns(e2NodeID) {
+			subscribed[e2NodeID] = true
+			continue
+		}
+
+		log.Infof("Handing E2 node %s over to replica %s", e2NodeID, m.owner(e2NodeID))
+		_, err := m.streams.CloseStream(ctx, stream.ChannelID())
+		if err != nil {
+			log.Warn(err)
+			continue
+		}
+		err = m.deleteMeasurements(ctx, e2NodeID)
+		if err != nil {
+			log.Warn(err)
+		}
+	}
+
+	e2NodeIDs, err := m.rnibClient.E2NodeIDs(ctx, kpmServiceModelOID)
+	if err != nil {
+		return err
+	}
+	for _, e2NodeID := range e2NodeIDs {
+		if subscribed[e2NodeID] || !m.owns(e2NodeID) || !m.rnibClient.HasKPMRanFunction(ctx, e2NodeID, kpmServiceModelOID) {
+			continue
+		}
+		log.Infof("Taking E2 node %s over", e2NodeID)
+		go func(e2NodeID topoapi.ID) {
+			err := m.newSubscription(ctx, e2NodeID)
+			if err != nil {
+				log.Warn(err)
+			}
+		}(e2NodeID)
+	}
+	return nil
+}
+
+// owner gets the replica owning an E2 node
+func (m *Manager) owner(e2NodeID topoapi.ID) sharding.MemberID {
+	owner, _ := m.sharder.Ring().Owner(string(e2NodeID))
+	return owner.ID
+}
+
+// deleteMeasurements deletes the measurements of the cells of an E2 node
+func (m *Manager) deleteMeasurements(ctx context.Context, e2NodeID topoapi.ID) error {
+	result, err := m.measurementStore.Query(ctx, measurements.Query{
+		NodeIDs: []string{string(e2NodeID)},
+	})
+	if err != nil {
+		return err
+	}
+	for _, entry := range result.Entries {
+		err = m.measurementStore.Delete(ctx, entry.Key)
+		if err != nil {
+			log.Warn(err)
+		}
+	}
+	return nil
+}
@@ -6,54 +6,77 @@ package actions
 
 import (
 	"context"
-	"sync"
 
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 
-	"github.com/onosproject/onos-kpimon/pkg/store/watcher"
+	"github.com/onosproject/onos-kpimon/pkg/store/generic"
 )
 
 // Store kpm action definitions  store interface
 type Store interface {
-	Put(ctx context.Context, key Key, value interface{}) (*Entry, error)
+	Put(ctx context.Context, key Key, value *e2smkpmv2.E2SmKpmActionDefinitionFormat1) (*Entry, error)
 
 	// Get gets a metric store entry based on a given key
 	Get(ctx context.Context, key Key) (*Entry, error)
+
+	// Snapshot gets a consistent copy of all of the store entries
+	Snapshot(ctx context.Context) ([]*Entry, error)
+
+	// Restore replaces all of the store entries with the given entries
+	Restore(ctx context.Context, entries []*Entry) error
 }
 
 type store struct {
-	actions  map[Key]*Entry
-	mu       sync.RWMutex
-	watchers *watcher.Watchers
+	actions generic.Store[Key, *Entry]
 }
 
 // NewStore creates new store
 func NewStore() Store {
-	watchers := watcher.NewWatchers()
 	return &store{
-		actions:  make(map[Key]*Entry),
-		watchers: watchers,
+		actions: generic.NewStore[Key, *Entry](),
 	}
 }
 
-func (s *store) Put(_ context.Context, key Key, value interface{}) (*Entry, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+func (s *store) Put(ctx context.Context, key Key, value *e2smkpmv2.E2SmKpmActionDefinitionFormat1) (*Entry, error) {
 	entry := &Entry{
 		Key:   key,
 		Value: value,
 	}
-	s.actions[key] = entry
+	err := s.actions.Put(ctx, key, entry)
+	if err != nil {
+		return nil, err
+	}
 	return entry, nil
 }
 
-func (s *store) Get(_ context.Context, key Key) (*Entry, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	if v, ok := s.actions[key]; ok {
-		return v, nil
+func (s *store) Get(ctx context.Context, key Key) (*Entry, error) {
+	entry, err := s.actions.Get(ctx, key)
+	if err != nil {
+		return nil, errors.New(errors.NotFound, "the cell entry does not exist")
 	}
-	return nil, errors.New(errors.NotFound, "the cell entry does not exist")
+	return entry, nil
+}
+
+func (s *store) Snapshot(ctx context.Context) ([]*Entry, error) {
+	return s.actions.List(ctx)
+}
+
+func (s *store) Restore(ctx context.Context, entries []*Entry) error {
+	return s.actions.Update(ctx, func(tx generic.Tx[Key, *Entry]) error {
+		keys := make([]Key, 0, tx.Len())
+		tx.Range(func(key Key, _ *Entry) bool {
+			keys = append(keys, key)
+			return true
+		})
+		for _, key := range keys {
+			tx.Delete(key)
+		}
+		for _, entry := range entries {
+			tx.Put(entry.Key, entry)
+		}
+		return nil
+	})
 }
 
 // NewKey creates a new key
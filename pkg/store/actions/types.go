@@ -4,6 +4,10 @@
 
 package actions
 
+import (
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+)
+
 // CellIdentity is the ID for each cell
 type CellIdentity struct {
 	CellID string
@@ -22,5 +26,5 @@ type Key struct {
 // Entry store entry
 type Entry struct {
 	Key   Key
-	Value interface{}
+	Value *e2smkpmv2.E2SmKpmActionDefinitionFormat1
 }
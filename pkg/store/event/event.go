@@ -4,9 +4,33 @@
 
 package event
 
+// Type store event type
+type Type int
+
+const (
+	// None none event, used to replay the existing entries
+	None Type = iota
+	// Created created entry event
+	Created
+	// Updated updated entry event
+	Updated
+	// Deleted deleted entry event
+	Deleted
+	// Resync resync required event, sent to a resuming watcher whose missed events are no longer buffered;
+	// the current entries follow as None events
+	Resync
+)
+
+func (t Type) String() string {
+	return [...]string{"None", "Created", "Updated", "Deleted", "Resync"}[t]
+}
+
 // Event store event data structure
-type Event struct {
-	Key   interface{}
-	Value interface{}
-	Type  interface{}
+// Sequence is the monotonic sequence number of the store change; the replayed None events and the
+// Resync events carry the sequence number of the last change
+type Event[K comparable, V any] struct {
+	Key      K
+	Value    V
+	Type     Type
+	Sequence uint64
 }
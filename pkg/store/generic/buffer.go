@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package generic
+
+import (
+	"github.com/onosproject/onos-kpimon/pkg/store/event"
+)
+
+// replayBuffer is a ring buffer of the latest store events, used to resume the watches
+type replayBuffer[K comparable, V any] struct {
+	events []event.Event[K, V]
+	// head is the index of the oldest event
+	head int
+	len  int
+}
+
+func newReplayBuffer[K comparable, V any](size int) *replayBuffer[K, V] {
+	return &replayBuffer[K, V]{
+		events: make([]event.Event[K, V], size),
+	}
+}
+
+// add adds an event, overwriting the oldest one once the buffer is full
+func (b *replayBuffer[K, V]) add(e event.Event[K, V]) {
+	if len(b.events) == 0 {
+		return
+	}
+	if b.len < len(b.events) {
+		b.events[(b.head+b.len)%len(b.events)] = e
+		b.len++
+		return
+	}
+	b.events[b.head] = e
+	b.head = (b.head + 1) % len(b.events)
+}
+
+// since gets the events following the given sequence number, or false if some of them are no longer buffered
+// or the sequence number is ahead of the last one, e.g. after a restart
+func (b *replayBuffer[K, V]) since(sequence uint64, last uint64) ([]event.Event[K, V], bool) {
+	if sequence > last {
+		return nil, false
+	}
+	if sequence == last {
+		return nil, true
+	}
+	if b.len == 0 || b.events[b.head].Sequence > sequence+1 {
+		return nil, false
+	}
+	events := make([]event.Event[K, V], 0, last-sequence)
+	for i := 0; i < b.len; i++ {
+		e := b.events[(b.head+i)%len(b.events)]
+		if e.Sequence > sequence {
+			events = append(events, e)
+		}
+	}
+	return events, true
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package generic
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func sequences(events []event.Event[string, int]) []uint64 {
+	result := make([]uint64, 0, len(events))
+	for _, e := range events {
+		result = append(result, e.Sequence)
+	}
+	return result
+}
+
+func TestReplayBuffer(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int
+		added     uint64
+		since     uint64
+		sequences []uint64
+		ok        bool
+	}{
+		{
+			name:      "not full",
+			size:      3,
+			added:     2,
+			since:     0,
+			sequences: []uint64{1, 2},
+			ok:        true,
+		},
+		{
+			name:      "wrapped around",
+			size:      3,
+			added:     5,
+			since:     2,
+			sequences: []uint64{3, 4, 5},
+			ok:        true,
+		},
+		{
+			name:      "up to date",
+			size:      3,
+			added:     5,
+			since:     5,
+			sequences: []uint64{},
+			ok:        true,
+		},
+		{
+			name:  "no longer buffered",
+			size:  3,
+			added: 5,
+			since: 1,
+		},
+		{
+			name:  "ahead of the last event",
+			size:  3,
+			added: 5,
+			since: 6,
+		},
+		{
+			name:  "no buffer",
+			size:  0,
+			added: 5,
+			since: 4,
+		},
+		{
+			name:      "no buffer up to date",
+			size:      0,
+			added:     5,
+			since:     5,
+			sequences: []uint64{},
+			ok:        true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := newReplayBuffer[string, int](test.size)
+			for sequence := uint64(1); sequence <= test.added; sequence++ {
+				b.add(event.Event[string, int]{Sequence: sequence})
+			}
+			events, ok := b.since(test.since, test.added)
+			assert.Equal(t, test.ok, ok)
+			if test.ok {
+				assert.Equal(t, test.sequences, sequences(events))
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package generic
+
+import (
+	"github.com/onosproject/onos-kpimon/pkg/store/watcher"
+)
+
+// Hooks are called with the store lock held whenever an entry is inserted or removed,
+// which lets a store built on top of this one maintain derived state such as indexes.
+// An update calls Removed with the old value and then Inserted with the new one.
+type Hooks[K comparable, V any] struct {
+	Inserted func(key K, value V)
+	Removed  func(key K, value V)
+}
+
+func (h Hooks[K, V]) inserted(key K, value V) {
+	if h.Inserted != nil {
+		h.Inserted(key, value)
+	}
+}
+
+func (h Hooks[K, V]) removed(key K, value V) {
+	if h.Removed != nil {
+		h.Removed(key, value)
+	}
+}
+
+// Options store options
+type Options[K comparable, V any] struct {
+	Hooks Hooks[K, V]
+
+	WatcherOptions []watcher.Option
+
+	// ReplayBufferSize is the number of latest events kept to resume the watches; zero disables the resumption
+	ReplayBufferSize int
+}
+
+// Option store option interface
+type Option[K comparable, V any] interface {
+	apply(*Options[K, V])
+}
+
+type funcOption[K comparable, V any] struct {
+	f func(*Options[K, V])
+}
+
+func (f funcOption[K, V]) apply(options *Options[K, V]) {
+	f.f(options)
+}
+
+func newOption[K comparable, V any](f func(*Options[K, V])) Option[K, V] {
+	return funcOption[K, V]{
+		f: f,
+	}
+}
+
+// WithHooks sets the hooks called when an entry is inserted or removed
+func WithHooks[K comparable, V any](hooks Hooks[K, V]) Option[K, V] {
+	return newOption(func(options *Options[K, V]) {
+		options.Hooks = hooks
+	})
+}
+
+// WithWatcherOptions sets the options of the store watchers
+func WithWatcherOptions[K comparable, V any](opts ...watcher.Option) Option[K, V] {
+	return newOption(func(options *Options[K, V]) {
+		options.WatcherOptions = opts
+	})
+}
+
+// WithReplayBufferSize sets the number of latest events kept to resume the watches
+func WithReplayBufferSize[K comparable, V any](size int) Option[K, V] {
+	return newOption(func(options *Options[K, V]) {
+		options.ReplayBufferSize = size
+	})
+}
+
+// WatchOptions store watch options
+type WatchOptions struct {
+	// Replay sends the current entries to the watcher before any live event
+	Replay bool
+	// Resume sends the buffered events following ResumeFrom to the watcher before any live event
+	Resume bool
+	// ResumeFrom is the sequence number of the last event received by the resuming watcher
+	ResumeFrom uint64
+	// Policy is applied when the queue of the watcher is full instead of the policy of the store; nil applies the policy of the store
+	Policy *watcher.SlowConsumerPolicy
+}
+
+// WatchOption watch option interface
+type WatchOption interface {
+	apply(*WatchOptions)
+}
+
+type funcWatchOption struct {
+	f func(*WatchOptions)
+}
+
+func (f funcWatchOption) apply(options *WatchOptions) {
+	f.f(options)
+}
+
+func newWatchOption(f func(*WatchOptions)) WatchOption {
+	return funcWatchOption{
+		f: f,
+	}
+}
+
+// WithReplay replays the current entries as None events before switching to live events
+func WithReplay() WatchOption {
+	return newWatchOption(func(options *WatchOptions) {
+		options.Replay = true
+	})
+}
+
+// WithResumeFrom resumes a watch after the event with the given sequence number
+// The missed events are replayed from the replay buffer; if some of them are no longer buffered,
+// a Resync event is sent followed by the current entries as None events.
+func WithResumeFrom(sequence uint64) WatchOption {
+	return newWatchOption(func(options *WatchOptions) {
+		options.Resume = true
+		options.ResumeFrom = sequence
+	})
+}
+
+// WithSlowConsumerPolicy sets the policy applied when the queue of the watcher is full, e.g. Disconnect for a watch
+// whose sequence numbers are used as resume points, since a dropped event would be skipped by the resumed watch
+func WithSlowConsumerPolicy(policy watcher.SlowConsumerPolicy) WatchOption {
+	return newWatchOption(func(options *WatchOptions) {
+		options.Policy = &policy
+	})
+}
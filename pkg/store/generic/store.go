@@ -0,0 +1,312 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package generic
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/onosproject/onos-kpimon/pkg/store/event"
+	"github.com/onosproject/onos-kpimon/pkg/store/watcher"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+)
+
+var log = logging.GetLogger()
+
+// Store is a typed key-value store whose changes can be watched
+type Store[K comparable, V any] interface {
+	// Put puts a store entry
+	Put(ctx context.Context, key K, value V) error
+
+	// Get gets a store entry based on a given key
+	Get(ctx context.Context, key K) (V, error)
+
+	// Delete deletes an entry based on a given key
+	Delete(ctx context.Context, key K) error
+
+	// List lists all of the store entries
+	List(ctx context.Context) ([]V, error)
+
+	// Watch watches the store changes
+	// With the WithReplay option, the current entries are sent first as None events and
+	// then the live events follow without gaps or duplicates.
+	// With the WithResumeFrom option, the events missed since the given sequence number are sent first.
+	Watch(ctx context.Context, ch chan<- event.Event[K, V], opts ...WatchOption) error
+
+	// View calls a function with a read-only view of the store
+	View(ctx context.Context, f func(tx ReadTx[K, V]) error) error
+
+	// Update calls a function with a read-write view of the store; the changes are applied atomically
+	// with respect to the other store operations and an event is sent for each of them once the function returns.
+	// If the function returns an error, its changes are rolled back and no event is sent.
+	Update(ctx context.Context, f func(tx Tx[K, V]) error) error
+
+	// DroppedEvents returns the number of events dropped for the slow watchers
+	DroppedEvents() uint64
+}
+
+// ReadTx is a read-only view of the store
+type ReadTx[K comparable, V any] interface {
+	// Get gets a store entry based on a given key
+	Get(key K) (V, bool)
+
+	// Range calls a function for each of the store entries until it returns false
+	Range(f func(key K, value V) bool)
+
+	// Len returns the number of store entries
+	Len() int
+}
+
+// Tx is a read-write view of the store
+type Tx[K comparable, V any] interface {
+	ReadTx[K, V]
+
+	// Put puts a store entry
+	Put(key K, value V)
+
+	// Delete deletes an entry based on a given key; it returns false if the entry does not exist
+	Delete(key K) bool
+}
+
+type store[K comparable, V any] struct {
+	entries  map[K]V
+	options  Options[K, V]
+	mu       sync.RWMutex
+	watchers *watcher.Watchers[event.Event[K, V]]
+	// sequence is the sequence number of the last event
+	sequence uint64
+	buffer   *replayBuffer[K, V]
+}
+
+// NewStore creates a new typed store
+func NewStore[K comparable, V any](opts ...Option[K, V]) Store[K, V] {
+	options := Options[K, V]{}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+
+	return &store[K, V]{
+		entries:  make(map[K]V),
+		options:  options,
+		watchers: watcher.NewWatchers[event.Event[K, V]](options.WatcherOptions...),
+		buffer:   newReplayBuffer[K, V](options.ReplayBufferSize),
+	}
+}
+
+func (s *store[K, V]) Put(ctx context.Context, key K, value V) error {
+	return s.Update(ctx, func(tx Tx[K, V]) error {
+		tx.Put(key, value)
+		return nil
+	})
+}
+
+func (s *store[K, V]) Get(_ context.Context, key K) (V, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if v, ok := s.entries[key]; ok {
+		return v, nil
+	}
+	var empty V
+	return empty, errors.NewNotFound("the entry %v does not exist", key)
+}
+
+func (s *store[K, V]) Delete(ctx context.Context, key K) error {
+	return s.Update(ctx, func(tx Tx[K, V]) error {
+		tx.Delete(key)
+		return nil
+	})
+}
+
+func (s *store[K, V]) List(_ context.Context) ([]V, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	values := make([]V, 0, len(s.entries))
+	for _, value := range s.entries {
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (s *store[K, V]) View(_ context.Context, f func(tx ReadTx[K, V]) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return f(&tx[K, V]{store: s})
+}
+
+func (s *store[K, V]) Update(_ context.Context, f func(tx Tx[K, V]) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := &tx[K, V]{store: s}
+	if err := f(t); err != nil {
+		t.rollback()
+		return err
+	}
+	for _, e := range t.events {
+		s.send(e)
+	}
+	return nil
+}
+
+func (s *store[K, V]) Watch(ctx context.Context, ch chan<- event.Event[K, V], opts ...WatchOption) error {
+	options := WatchOptions{}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+
+	id := uuid.New()
+	err := s.addWatcher(id, ch, options)
+	if err != nil {
+		log.Error(err)
+		close(ch)
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		// the watcher may have already been disconnected as a slow consumer
+		err := s.watchers.RemoveWatcher(id)
+		if err != nil && !errors.IsNotFound(err) {
+			log.Error(err)
+		}
+	}()
+	return nil
+}
+
+func (s *store[K, V]) DroppedEvents() uint64 {
+	return s.watchers.DroppedEvents()
+}
+
+// addWatcher registers a watcher while holding the store lock, so that no entry
+// can be put between the replay snapshot and the first live event
+func (s *store[K, V]) addWatcher(id uuid.UUID, ch chan<- event.Event[K, V], options WatchOptions) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var replay []event.Event[K, V]
+	if options.Resume {
+		events, ok := s.buffer.since(options.ResumeFrom, s.sequence)
+		if ok {
+			replay = events
+		} else {
+			replay = append([]event.Event[K, V]{{
+				Type:     event.Resync,
+				Sequence: s.sequence,
+			}}, s.replay()...)
+		}
+	} else if options.Replay {
+		replay = s.replay()
+	}
+	if options.Policy != nil {
+		return s.watchers.AddWatcherWithPolicy(id, ch, *options.Policy, replay...)
+	}
+	return s.watchers.AddWatcher(id, ch, replay...)
+}
+
+// replay gets the current entries as None events
+func (s *store[K, V]) replay() []event.Event[K, V] {
+	replay := make([]event.Event[K, V], 0, len(s.entries))
+	for key, value := range s.entries {
+		replay = append(replay, event.Event[K, V]{
+			Key:      key,
+			Value:    value,
+			Type:     event.None,
+			Sequence: s.sequence,
+		})
+	}
+	return replay
+}
+
+// send numbers an event, buffers it and sends it to the watchers while holding the store lock
+func (s *store[K, V]) send(e event.Event[K, V]) {
+	s.sequence++
+	e.Sequence = s.sequence
+	s.buffer.add(e)
+	s.watchers.Send(e)
+}
+
+// tx is a view of the store used while holding the store lock
+// Its changes are applied to the entries right away, so that it reads its own changes, and are logged
+// to be rolled back if the transaction fails; their events are only sent once it succeeds.
+type tx[K comparable, V any] struct {
+	store   *store[K, V]
+	changes []change[K, V]
+	events  []event.Event[K, V]
+}
+
+// change is the previous value of an entry changed by a transaction
+type change[K comparable, V any] struct {
+	key     K
+	value   V
+	existed bool
+}
+
+func (t *tx[K, V]) Get(key K) (V, bool) {
+	value, ok := t.store.entries[key]
+	return value, ok
+}
+
+func (t *tx[K, V]) Range(f func(key K, value V) bool) {
+	for key, value := range t.store.entries {
+		if !f(key, value) {
+			return
+		}
+	}
+}
+
+func (t *tx[K, V]) Len() int {
+	return len(t.store.entries)
+}
+
+func (t *tx[K, V]) Put(key K, value V) {
+	eventType := event.Created
+	old, ok := t.store.entries[key]
+	if ok {
+		t.store.options.Hooks.removed(key, old)
+		eventType = event.Updated
+	}
+	t.changes = append(t.changes, change[K, V]{key: key, value: old, existed: ok})
+	t.store.entries[key] = value
+	t.store.options.Hooks.inserted(key, value)
+	t.events = append(t.events, event.Event[K, V]{
+		Key:   key,
+		Value: value,
+		Type:  eventType,
+	})
+}
+
+func (t *tx[K, V]) Delete(key K) bool {
+	value, ok := t.store.entries[key]
+	if !ok {
+		return false
+	}
+	t.changes = append(t.changes, change[K, V]{key: key, value: value, existed: true})
+	delete(t.store.entries, key)
+	t.store.options.Hooks.removed(key, value)
+	t.events = append(t.events, event.Event[K, V]{
+		Key:   key,
+		Value: value,
+		Type:  event.Deleted,
+	})
+	return true
+}
+
+// rollback restores the entries changed by the transaction, latest change first, and discards its events
+func (t *tx[K, V]) rollback() {
+	for i := len(t.changes) - 1; i >= 0; i-- {
+		c := t.changes[i]
+		if value, ok := t.store.entries[c.key]; ok {
+			delete(t.store.entries, c.key)
+			t.store.options.Hooks.removed(c.key, value)
+		}
+		if c.existed {
+			t.store.entries[c.key] = c.value
+			t.store.options.Hooks.inserted(c.key, c.value)
+		}
+	}
+	t.changes = nil
+	t.events = nil
+}
+
+var _ Store[string, string] = &store[string, string]{}
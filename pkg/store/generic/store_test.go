@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package generic
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/event"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func nextEvent(t *testing.T, ch <-chan event.Event[string, int]) event.Event[string, int] {
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return event.Event[string, int]{}
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore[string, int]()
+
+	_, err := s.Get(ctx, "a")
+	assert.True(t, errors.IsNotFound(err))
+
+	assert.NoError(t, s.Put(ctx, "a", 1))
+	assert.NoError(t, s.Put(ctx, "b", 2))
+	value, err := s.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, value)
+
+	values, err := s.List(ctx)
+	assert.NoError(t, err)
+	sort.Ints(values)
+	assert.Equal(t, []int{1, 2}, values)
+
+	assert.NoError(t, s.Delete(ctx, "a"))
+	_, err = s.Get(ctx, "a")
+	assert.True(t, errors.IsNotFound(err))
+	// deleting a missing entry is not an error
+	assert.NoError(t, s.Delete(ctx, "a"))
+}
+
+func TestHooks(t *testing.T) {
+	ctx := context.Background()
+	var inserted, removed []int
+	s := NewStore[string, int](WithHooks(Hooks[string, int]{
+		Inserted: func(_ string, value int) {
+			inserted = append(inserted, value)
+		},
+		Removed: func(_ string, value int) {
+			removed = append(removed, value)
+		},
+	}))
+
+	tests := []struct {
+		name     string
+		update   func() error
+		inserted []int
+		removed  []int
+	}{
+		{
+			name:     "create",
+			update:   func() error { return s.Put(ctx, "a", 1) },
+			inserted: []int{1},
+		},
+		{
+			name:     "update removes the old value first",
+			update:   func() error { return s.Put(ctx, "a", 2) },
+			inserted: []int{2},
+			removed:  []int{1},
+		},
+		{
+			name:    "delete",
+			update:  func() error { return s.Delete(ctx, "a") },
+			removed: []int{2},
+		},
+		{
+			name:   "delete a missing entry",
+			update: func() error { return s.Delete(ctx, "a") },
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			inserted, removed = nil, nil
+			assert.NoError(t, test.update())
+			assert.Equal(t, test.inserted, inserted)
+			assert.Equal(t, test.removed, removed)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := NewStore[string, int]()
+	assert.NoError(t, s.Put(ctx, "a", 1))
+
+	ch := make(chan event.Event[string, int])
+	assert.NoError(t, s.Watch(ctx, ch))
+
+	// the changes of a transaction are applied at once and an event is sent for each of them
+	err := s.Update(ctx, func(tx Tx[string, int]) error {
+		value, ok := tx.Get("a")
+		assert.True(t, ok)
+		tx.Put("b", value+1)
+		assert.True(t, tx.Delete("a"))
+		assert.False(t, tx.Delete("c"))
+		assert.Equal(t, 1, tx.Len())
+		return nil
+	})
+	assert.NoError(t, err)
+
+	e := nextEvent(t, ch)
+	assert.Equal(t, event.Created, e.Type)
+	assert.Equal(t, "b", e.Key)
+	assert.Equal(t, 2, e.Value)
+	e = nextEvent(t, ch)
+	assert.Equal(t, event.Deleted, e.Type)
+	assert.Equal(t, "a", e.Key)
+	assert.Equal(t, 1, e.Value)
+
+	err = s.View(ctx, func(tx ReadTx[string, int]) error {
+		keys := make([]string, 0)
+		tx.Range(func(key string, _ int) bool {
+			keys = append(keys, key)
+			return true
+		})
+		assert.Equal(t, []string{"b"}, keys)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	// the error of a transaction is returned
+	err = s.Update(ctx, func(tx Tx[string, int]) error {
+		return errors.NewInvalid("invalid")
+	})
+	assert.True(t, errors.IsInvalid(err))
+}
+
+func TestUpdateRollback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	values := make(map[string]int)
+	s := NewStore[string, int](WithHooks(Hooks[string, int]{
+		Inserted: func(key string, value int) {
+			values[key] = value
+		},
+		Removed: func(key string, _ int) {
+			delete(values, key)
+		},
+	}))
+	assert.NoError(t, s.Put(ctx, "a", 1))
+	assert.NoError(t, s.Put(ctx, "b", 2))
+
+	ch := make(chan event.Event[string, int], 10)
+	assert.NoError(t, s.Watch(ctx, ch))
+
+	// the changes of a failed transaction are seen by the transaction only and rolled back with the hooks
+	err := s.Update(ctx, func(tx Tx[string, int]) error {
+		tx.Put("a", 10)
+		tx.Put("a", 11)
+		tx.Put("c", 3)
+		assert.True(t, tx.Delete("b"))
+		value, ok := tx.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 11, value)
+		_, ok = tx.Get("b")
+		assert.False(t, ok)
+		return errors.NewInvalid("invalid")
+	})
+	assert.True(t, errors.IsInvalid(err))
+
+	listed, err := s.List(ctx)
+	assert.NoError(t, err)
+	sort.Ints(listed)
+	assert.Equal(t, []int{1, 2}, listed)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, values)
+
+	// no event is sent for the rolled back changes
+	assert.NoError(t, s.Put(ctx, "d", 4))
+	e := nextEvent(t, ch)
+	assert.Equal(t, event.Created, e.Type)
+	assert.Equal(t, "d", e.Key)
+	assert.Equal(t, uint64(3), e.Sequence)
+}
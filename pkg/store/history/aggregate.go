@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package history
+
+import (
+	"math"
+	"sort"
+
+	valueutils "github.com/onosproject/onos-kpimon/pkg/utils/values"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// MaxPoints is the maximum number of points of a range query series
+const MaxPoints = 11000
+
+// Function is an aggregate function of the samples of a step
+type Function int
+
+const (
+	// Avg averages the samples
+	Avg Function = iota
+	// Min gets the minimum sample
+	Min
+	// Max gets the maximum sample
+	Max
+	// Sum sums the samples
+	Sum
+	// Last gets the latest sample
+	Last
+	// Percentile gets a percentile of the samples
+	Percentile
+)
+
+func (f Function) String() string {
+	return [...]string{"avg", "min", "max", "sum", "last", "percentile"}[f]
+}
+
+// Range is the time range and the step of a range query
+type Range struct {
+	// Start is the inclusive start of the range in nanoseconds
+	Start uint64
+	// End is the exclusive end of the range in nanoseconds
+	End uint64
+	// Step is the duration of the points in nanoseconds
+	Step uint64
+}
+
+// Validate checks the range is bounded and does not have more than MaxPoints points
+func (r Range) Validate() error {
+	if r.Step == 0 {
+		return errors.NewInvalid("range step must be set")
+	}
+	if r.Start == 0 || r.End <= r.Start {
+		return errors.NewInvalid("range start must be set and before its end")
+	}
+	if (r.End-r.Start-1)/r.Step+1 > MaxPoints {
+		return errors.NewInvalid("range exceeds %d points, increase the step", MaxPoints)
+	}
+	return nil
+}
+
+// Point is an aggregate of the samples of a step
+type Point struct {
+	// Timestamp is the start of the step in nanoseconds
+	Timestamp uint64
+	Value     float64
+	// Count is the number of aggregated samples
+	Count int
+}
+
+// Aggregate aggregates the samples of a series per step of a range
+// The steps start at the start of the range and the steps without samples have no point.
+// The samples without value are skipped and the percentile, from 0 to 100, is only used by the Percentile function.
+func Aggregate(samples []Sample, r Range, function Function, percentile float64) []Point {
+	points := make([]Point, 0)
+	var values []float64
+	var bucket uint64
+	flush := func() {
+		if len(values) > 0 {
+			points = append(points, Point{
+				Timestamp: r.Start + bucket*r.Step,
+				Value:     aggregate(values, function, percentile),
+				Count:     len(values),
+			})
+		}
+		values = values[:0]
+	}
+
+	for _, sample := range samples {
+		if sample.Timestamp < r.Start || sample.Timestamp >= r.End {
+			continue
+		}
+		value, ok := valueutils.ToFloat64(sample.Value)
+		if !ok {
+			continue
+		}
+		b := (sample.Timestamp - r.Start) / r.Step
+		if b != bucket {
+			flush()
+			bucket = b
+		}
+		values = append(values, value)
+	}
+	flush()
+	return points
+}
+
+// aggregate aggregates the values of a step, ordered by timestamp
+func aggregate(values []float64, function Function, percentile float64) float64 {
+	switch function {
+	case Min:
+		return valueutils.Min(values)
+	case Max:
+		return valueutils.Max(values)
+	case Sum:
+		return valueutils.Sum(values)
+	case Avg:
+		return valueutils.Avg(values)
+	case Last:
+		return values[len(values)-1]
+	case Percentile:
+		return percentileOf(values, percentile)
+	default:
+		return math.NaN()
+	}
+}
+
+// percentileOf interpolates linearly between the closest ranks
+func percentileOf(values []float64, percentile float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := percentile / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package history keeps the time series of the measurement records received over time
+package history
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	valueutils "github.com/onosproject/onos-kpimon/pkg/utils/values"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+)
+
+var log = logging.GetLogger()
+
+// Store is a store of the time series of the measurement records
+type Store interface {
+	// Add appends the records of a measurement entry to the series of its cell
+	// The records that are not newer than the latest sample of their series are skipped.
+	Add(ctx context.Context, entry *measurements.Entry) error
+
+	// Query gets the series matching the cell and measurement name filters of a query, with the samples
+	// in the time range of the query, ordered by node ID, cell object ID and series key
+	Query(ctx context.Context, query measurements.Query) ([]*Series, error)
+}
+
+// SeriesKey identifies a series
+type SeriesKey struct {
+	Key measurements.Key
+	// ID is the series key of the records of the series
+	ID string
+}
+
+// Series is the time series of the records of a measurement of a cell, per label set
+type Series struct {
+	Key          SeriesKey
+	CellGlobalID string
+	PlmnID       uint32
+	Name         string
+	Labels       map[string]string
+	// Samples are ordered by timestamp
+	Samples []Sample
+}
+
+// Sample is a value of a series
+type Sample struct {
+	// Timestamp is the start of the granularity period of the record in nanoseconds
+	Timestamp uint64
+	// Value is an int64, a float64 or an int32 no value
+	Value interface{}
+}
+
+// NewStore creates a new history store
+func NewStore(opts ...Option) Store {
+	options := Options{}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	return &store{
+		series:  make(map[SeriesKey]*Series),
+		options: options,
+	}
+}
+
+// expiryInterval is the minimum interval between two passes over the series to drop the expired samples
+const expiryInterval = time.Second
+
+type store struct {
+	mu         sync.RWMutex
+	series     map[SeriesKey]*Series
+	options    Options
+	lastExpiry time.Time
+}
+
+func (s *store) Add(_ context.Context, entry *measurements.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the records of an entry are ordered by granularity period
+	records := make([]measurements.MeasurementRecord, 0)
+	for _, measItem := range entry.Value {
+		records = append(records, measItem.MeasurementRecords...)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp < records[j].Timestamp
+	})
+
+	updated := make(map[SeriesKey]*Series)
+	for _, record := range records {
+		key := SeriesKey{
+			Key: entry.Key,
+			ID:  record.SeriesKey(),
+		}
+		series, ok := s.series[key]
+		if !ok {
+			series = &Series{
+				Key:    key,
+				Name:   record.MeasurementName,
+				Labels: record.Labels,
+			}
+			s.series[key] = series
+		}
+		series.CellGlobalID = entry.CellGlobalID
+		series.PlmnID = entry.PlmnID
+		if n := len(series.Samples); n > 0 && series.Samples[n-1].Timestamp >= record.Timestamp {
+			continue
+		}
+		series.Samples = append(series.Samples, Sample{
+			Timestamp: record.Timestamp,
+			Value:     record.MeasurementValue,
+		})
+		updated[key] = series
+	}
+
+	for _, series := range updated {
+		s.trim(series)
+	}
+	s.expire()
+	return nil
+}
+
+// trim drops the oldest samples of a series beyond the maximum number of samples; it must be called with the lock held
+func (s *store) trim(series *Series) {
+	if s.options.MaxSamples > 0 && len(series.Samples) > s.options.MaxSamples {
+		series.Samples = append([]Sample(nil), series.Samples[len(series.Samples)-s.options.MaxSamples:]...)
+	}
+}
+
+// expire drops the samples older than the retention and the series left empty; it must be called with the lock held
+func (s *store) expire() {
+	now := time.Now()
+	if s.options.Retention <= 0 || now.Sub(s.lastExpiry) < expiryInterval {
+		return
+	}
+	s.lastExpiry = now
+	cutoff := uint64(now.Add(-s.options.Retention).UnixNano())
+	for key, series := range s.series {
+		i := sort.Search(len(series.Samples), func(i int) bool {
+			return series.Samples[i].Timestamp >= cutoff
+		})
+		if i == len(series.Samples) {
+			delete(s.series, key)
+			continue
+		}
+		if i > 0 {
+			series.Samples = append([]Sample(nil), series.Samples[i:]...)
+		}
+	}
+}
+
+func (s *store) Query(_ context.Context, query measurements.Query) ([]*Series, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*Series, 0)
+	for _, series := range s.series {
+		if !query.MatchKey(&measurements.Entry{
+			Key:          series.Key.Key,
+			CellGlobalID: series.CellGlobalID,
+			PlmnID:       series.PlmnID,
+		}) {
+			continue
+		}
+		if len(query.MeasurementNames) > 0 && !valueutils.Contains(query.MeasurementNames, series.Name) {
+			continue
+		}
+
+		start := 0
+		if query.StartTime != 0 {
+			start = sort.Search(len(series.Samples), func(i int) bool {
+				return series.Samples[i].Timestamp >= query.StartTime
+			})
+		}
+		end := len(series.Samples)
+		if query.EndTime != 0 {
+			end = sort.Search(len(series.Samples), func(i int) bool {
+				return series.Samples[i].Timestamp >= query.EndTime
+			})
+		}
+		if start >= end {
+			continue
+		}
+
+		matched := *series
+		matched.Samples = append([]Sample(nil), series.Samples[start:end]...)
+		result = append(result, &matched)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i].Key, result[j].Key
+		if a.Key.NodeID != b.Key.NodeID {
+			return a.Key.NodeID < b.Key.NodeID
+		}
+		if a.Key.CellIdentity.CellID != b.Key.CellIdentity.CellID {
+			return a.Key.CellIdentity.CellID < b.Key.CellIdentity.CellID
+		}
+		return a.ID < b.ID
+	})
+	return result, nil
+}
+
+// Record adds the entries put to a measurement store to a history store until the context is done
+func Record(ctx context.Context, measStore measurements.Store, historyStore Store) error {
+	ch := make(chan measurements.Event)
+	err := measStore.Watch(ctx, ch)
+	if err != nil {
+		return err
+	}
+	go func() {
+		for e := range ch {
+			if e.Type != measurements.Created && e.Type != measurements.Updated {
+				continue
+			}
+			err := historyStore.Add(ctx, e.Value)
+			if err != nil {
+				log.Warn(err)
+			}
+		}
+	}()
+	return nil
+}
+
+var _ Store = &store{}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package history
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEntry(cellID string, records ...measurements.MeasurementRecord) *measurements.Entry {
+	return &measurements.Entry{
+		Key:          measurements.NewKey(measurements.CellIdentity{CellID: cellID}, "e2:1"),
+		Value:        []measurements.MeasurementItem{{MeasurementRecords: records}},
+		CellGlobalID: "cgi-" + cellID,
+	}
+}
+
+func newTestRecord(timestamp uint64, name string, value interface{}) measurements.MeasurementRecord {
+	return measurements.MeasurementRecord{
+		Timestamp:        timestamp,
+		MeasurementName:  name,
+		MeasurementValue: value,
+	}
+}
+
+func TestAddQuery(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore(WithMaxSamples(3))
+	assert.NoError(t, s.Add(ctx, newTestEntry("1",
+		newTestRecord(2, "A", int64(2)),
+		newTestRecord(1, "A", int64(1)),
+		newTestRecord(1, "B", 1.5))))
+	// the records that are not newer than the latest sample are skipped
+	assert.NoError(t, s.Add(ctx, newTestEntry("1",
+		newTestRecord(2, "A", int64(20)),
+		newTestRecord(3, "A", int64(3)),
+		newTestRecord(4, "A", int64(4)))))
+	assert.NoError(t, s.Add(ctx, newTestEntry("2", newTestRecord(1, "A", int64(5)))))
+
+	tests := []struct {
+		name    string
+		query   measurements.Query
+		samples [][]Sample
+	}{
+		{
+			name:  "all series",
+			query: measurements.Query{},
+			samples: [][]Sample{
+				// the oldest samples beyond the maximum are trimmed
+				{{2, int64(2)}, {3, int64(3)}, {4, int64(4)}},
+				{{1, 1.5}},
+				{{1, int64(5)}},
+			},
+		},
+		{
+			name:    "measurement name and cell",
+			query:   measurements.Query{CellIDs: []string{"1"}, MeasurementNames: []string{"A"}},
+			samples: [][]Sample{{{2, int64(2)}, {3, int64(3)}, {4, int64(4)}}},
+		},
+		{
+			name:    "cell global ID",
+			query:   measurements.Query{CellGlobalIDs: []string{"cgi-2"}},
+			samples: [][]Sample{{{1, int64(5)}}},
+		},
+		{
+			name:    "time range",
+			query:   measurements.Query{MeasurementNames: []string{"A"}, StartTime: 3, EndTime: 4},
+			samples: [][]Sample{{{3, int64(3)}}},
+		},
+		{
+			name:  "no sample in range",
+			query: measurements.Query{StartTime: 10},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := s.Query(ctx, test.query)
+			assert.NoError(t, err)
+			assert.Len(t, result, len(test.samples))
+			for i, series := range result {
+				assert.Equal(t, test.samples[i], series.Samples)
+			}
+		})
+	}
+}
+
+func TestRetention(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore(WithRetention(time.Hour))
+	now := uint64(time.Now().UnixNano())
+	old := uint64(time.Now().Add(-2 * time.Hour).UnixNano())
+	assert.NoError(t, s.Add(ctx, newTestEntry("1", newTestRecord(old, "A", int64(1)), newTestRecord(now, "A", int64(2)))))
+	// the samples are expired at most once per expiry interval
+	assert.NoError(t, s.Add(ctx, newTestEntry("2", newTestRecord(old, "A", int64(3)))))
+	result, err := s.Query(ctx, measurements.Query{CellIDs: []string{"2"}})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	s.(*store).lastExpiry = time.Time{}
+	assert.NoError(t, s.Add(ctx, newTestEntry("1", newTestRecord(now+1, "A", int64(4)))))
+
+	result, err = s.Query(ctx, measurements.Query{})
+	assert.NoError(t, err)
+	// the expired samples are removed, and so are the series without samples
+	assert.Len(t, result, 1)
+	assert.Equal(t, []Sample{{now, int64(2)}, {now + 1, int64(4)}}, result[0].Samples)
+}
+
+func TestRangeValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		r     Range
+		valid bool
+	}{
+		{"valid", Range{Start: 1, End: 11, Step: 5}, true},
+		{"no step", Range{Start: 1, End: 11}, false},
+		{"no start", Range{End: 11, Step: 5}, false},
+		{"end before start", Range{Start: 11, End: 1, Step: 5}, false},
+		{"max points", Range{Start: 1, End: MaxPoints + 1, Step: 1}, true},
+		{"too many points", Range{Start: 1, End: MaxPoints + 2, Step: 1}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.r.Validate()
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.IsInvalid(err))
+			}
+		})
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	samples := []Sample{
+		{Timestamp: 5, Value: int64(100)},
+		{Timestamp: 10, Value: int64(1)},
+		{Timestamp: 12, Value: 3.0},
+		{Timestamp: 14, Value: int32(0)},
+		{Timestamp: 18, Value: int64(5)},
+		{Timestamp: 31, Value: int64(7)},
+		{Timestamp: 40, Value: int64(100)},
+	}
+	r := Range{Start: 10, End: 40, Step: 10}
+
+	tests := []struct {
+		name       string
+		function   Function
+		percentile float64
+		values     []float64
+	}{
+		{name: "avg", function: Avg, values: []float64{3, 7}},
+		{name: "min", function: Min, values: []float64{1, 7}},
+		{name: "max", function: Max, values: []float64{5, 7}},
+		{name: "sum", function: Sum, values: []float64{9, 7}},
+		{name: "last", function: Last, values: []float64{5, 7}},
+		{name: "median", function: Percentile, percentile: 50, values: []float64{3, 7}},
+		{name: "percentile", function: Percentile, percentile: 75, values: []float64{4, 7}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			points := Aggregate(samples, r, test.function, test.percentile)
+			// the samples out of range and without value are skipped and the empty steps have no point
+			assert.Len(t, points, 2)
+			for i, point := range points {
+				assert.Equal(t, test.values[i], point.Value)
+			}
+			assert.Equal(t, Point{Timestamp: 10, Value: points[0].Value, Count: 3}, points[0])
+			assert.Equal(t, Point{Timestamp: 30, Value: points[1].Value, Count: 1}, points[1])
+		})
+	}
+	assert.True(t, math.IsNaN(aggregate([]float64{1}, Function(-1), 0)))
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package history
+
+import "time"
+
+// Options history store options
+type Options struct {
+	// Retention is the duration the samples are kept for; zero means the samples are only bounded by MaxSamples
+	Retention time.Duration
+	// MaxSamples is the maximum number of samples of a series; zero means unbounded
+	MaxSamples int
+}
+
+// Option history store option interface
+type Option interface {
+	apply(*Options)
+}
+
+type funcOption struct {
+	f func(*Options)
+}
+
+func (f funcOption) apply(options *Options) {
+	f.f(options)
+}
+
+func newOption(f func(*Options)) Option {
+	return funcOption{
+		f: f,
+	}
+}
+
+// WithRetention sets the duration the samples are kept for
+func WithRetention(retention time.Duration) Option {
+	return newOption(func(options *Options) {
+		options.Retention = retention
+	})
+}
+
+// WithMaxSamples sets the maximum number of samples of a series
+func WithMaxSamples(maxSamples int) Option {
+	return newOption(func(options *Options) {
+		options.MaxSamples = maxSamples
+	})
+}
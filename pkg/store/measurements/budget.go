@@ -0,0 +1,243 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package measurements
+
+import (
+	"container/heap"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/generic"
+)
+
+const (
+	// entryOverhead and recordOverhead approximate the memory used by an entry and a record besides their strings
+	entryOverhead  = 160
+	recordOverhead = 64
+	// evictionTarget is the percentage of the budget the usage is brought back to, to avoid evicting on every put
+	evictionTarget = 90
+)
+
+// Usage measurement store usage
+type Usage struct {
+	// Entries is the number of stored entries
+	Entries int
+	// Records is the number of stored measurement records
+	Records int
+	// Bytes is the approximate memory used by the stored entries
+	Bytes int
+	// MaxRecords is the record budget, or zero if it is unbounded
+	MaxRecords int
+	// MaxBytes is the memory budget, or zero if it is unbounded
+	MaxBytes int
+	// EvictedRecords is the number of records evicted to stay within the budget
+	EvictedRecords uint64
+}
+
+// usage is the incrementally maintained usage of the store
+type usage struct {
+	records int
+	bytes   int
+	evicted uint64
+}
+
+func (u *usage) add(entry *Entry) {
+	u.records += recordCount(entry)
+	u.bytes += entrySize(entry)
+}
+
+func (u *usage) remove(entry *Entry) {
+	u.records -= recordCount(entry)
+	u.bytes -= entrySize(entry)
+}
+
+// recordRef refers to a record of a stored entry
+type recordRef struct {
+	key      Key
+	record   *MeasurementRecord
+	latest   bool
+	priority int
+	// index is the position of the reference in the eviction order, or -1 once it is removed from it
+	index int
+}
+
+// evictionOrder is a heap of the stored records in eviction order, maintained as the entries are inserted and removed
+// so that enforcing the budget does not sort all of the records on each put
+// The records of past granularity periods come first, oldest first. The records of the
+// latest granularity period of each entry follow, lowest-priority measurements first.
+type evictionOrder struct {
+	refs []*recordRef
+	// byKey holds the references to the records of each entry
+	byKey map[Key][]*recordRef
+}
+
+func newEvictionOrder() *evictionOrder {
+	return &evictionOrder{
+		byKey: make(map[Key][]*recordRef),
+	}
+}
+
+func (o *evictionOrder) Len() int {
+	return len(o.refs)
+}
+
+func (o *evictionOrder) Less(i, j int) bool {
+	a, b := o.refs[i], o.refs[j]
+	if a.latest != b.latest {
+		return !a.latest
+	}
+	if a.latest && a.priority != b.priority {
+		return a.priority < b.priority
+	}
+	return a.record.Timestamp < b.record.Timestamp
+}
+
+func (o *evictionOrder) Swap(i, j int) {
+	o.refs[i], o.refs[j] = o.refs[j], o.refs[i]
+	o.refs[i].index = i
+	o.refs[j].index = j
+}
+
+func (o *evictionOrder) Push(x interface{}) {
+	ref := x.(*recordRef)
+	ref.index = len(o.refs)
+	o.refs = append(o.refs, ref)
+}
+
+func (o *evictionOrder) Pop() interface{} {
+	n := len(o.refs)
+	ref := o.refs[n-1]
+	o.refs[n-1] = nil
+	o.refs = o.refs[:n-1]
+	ref.index = -1
+	return ref
+}
+
+// add adds the records of an inserted entry
+func (o *evictionOrder) add(entry *Entry, priorities map[string]int) {
+	measItems := entry.Value
+	refs := make([]*recordRef, 0, recordCount(entry))
+	for i := range measItems {
+		for j := range measItems[i].MeasurementRecords {
+			record := &measItems[i].MeasurementRecords[j]
+			ref := &recordRef{
+				key:      entry.Key,
+				record:   record,
+				latest:   i == len(measItems)-1,
+				priority: priorities[record.MeasurementName],
+			}
+			heap.Push(o, ref)
+			refs = append(refs, ref)
+		}
+	}
+	o.byKey[entry.Key] = refs
+}
+
+// remove removes the records of a removed entry that are still in the eviction order
+func (o *evictionOrder) remove(entry *Entry) {
+	for _, ref := range o.byKey[entry.Key] {
+		if ref.index >= 0 {
+			heap.Remove(o, ref.index)
+		}
+	}
+	delete(o.byKey, entry.Key)
+}
+
+// budgeted checks if the store has a record or memory budget
+func (s *store) budgeted() bool {
+	return s.options.MaxRecords > 0 || s.options.MaxBytes > 0
+}
+
+// overBudget checks if the given usage exceeds the percentage of the budget
+func (s *store) overBudget(records int, bytes int, percentage int) bool {
+	if s.options.MaxRecords > 0 && records*100 > s.options.MaxRecords*percentage {
+		return true
+	}
+	if s.options.MaxBytes > 0 && bytes*100 > s.options.MaxBytes*percentage {
+		return true
+	}
+	return false
+}
+
+// enforceBudget evicts records until the usage is back within the budget
+// The records of past granularity periods are evicted first, oldest first. The records of the
+// latest granularity period of each entry are then evicted from the lowest-priority measurements.
+func (s *store) enforceBudget(tx generic.Tx[Key, *Entry]) {
+	if !s.overBudget(s.usage.records, s.usage.bytes, 100) {
+		return
+	}
+
+	evicted := make(map[Key]map[*MeasurementRecord]bool)
+	records, bytes := s.usage.records, s.usage.bytes
+	for s.eviction.Len() > 0 && s.overBudget(records, bytes, evictionTarget) {
+		ref := heap.Pop(s.eviction).(*recordRef)
+		if _, ok := evicted[ref.key]; !ok {
+			evicted[ref.key] = make(map[*MeasurementRecord]bool)
+		}
+		evicted[ref.key][ref.record] = true
+		records--
+		bytes -= recordSize(*ref.record)
+	}
+
+	for key, records := range evicted {
+		entry, _ := tx.Get(key)
+		s.evictRecords(tx, entry, records)
+	}
+	log.Debugf("Evicted measurement records to stay within budget, usage is now %d records and %d bytes",
+		s.usage.records, s.usage.bytes)
+}
+
+// evictRecords replaces an entry by a copy without the evicted records, or deletes it if no record is left
+func (s *store) evictRecords(tx generic.Tx[Key, *Entry], entry *Entry, evicted map[*MeasurementRecord]bool) {
+	measItems := entry.Value
+	keptItems := make([]MeasurementItem, 0, len(measItems))
+	for i := range measItems {
+		records := make([]MeasurementRecord, 0, len(measItems[i].MeasurementRecords))
+		for j := range measItems[i].MeasurementRecords {
+			if !evicted[&measItems[i].MeasurementRecords[j]] {
+				records = append(records, measItems[i].MeasurementRecords[j])
+			}
+		}
+		if len(records) > 0 {
+			keptItems = append(keptItems, MeasurementItem{
+				MeasurementRecords: records,
+			})
+		}
+	}
+
+	s.usage.evicted += uint64(len(evicted))
+	if len(keptItems) == 0 {
+		tx.Delete(entry.Key)
+		return
+	}
+
+	keptEntry := *entry
+	keptEntry.Value = keptItems
+	tx.Put(entry.Key, &keptEntry)
+}
+
+func recordCount(entry *Entry) int {
+	count := 0
+	for _, measItem := range entry.Value {
+		count += len(measItem.MeasurementRecords)
+	}
+	return count
+}
+
+func entrySize(entry *Entry) int {
+	size := entryOverhead + len(entry.Key.NodeID) + len(entry.Key.CellIdentity.CellID) + len(entry.CellGlobalID)
+	for _, measItem := range entry.Value {
+		for _, record := range measItem.MeasurementRecords {
+			size += recordSize(record)
+		}
+	}
+	return size
+}
+
+func recordSize(record MeasurementRecord) int {
+	size := recordOverhead + len(record.MeasurementName)
+	for name, value := range record.Labels {
+		size += len(name) + len(value)
+	}
+	return size
+}
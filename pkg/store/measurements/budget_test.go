@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package measurements
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordNames gets the sorted "cell/name/timestamp" of the stored records
+func recordNames(t *testing.T, s Store) []string {
+	result, err := s.Query(context.Background(), Query{})
+	assert.NoError(t, err)
+	names := make([]string, 0)
+	for _, entry := range result.Entries {
+		for _, measItem := range entry.Value {
+			for _, record := range measItem.MeasurementRecords {
+				names = append(names, entry.Key.CellIdentity.CellID+"/"+record.MeasurementName+"/"+string(rune('0'+record.Timestamp)))
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestBudget(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		puts    map[string][]MeasurementItem
+		records []string
+		evicted uint64
+	}{
+		{
+			name: "within budget",
+			opts: []Option{WithMaxRecords(10)},
+			puts: map[string][]MeasurementItem{
+				"cell1": newItems(newRecord("A", 1, int64(1)), newRecord("B", 1, int64(1))),
+			},
+			records: []string{"cell1/A/1", "cell1/B/1"},
+		},
+		{
+			name: "past granularity periods first, oldest first",
+			opts: []Option{WithMaxRecords(4)},
+			puts: map[string][]MeasurementItem{
+				"cell1": {
+					{MeasurementRecords: []MeasurementRecord{newRecord("A", 1, int64(1)), newRecord("A", 3, int64(1))}},
+					{MeasurementRecords: []MeasurementRecord{newRecord("A", 5, int64(1))}},
+				},
+				"cell2": {
+					{MeasurementRecords: []MeasurementRecord{newRecord("A", 2, int64(1))}},
+					{MeasurementRecords: []MeasurementRecord{newRecord("A", 6, int64(1))}},
+				},
+			},
+			// 5 records over a budget of 4 are brought back to 90% of the budget, 3 records
+			records: []string{"cell1/A/3", "cell1/A/5", "cell2/A/6"},
+			evicted: 2,
+		},
+		{
+			name: "lowest priority of the latest period next",
+			opts: []Option{WithMaxRecords(3), WithMeasurementPriorities(map[string]int{"A": 2, "B": 1})},
+			puts: map[string][]MeasurementItem{
+				"cell1": newItems(newRecord("A", 1, int64(1)), newRecord("B", 2, int64(1)), newRecord("C", 3, int64(1))),
+				"cell2": newItems(newRecord("A", 1, int64(1))),
+			},
+			// C and then B are evicted before the higher priority A records
+			records: []string{"cell1/A/1", "cell2/A/1"},
+			evicted: 2,
+		},
+		{
+			name: "entries without records are deleted",
+			opts: []Option{WithMaxRecords(2)},
+			puts: map[string][]MeasurementItem{
+				"cell1": {
+					{MeasurementRecords: []MeasurementRecord{newRecord("A", 1, int64(1))}},
+					{MeasurementRecords: []MeasurementRecord{newRecord("A", 2, int64(1))}},
+				},
+				"cell2": {
+					{MeasurementRecords: []MeasurementRecord{newRecord("A", 3, int64(1))}},
+				},
+			},
+			// the past and then the oldest latest record of cell1 are evicted
+			records: []string{"cell2/A/3"},
+			evicted: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := NewStore(test.opts...)
+			cells := make([]string, 0, len(test.puts))
+			for cell := range test.puts {
+				cells = append(cells, cell)
+			}
+			sort.Strings(cells)
+			for _, cell := range cells {
+				_, err := s.Put(ctx, newTestKey("node1", cell), test.puts[cell])
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.records, recordNames(t, s))
+			usage, err := s.Usage(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, test.evicted, usage.EvictedRecords)
+			assert.Equal(t, len(test.records), usage.Records)
+		})
+	}
+}
+
+func TestEvictionOrderFollowsUpdates(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore(WithMaxRecords(100)).(*store)
+	key := newTestKey("node1", "cell1")
+	for i := 1; i <= 3; i++ {
+		_, err := s.Put(ctx, key, newItems(newRecord("A", uint64(i), int64(i)), newRecord("B", uint64(i), int64(i))))
+		assert.NoError(t, err)
+	}
+	// the replaced entries leave the eviction order
+	assert.Equal(t, 2, s.eviction.Len())
+	assert.Len(t, s.eviction.byKey[key], 2)
+	assert.NoError(t, s.Delete(ctx, key))
+	assert.Equal(t, 0, s.eviction.Len())
+	assert.Empty(t, s.eviction.byKey)
+}
+
+func TestEvictionOrderUnbounded(t *testing.T) {
+	s := NewStore().(*store)
+	_, err := s.Put(context.Background(), newTestKey("node1", "cell1"), newItems(newRecord("A", 1, int64(1))))
+	assert.NoError(t, err)
+	// the eviction order is not maintained without budget
+	assert.Equal(t, 0, s.eviction.Len())
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package measurements
+
+import (
+	"context"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/generic"
+)
+
+const (
+	minExpiryInterval = time.Second
+	// expiryPassesPerTTL is the number of expiry passes within a TTL, which bounds how late an entry expires
+	expiryPassesPerTTL = 4
+)
+
+// expireStaleEntries periodically applies the stale policy to the entries that are not updated within the TTL
+// until the store is closed
+func (s *store) expireStaleEntries() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+		case <-s.done:
+			return
+		}
+		interval := minExpiryInterval
+		ttl := s.options.StaleTTL()
+		if ttl > 0 {
+			s.expire(time.Now(), ttl)
+			if ttl/expiryPassesPerTTL > interval {
+				interval = ttl / expiryPassesPerTTL
+			}
+		}
+		timer.Reset(interval)
+	}
+}
+
+// expire applies the stale policy to the entries updated before now minus the TTL
+func (s *store) expire(now time.Time, ttl time.Duration) {
+	err := s.entries.Update(context.Background(), func(tx generic.Tx[Key, *Entry]) error {
+		staleEntries := make([]*Entry, 0)
+		tx.Range(func(_ Key, entry *Entry) bool {
+			if !entry.Stale && now.Sub(entry.UpdatedAt) > ttl {
+				staleEntries = append(staleEntries, entry)
+			}
+			return true
+		})
+
+		for _, entry := range staleEntries {
+			key := entry.Key
+			switch s.options.StalePolicy {
+			case EvictStale:
+				log.Infof("Evicting stale measurements of cell %s in node %s", key.CellIdentity.CellID, key.NodeID)
+				tx.Delete(key)
+			case MarkStale:
+				log.Infof("Marking stale measurements of cell %s in node %s", key.CellIdentity.CellID, key.NodeID)
+				staleEntry := *entry
+				staleEntry.Stale = true
+				tx.Put(key, &staleEntry)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Warn(err)
+	}
+}
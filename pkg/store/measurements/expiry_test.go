@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package measurements
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpire(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  StalePolicy
+		entries []Key
+		stale   []Key
+		events  []MeasurementEvent
+	}{
+		{
+			name:    "evict",
+			policy:  EvictStale,
+			entries: []Key{newTestKey("node1", "fresh")},
+			stale:   []Key{},
+			events:  []MeasurementEvent{Deleted},
+		},
+		{
+			name:    "mark",
+			policy:  MarkStale,
+			entries: []Key{newTestKey("node1", "fresh"), newTestKey("node1", "old")},
+			stale:   []Key{newTestKey("node1", "old")},
+			events:  []MeasurementEvent{Updated},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			s := NewStore(WithStalePolicy(test.policy)).(*store)
+			now := time.Now()
+			_, err := s.Put(ctx, newTestKey("node1", "old"), newItems(newRecord("A", 1, int64(1))))
+			assert.NoError(t, err)
+			_, err = s.Put(ctx, newTestKey("node1", "fresh"), newItems(newRecord("A", 1, int64(1))))
+			assert.NoError(t, err)
+			// only the old entry is older than the TTL once its update time is moved back
+			old, err := s.Get(ctx, newTestKey("node1", "old"))
+			assert.NoError(t, err)
+			old.UpdatedAt = now.Add(-time.Minute)
+
+			ch := make(chan Event)
+			assert.NoError(t, s.Watch(ctx, ch))
+			s.expire(now, 30*time.Second)
+			for _, eventType := range test.events {
+				e := nextEvent(t, ch)
+				assert.Equal(t, eventType, e.Type)
+				assert.Equal(t, newTestKey("node1", "old"), e.Key)
+			}
+
+			result, err := s.Query(ctx, Query{})
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, test.entries, keys(result.Entries))
+			stale := make([]Key, 0)
+			for _, entry := range result.Entries {
+				if entry.Stale {
+					stale = append(stale, entry.Key)
+				}
+			}
+			assert.Equal(t, test.stale, stale)
+
+			// the stale entries are not expired again
+			s.expire(now.Add(time.Second), 30*time.Second)
+			result, err = s.Query(ctx, Query{ExcludeStale: true})
+			assert.NoError(t, err)
+			assert.Equal(t, []Key{newTestKey("node1", "fresh")}, keys(result.Entries))
+		})
+	}
+}
+
+func TestClose(t *testing.T) {
+	s := NewStore(WithStaleTTL(func() time.Duration {
+		return time.Millisecond
+	}))
+	assert.NoError(t, s.Close())
+	// closing twice is harmless
+	assert.NoError(t, s.Close())
+}
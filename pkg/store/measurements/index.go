@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package measurements
+
+// keySet is a set of store keys
+type keySet map[Key]struct{}
+
+// index is a secondary index of the store keys
+type index[T comparable] map[T]keySet
+
+func (i index[T]) add(value T, key Key) {
+	keys, ok := i[value]
+	if !ok {
+		keys = make(keySet)
+		i[value] = keys
+	}
+	keys[key] = struct{}{}
+}
+
+func (i index[T]) remove(value T, key Key) {
+	keys, ok := i[value]
+	if !ok {
+		return
+	}
+	delete(keys, key)
+	if len(keys) == 0 {
+		delete(i, value)
+	}
+}
+
+// lookup returns the union of the keys indexed by the given values
+func (i index[T]) lookup(values []T) keySet {
+	result := make(keySet)
+	for _, value := range values {
+		for key := range i[value] {
+			result[key] = struct{}{}
+		}
+	}
+	return result
+}
+
+// intersect returns the keys that are in both sets; a nil set stands for all of keys
+func (s keySet) intersect(other keySet) keySet {
+	if s == nil {
+		return other
+	}
+	if other == nil {
+		return s
+	}
+	result := make(keySet)
+	for key := range s {
+		if _, ok := other[key]; ok {
+			result[key] = struct{}{}
+		}
+	}
+	return result
+}
+
+// indexes are the secondary indexes of the measurement store
+type indexes struct {
+	byName index[string]
+	byPlmn index[uint32]
+}
+
+func newIndexes() *indexes {
+	return &indexes{
+		byName: make(index[string]),
+		byPlmn: make(index[uint32]),
+	}
+}
+
+func (i *indexes) add(entry *Entry) {
+	for name := range measurementNames(entry) {
+		i.byName.add(name, entry.Key)
+	}
+	if entry.PlmnID != 0 {
+		i.byPlmn.add(entry.PlmnID, entry.Key)
+	}
+}
+
+func (i *indexes) remove(entry *Entry) {
+	for name := range measurementNames(entry) {
+		i.byName.remove(name, entry.Key)
+	}
+	if entry.PlmnID != 0 {
+		i.byPlmn.remove(entry.PlmnID, entry.Key)
+	}
+}
+
+// candidates returns the keys that may match a query, or nil if the query cannot use an index
+func (i *indexes) candidates(query Query) keySet {
+	var keys keySet
+	if len(query.PlmnIDs) > 0 {
+		keys = keys.intersect(i.byPlmn.lookup(query.PlmnIDs))
+	}
+	if len(query.MeasurementNames) > 0 {
+		keys = keys.intersect(i.byName.lookup(query.MeasurementNames))
+	}
+	return keys
+}
+
+func measurementNames(entry *Entry) map[string]struct{} {
+	names := make(map[string]struct{})
+	for _, measItem := range entry.Value {
+		for _, record := range measItem.MeasurementRecords {
+			names[record.MeasurementName] = struct{}{}
+		}
+	}
+	return names
+}
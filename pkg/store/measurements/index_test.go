@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package measurements
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexCandidates(t *testing.T) {
+	i := newIndexes()
+	entry1 := &Entry{Key: newTestKey("node1", "cell1"), PlmnID: 1, Value: newItems(newRecord("A", 1, int64(1)), newRecord("B", 1, int64(1)))}
+	entry2 := &Entry{Key: newTestKey("node1", "cell2"), PlmnID: 2, Value: newItems(newRecord("A", 1, int64(1)))}
+	entry3 := &Entry{Key: newTestKey("node2", "cell1"), Value: newItems(newRecord("C", 1, int64(1)))}
+	for _, entry := range []*Entry{entry1, entry2, entry3} {
+		i.add(entry)
+	}
+
+	tests := []struct {
+		name  string
+		query Query
+		keys  keySet
+	}{
+		{
+			name:  "no indexed filter",
+			query: Query{NodeIDs: []string{"node1"}},
+			keys:  nil,
+		},
+		{
+			name:  "name",
+			query: Query{MeasurementNames: []string{"A"}},
+			keys:  keySet{entry1.Key: {}, entry2.Key: {}},
+		},
+		{
+			name:  "names are unioned",
+			query: Query{MeasurementNames: []string{"B", "C"}},
+			keys:  keySet{entry1.Key: {}, entry3.Key: {}},
+		},
+		{
+			name:  "name and PLMN are intersected",
+			query: Query{MeasurementNames: []string{"A"}, PlmnIDs: []uint32{2}},
+			keys:  keySet{entry2.Key: {}},
+		},
+		{
+			name:  "unknown name",
+			query: Query{MeasurementNames: []string{"D"}},
+			keys:  keySet{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.keys, i.candidates(test.query))
+		})
+	}
+
+	i.remove(entry1)
+	assert.Equal(t, keySet{entry2.Key: {}}, i.candidates(Query{MeasurementNames: []string{"A"}}))
+	assert.Equal(t, keySet{}, i.candidates(Query{PlmnIDs: []uint32{1}}))
+}
+
+func TestIndexFollowsUpdates(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	key := newTestKey("node1", "cell1")
+	_, err := s.Put(ctx, key, newItems(newRecord("A", 1, int64(1))), WithPlmnID(1))
+	assert.NoError(t, err)
+	// the update replaces the indexed names and PLMN
+	_, err = s.Put(ctx, key, newItems(newRecord("B", 2, int64(1))), WithPlmnID(2))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		query Query
+		total int
+	}{
+		{"old name", Query{MeasurementNames: []string{"A"}}, 0},
+		{"new name", Query{MeasurementNames: []string{"B"}}, 1},
+		{"old PLMN", Query{PlmnIDs: []uint32{1}}, 0},
+		{"new PLMN", Query{PlmnIDs: []uint32{2}}, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := s.Query(ctx, test.query)
+			assert.NoError(t, err)
+			assert.Equal(t, test.total, result.Total)
+		})
+	}
+
+	assert.NoError(t, s.Delete(ctx, key))
+	result, err := s.Query(ctx, Query{MeasurementNames: []string{"B"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Total)
+}
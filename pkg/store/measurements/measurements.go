@@ -6,25 +6,21 @@ package measurements
 
 import (
 	"context"
-	"fmt"
 	"sync"
+	"time"
 
-	"github.com/onosproject/onos-lib-go/pkg/logging"
-
-	"github.com/google/uuid"
-
+	"github.com/onosproject/onos-kpimon/pkg/store/generic"
 	"github.com/onosproject/onos-kpimon/pkg/store/watcher"
-
-	"github.com/onosproject/onos-kpimon/pkg/store/event"
-
 	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
 )
 
 var log = logging.GetLogger()
 
 // Store kpm metrics store interface
 type Store interface {
-	Put(ctx context.Context, key Key, value interface{}) (*Entry, error)
+	// Put puts a metric store entry
+	Put(ctx context.Context, key Key, value []MeasurementItem, opts ...PutOption) (*Entry, error)
 
 	// Get gets a metric store entry based on a given key
 	Get(ctx context.Context, key Key) (*Entry, error)
@@ -35,35 +31,80 @@ type Store interface {
 	// Entries list all of the metric store entries
 	Entries(ctx context.Context, ch chan<- *Entry) error
 
+	// Query lists the metric store entries matching a given query
+	// An empty result is returned if no entry matches.
+	Query(ctx context.Context, query Query) (*QueryResult, error)
+
+	// Usage gets the current usage and budget of the store
+	Usage(ctx context.Context) (*Usage, error)
+
+	// Snapshot gets a consistent copy of all of the metric store entries
+	Snapshot(ctx context.Context) ([]*Entry, error)
+
+	// Restore replaces all of the metric store entries with the given entries
+	// Entries with the same update time as the stored ones are left untouched.
+	Restore(ctx context.Context, entries []*Entry) error
+
 	// Watch measurement store changes
-	Watch(ctx context.Context, ch chan<- event.Event) error
+	// With the generic.WithReplay option, the current entries are sent first as None events and
+	// then the live events follow without gaps or duplicates; with the generic.WithResumeFrom option,
+	// the events missed since the given sequence number are sent first.
+	Watch(ctx context.Context, ch chan<- Event, opts ...generic.WatchOption) error
+
+	// DroppedEvents returns the number of events dropped for the slow watchers
+	DroppedEvents() uint64
+
+	// Close stops the expiry of the stale entries
+	Close() error
 }
 
 type store struct {
-	measurements map[Key]*Entry
-	mu           sync.RWMutex
-	watchers     *watcher.Watchers
+	entries generic.Store[Key, *Entry]
+	// indexes and usage are guarded by the lock of the entries store
+	indexes *indexes
+	usage   usage
+	// eviction is the eviction order of the records, only maintained if the store has a budget
+	eviction *evictionOrder
+	options  Options
+	// done is closed when the store is closed
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
 // NewStore creates new store
-func NewStore() Store {
-	watchers := watcher.NewWatchers()
-	return &store{
-		measurements: make(map[Key]*Entry),
-		watchers:     watchers,
+func NewStore(opts ...Option) Store {
+	options := Options{}
+	for _, opt := range opts {
+		opt.apply(&options)
 	}
-}
 
-func (s *store) Entries(_ context.Context, ch chan<- *Entry) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	s := &store{
+		indexes:  newIndexes(),
+		eviction: newEvictionOrder(),
+		options:  options,
+		done:     make(chan struct{}),
+	}
+	s.entries = generic.NewStore[Key, *Entry](generic.WithHooks(generic.Hooks[Key, *Entry]{
+		Inserted: s.inserted,
+		Removed:  s.removed,
+	}), generic.WithReplayBufferSize[Key, *Entry](options.ReplayBufferSize),
+		generic.WithWatcherOptions[Key, *Entry](
+			watcher.WithQueueSize(options.WatcherQueueSize),
+			watcher.WithSlowConsumerPolicy(options.SlowConsumerPolicy)))
+	if options.StaleTTL != nil {
+		go s.expireStaleEntries()
+	}
+	return s
+}
 
-	if len(s.measurements) == 0 {
+func (s *store) Entries(ctx context.Context, ch chan<- *Entry) error {
+	entries, err := s.entries.List(ctx)
+	if err != nil {
 		close(ch)
-		return fmt.Errorf("no measurements entries stored")
+		return err
 	}
 
-	for _, entry := range s.measurements {
+	for _, entry := range entries {
 		ch <- entry
 	}
 
@@ -71,60 +112,158 @@ func (s *store) Entries(_ context.Context, ch chan<- *Entry) error {
 	return nil
 }
 
-func (s *store) Delete(_ context.Context, key Key) error {
-	// TODO check the key and make sure it is not empty
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	delete(s.measurements, key)
-	return nil
+func (s *store) Query(ctx context.Context, query Query) (*QueryResult, error) {
+	if query.Offset < 0 || query.Limit < 0 {
+		return nil, errors.NewInvalid("query offset and limit must not be negative")
+	}
+
+	entries := make([]*Entry, 0)
+	err := s.entries.View(ctx, func(tx generic.ReadTx[Key, *Entry]) error {
+		if candidates := s.indexes.candidates(query); candidates != nil {
+			for key := range candidates {
+				entry, _ := tx.Get(key)
+				if filtered, ok := query.Filter(entry); ok {
+					entries = append(entries, filtered)
+				}
+			}
+			return nil
+		}
 
+		tx.Range(func(_ Key, entry *Entry) bool {
+			if filtered, ok := query.Filter(entry); ok {
+				entries = append(entries, filtered)
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return query.paginate(entries), nil
 }
 
-func (s *store) Put(_ context.Context, key Key, value interface{}) (*Entry, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+func (s *store) Delete(ctx context.Context, key Key) error {
+	// TODO check the key and make sure it is not empty
+	return s.entries.Delete(ctx, key)
+}
+
+func (s *store) Put(ctx context.Context, key Key, value []MeasurementItem, opts ...PutOption) (*Entry, error) {
+	options := PutOptions{}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+
 	entry := &Entry{
-		Key:   key,
-		Value: value,
-	}
-	s.measurements[key] = entry
-	s.watchers.Send(event.Event{
-		Key:   key,
-		Value: entry,
-		Type:  Created,
+		Key:          key,
+		Value:        value,
+		CellGlobalID: options.CellGlobalID,
+		PlmnID:       options.PlmnID,
+		UpdatedAt:    time.Now(),
+	}
+	err := s.entries.Update(ctx, func(tx generic.Tx[Key, *Entry]) error {
+		tx.Put(key, entry)
+		s.enforceBudget(tx)
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
 	return entry, nil
-
 }
 
-func (s *store) Get(_ context.Context, key Key) (*Entry, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	if v, ok := s.measurements[key]; ok {
-		return v, nil
+func (s *store) Get(ctx context.Context, key Key) (*Entry, error) {
+	entry, err := s.entries.Get(ctx, key)
+	if err != nil {
+		return nil, errors.New(errors.NotFound, "the measurement entry does not exist")
 	}
-	return nil, errors.New(errors.NotFound, "the measurement entry does not exist")
+	return entry, nil
 }
 
-func (s *store) Watch(ctx context.Context, ch chan<- event.Event) error {
-	id := uuid.New()
-	err := s.watchers.AddWatcher(id, ch)
+func (s *store) Usage(ctx context.Context) (*Usage, error) {
+	usage := &Usage{
+		MaxRecords: s.options.MaxRecords,
+		MaxBytes:   s.options.MaxBytes,
+	}
+	err := s.entries.View(ctx, func(tx generic.ReadTx[Key, *Entry]) error {
+		usage.Entries = tx.Len()
+		usage.Records = s.usage.records
+		usage.Bytes = s.usage.bytes
+		usage.EvictedRecords = s.usage.evicted
+		return nil
+	})
 	if err != nil {
-		log.Error(err)
-		close(ch)
-		return err
+		return nil, err
 	}
-	go func() {
-		<-ctx.Done()
-		err = s.watchers.RemoveWatcher(id)
-		if err != nil {
-			log.Error(err)
+	return usage, nil
+}
+
+func (s *store) Snapshot(ctx context.Context) ([]*Entry, error) {
+	return s.entries.List(ctx)
+}
+
+func (s *store) Restore(ctx context.Context, entries []*Entry) error {
+	return s.entries.Update(ctx, func(tx generic.Tx[Key, *Entry]) error {
+		restored := make(map[Key]*Entry, len(entries))
+		for _, entry := range entries {
+			restored[entry.Key] = entry
 		}
-		close(ch)
-	}()
+		keys := make([]Key, 0)
+		tx.Range(func(key Key, _ *Entry) bool {
+			if _, ok := restored[key]; !ok {
+				keys = append(keys, key)
+			}
+			return true
+		})
+		for _, key := range keys {
+			tx.Delete(key)
+		}
+		// the unchanged entries are kept as they are so that restoring the same state repeatedly,
+		// as a standby replica does, does not notify the watchers
+		for key, entry := range restored {
+			if current, ok := tx.Get(key); ok && current.UpdatedAt.Equal(entry.UpdatedAt) && current.Stale == entry.Stale {
+				continue
+			}
+			tx.Put(key, entry)
+		}
+		s.enforceBudget(tx)
+		return nil
+	})
+}
+
+func (s *store) Watch(ctx context.Context, ch chan<- Event, opts ...generic.WatchOption) error {
+	return s.entries.Watch(ctx, ch, opts...)
+}
+
+func (s *store) DroppedEvents() uint64 {
+	return s.entries.DroppedEvents()
+}
+
+func (s *store) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
 	return nil
 }
 
+// inserted updates the indexes and the usage when an entry is inserted
+func (s *store) inserted(_ Key, entry *Entry) {
+	s.indexes.add(entry)
+	s.usage.add(entry)
+	if s.budgeted() {
+		s.eviction.add(entry, s.options.MeasurementPriorities)
+	}
+}
+
+// removed updates the indexes and the usage when an entry is removed
+func (s *store) removed(_ Key, entry *Entry) {
+	s.indexes.remove(entry)
+	s.usage.remove(entry)
+	if s.budgeted() {
+		s.eviction.remove(entry)
+	}
+}
+
 // NewKey creates a new measurements map key
 func NewKey(CellID CellIdentity, nodeID string) Key {
 	return Key{
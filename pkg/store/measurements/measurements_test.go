@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package measurements
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/generic"
+	"github.com/onosproject/onos-kpimon/pkg/store/watcher"
+	"github.com/stretchr/testify/assert"
+)
+
+func newItems(records ...MeasurementRecord) []MeasurementItem {
+	return []MeasurementItem{{MeasurementRecords: records}}
+}
+
+func newRecord(name string, timestamp uint64, value interface{}) MeasurementRecord {
+	return MeasurementRecord{
+		Timestamp:        timestamp,
+		MeasurementName:  name,
+		MeasurementValue: value,
+	}
+}
+
+func newTestKey(nodeID string, cellID string) Key {
+	return NewKey(CellIdentity{CellID: cellID}, nodeID)
+}
+
+func nextEvent(t *testing.T, ch <-chan Event) Event {
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return Event{}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []generic.WatchOption
+		replay int
+	}{
+		{
+			name:   "live events only",
+			replay: 0,
+		},
+		{
+			name:   "replay",
+			opts:   []generic.WatchOption{generic.WithReplay()},
+			replay: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			s := NewStore()
+			_, err := s.Put(ctx, newTestKey("node1", "cell1"), newItems(newRecord("A", 1, int64(1))))
+			assert.NoError(t, err)
+			_, err = s.Put(ctx, newTestKey("node1", "cell2"), newItems(newRecord("A", 1, int64(2))))
+			assert.NoError(t, err)
+
+			ch := make(chan Event)
+			assert.NoError(t, s.Watch(ctx, ch, test.opts...))
+			replayed := make(map[Key]bool)
+			for i := 0; i < test.replay; i++ {
+				e := nextEvent(t, ch)
+				assert.Equal(t, None, e.Type)
+				assert.Equal(t, uint64(2), e.Sequence)
+				replayed[e.Key] = true
+			}
+			assert.Len(t, replayed, test.replay)
+
+			// the live events follow the replay without gap
+			_, err = s.Put(ctx, newTestKey("node1", "cell1"), newItems(newRecord("A", 2, int64(3))))
+			assert.NoError(t, err)
+			assert.NoError(t, s.Delete(ctx, newTestKey("node1", "cell2")))
+			_, err = s.Put(ctx, newTestKey("node2", "cell1"), newItems(newRecord("A", 2, int64(4))))
+			assert.NoError(t, err)
+
+			e := nextEvent(t, ch)
+			assert.Equal(t, Updated, e.Type)
+			assert.Equal(t, uint64(3), e.Sequence)
+			assert.Equal(t, int64(3), e.Value.Value[0].MeasurementRecords[0].MeasurementValue)
+			e = nextEvent(t, ch)
+			assert.Equal(t, Deleted, e.Type)
+			assert.Equal(t, newTestKey("node1", "cell2"), e.Key)
+			e = nextEvent(t, ch)
+			assert.Equal(t, Created, e.Type)
+			assert.Equal(t, uint64(5), e.Sequence)
+
+			// the watch ends with its context
+			cancel()
+			assert.Eventually(t, func() bool {
+				_, ok := <-ch
+				return !ok
+			}, time.Second, time.Millisecond)
+		})
+	}
+}
+
+func TestResumeWatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		resumeFrom uint64
+		types      []MeasurementEvent
+	}{
+		{
+			name:       "buffered",
+			resumeFrom: 3,
+			types:      []MeasurementEvent{Updated, Updated},
+		},
+		{
+			name:       "up to date",
+			resumeFrom: 5,
+			types:      []MeasurementEvent{},
+		},
+		{
+			name:       "no longer buffered",
+			resumeFrom: 1,
+			types:      []MeasurementEvent{Resync, None},
+		},
+		{
+			name:       "ahead of the store",
+			resumeFrom: 6,
+			types:      []MeasurementEvent{Resync, None},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			s := NewStore(WithReplayBufferSize(3))
+			for i := 1; i <= 5; i++ {
+				_, err := s.Put(ctx, newTestKey("node1", "cell1"), newItems(newRecord("A", uint64(i), int64(i))))
+				assert.NoError(t, err)
+			}
+
+			ch := make(chan Event)
+			assert.NoError(t, s.Watch(ctx, ch, generic.WithResumeFrom(test.resumeFrom)))
+			for _, eventType := range test.types {
+				e := nextEvent(t, ch)
+				assert.Equal(t, eventType, e.Type)
+			}
+			_, err := s.Put(ctx, newTestKey("node1", "cell1"), newItems(newRecord("A", 6, int64(6))))
+			assert.NoError(t, err)
+			e := nextEvent(t, ch)
+			assert.Equal(t, Updated, e.Type)
+			assert.Equal(t, uint64(6), e.Sequence)
+		})
+	}
+}
+
+func TestWatchSlowConsumerPolicy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := NewStore(WithWatcherQueueSize(1))
+	// the watch is disconnected instead of dropping the events of its full queue
+	ch := make(chan Event)
+	assert.NoError(t, s.Watch(ctx, ch, generic.WithSlowConsumerPolicy(watcher.Disconnect)))
+	for i := 1; i <= 3; i++ {
+		_, err := s.Put(ctx, newTestKey("node1", "cell1"), newItems(newRecord("A", uint64(i), int64(i))))
+		assert.NoError(t, err)
+	}
+	assert.Eventually(t, func() bool {
+		select {
+		case _, ok := <-ch:
+			return !ok
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+}
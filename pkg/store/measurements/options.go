@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package measurements
+
+import (
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/watcher"
+)
+
+// Options measurement store options
+type Options struct {
+	// StaleTTL returns the duration after which an entry that has not been updated is stale;
+	// it is evaluated on every expiry pass so that it can follow the report period
+	StaleTTL func() time.Duration
+	// StalePolicy is applied to the stale entries
+	StalePolicy StalePolicy
+	// MaxRecords is the maximum number of stored records; zero means unbounded
+	MaxRecords int
+	// MaxBytes is the maximum approximate memory used by the stored entries; zero means unbounded
+	MaxBytes int
+	// MeasurementPriorities are the eviction priorities of the measurements by name;
+	// the records of lower priority are evicted first and unlisted measurements have priority zero
+	MeasurementPriorities map[string]int
+	// ReplayBufferSize is the number of latest events kept to resume the watches; zero disables the resumption
+	ReplayBufferSize int
+	// WatcherQueueSize is the maximum number of events pending for each watcher
+	WatcherQueueSize int
+	// SlowConsumerPolicy is applied when the queue of a watcher is full
+	SlowConsumerPolicy watcher.SlowConsumerPolicy
+}
+
+// Option measurement store option interface
+type Option interface {
+	apply(*Options)
+}
+
+type funcOption struct {
+	f func(*Options)
+}
+
+func (f funcOption) apply(options *Options) {
+	f.f(options)
+}
+
+func newOption(f func(*Options)) Option {
+	return funcOption{
+		f: f,
+	}
+}
+
+// WithStaleTTL enables the expiry of the entries that are not updated within the given TTL
+func WithStaleTTL(ttl func() time.Duration) Option {
+	return newOption(func(options *Options) {
+		options.StaleTTL = ttl
+	})
+}
+
+// WithStalePolicy sets the policy applied to the stale entries
+func WithStalePolicy(policy StalePolicy) Option {
+	return newOption(func(options *Options) {
+		options.StalePolicy = policy
+	})
+}
+
+// WithMaxRecords sets the maximum number of stored records
+func WithMaxRecords(maxRecords int) Option {
+	return newOption(func(options *Options) {
+		options.MaxRecords = maxRecords
+	})
+}
+
+// WithMaxBytes sets the maximum approximate memory used by the stored entries
+func WithMaxBytes(maxBytes int) Option {
+	return newOption(func(options *Options) {
+		options.MaxBytes = maxBytes
+	})
+}
+
+// WithMeasurementPriorities sets the eviction priorities of the measurements by name
+func WithMeasurementPriorities(priorities map[string]int) Option {
+	return newOption(func(options *Options) {
+		options.MeasurementPriorities = priorities
+	})
+}
+
+// WithReplayBufferSize sets the number of latest events kept to resume the watches
+func WithReplayBufferSize(size int) Option {
+	return newOption(func(options *Options) {
+		options.ReplayBufferSize = size
+	})
+}
+
+// WithWatcherQueueSize sets the maximum number of events pending for each watcher
+func WithWatcherQueueSize(size int) Option {
+	return newOption(func(options *Options) {
+		options.WatcherQueueSize = size
+	})
+}
+
+// WithSlowConsumerPolicy sets the policy applied when the queue of a watcher is full
+func WithSlowConsumerPolicy(policy watcher.SlowConsumerPolicy) Option {
+	return newOption(func(options *Options) {
+		options.SlowConsumerPolicy = policy
+	})
+}
+
+// PutOptions measurement store put options
+type PutOptions struct {
+	// CellGlobalID is the global ID of the cell the entry belongs to
+	CellGlobalID string
+	// PlmnID is the PLMN ID of the cell the entry belongs to, or zero if it is unknown
+	PlmnID uint32
+}
+
+// PutOption put option interface
+type PutOption interface {
+	apply(*PutOptions)
+}
+
+type funcPutOption struct {
+	f func(*PutOptions)
+}
+
+func (f funcPutOption) apply(options *PutOptions) {
+	f.f(options)
+}
+
+func newPutOption(f func(*PutOptions)) PutOption {
+	return funcPutOption{
+		f: f,
+	}
+}
+
+// WithCellGlobalID sets the global ID of the cell the entry belongs to
+func WithCellGlobalID(cellGlobalID string) PutOption {
+	return newPutOption(func(options *PutOptions) {
+		options.CellGlobalID = cellGlobalID
+	})
+}
+
+// WithPlmnID sets the PLMN ID of the cell the entry belongs to
+func WithPlmnID(plmnID uint32) PutOption {
+	return newPutOption(func(options *PutOptions) {
+		options.PlmnID = plmnID
+	})
+}
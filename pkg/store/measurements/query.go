@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package measurements
+
+import (
+	"sort"
+
+	valueutils "github.com/onosproject/onos-kpimon/pkg/utils/values"
+)
+
+// Query measurement store query
+// Empty filters match everything; a time bound set to zero is unbounded.
+type Query struct {
+	// NodeIDs selects entries of the given E2 nodes
+	NodeIDs []string
+	// CellIDs selects entries of the given cell object IDs
+	CellIDs []string
+	// CellGlobalIDs selects entries of the given cell global IDs
+	CellGlobalIDs []string
+	// PlmnIDs selects entries of the cells in the given PLMNs
+	PlmnIDs []uint32
+	// MeasurementNames selects the records with the given measurement names
+	MeasurementNames []string
+	// StartTime selects the records with a timestamp equal or after it, in nanoseconds
+	StartTime uint64
+	// EndTime selects the records with a timestamp before it, in nanoseconds
+	EndTime uint64
+	// ExcludeStale skips the entries marked as stale
+	ExcludeStale bool
+	// Offset is the number of matching entries to skip
+	Offset int
+	// Limit is the maximum number of entries to return; zero means no limit
+	Limit int
+}
+
+// QueryResult measurement store query result
+type QueryResult struct {
+	// Entries are the matching entries ordered by node ID and cell object ID
+	Entries []*Entry
+	// Total is the number of matching entries regardless of the pagination
+	Total int
+	// NextOffset is the offset of the next page, or zero if this is the last page
+	NextOffset int
+}
+
+// MatchKey checks if the identities of an entry match the node, cell and PLMN filters of the query
+func (q Query) MatchKey(entry *Entry) bool {
+	key := entry.Key
+	if q.ExcludeStale && entry.Stale {
+		return false
+	}
+	if len(q.NodeIDs) > 0 && !valueutils.Contains(q.NodeIDs, key.NodeID) {
+		return false
+	}
+	if len(q.PlmnIDs) > 0 && !valueutils.Contains(q.PlmnIDs, entry.PlmnID) {
+		return false
+	}
+	if len(q.CellIDs) > 0 || len(q.CellGlobalIDs) > 0 {
+		// a cell may be selected by either of its identities
+		if !valueutils.Contains(q.CellIDs, key.CellIdentity.CellID) && !valueutils.Contains(q.CellGlobalIDs, entry.CellGlobalID) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchRecord checks if a record matches the measurement name and time range filters of the query
+func (q Query) MatchRecord(record MeasurementRecord) bool {
+	if len(q.MeasurementNames) > 0 && !valueutils.Contains(q.MeasurementNames, record.MeasurementName) {
+		return false
+	}
+	if q.StartTime != 0 && record.Timestamp < q.StartTime {
+		return false
+	}
+	if q.EndTime != 0 && record.Timestamp >= q.EndTime {
+		return false
+	}
+	return true
+}
+
+// Filter applies the query to an entry
+// It returns a copy of the entry holding only the matching records, or false if nothing matches.
+func (q Query) Filter(entry *Entry) (*Entry, bool) {
+	if !q.MatchKey(entry) {
+		return nil, false
+	}
+	if len(q.MeasurementNames) == 0 && q.StartTime == 0 && q.EndTime == 0 {
+		return entry, true
+	}
+
+	measItems := entry.Value
+	filteredItems := make([]MeasurementItem, 0, len(measItems))
+	for _, measItem := range measItems {
+		records := make([]MeasurementRecord, 0, len(measItem.MeasurementRecords))
+		for _, record := range measItem.MeasurementRecords {
+			if q.MatchRecord(record) {
+				records = append(records, record)
+			}
+		}
+		if len(records) > 0 {
+			filteredItems = append(filteredItems, MeasurementItem{
+				MeasurementRecords: records,
+			})
+		}
+	}
+	if len(filteredItems) == 0 {
+		return nil, false
+	}
+
+	filteredEntry := *entry
+	filteredEntry.Value = filteredItems
+	return &filteredEntry, true
+}
+
+// paginate sorts the matching entries and returns the page selected by the query
+func (q Query) paginate(entries []*Entry) *QueryResult {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key.less(entries[j].Key)
+	})
+
+	result := &QueryResult{
+		Entries: make([]*Entry, 0),
+		Total:   len(entries),
+	}
+	if q.Offset >= len(entries) {
+		return result
+	}
+	end := len(entries)
+	if q.Limit > 0 && q.Offset+q.Limit < end {
+		end = q.Offset + q.Limit
+		result.NextOffset = end
+	}
+	result.Entries = append(result.Entries, entries[q.Offset:end]...)
+	return result
+}
+
+func (k Key) less(other Key) bool {
+	if k.NodeID != other.NodeID {
+		return k.NodeID < other.NodeID
+	}
+	return k.CellIdentity.CellID < other.CellIdentity.CellID
+}
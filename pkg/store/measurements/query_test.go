@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package measurements
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newQueryTestStore(t *testing.T) Store {
+	ctx := context.Background()
+	s := NewStore()
+	puts := []struct {
+		key          Key
+		cellGlobalID string
+		plmnID       uint32
+		records      []MeasurementRecord
+	}{
+		{newTestKey("node2", "cell1"), "cgi-21", 0x138426, []MeasurementRecord{newRecord("A", 10, int64(1)), newRecord("B", 20, int64(2))}},
+		{newTestKey("node1", "cell2"), "cgi-12", 0x138426, []MeasurementRecord{newRecord("A", 10, int64(3))}},
+		{newTestKey("node1", "cell1"), "cgi-11", 0x001001, []MeasurementRecord{newRecord("B", 30, 4.5)}},
+	}
+	for _, put := range puts {
+		_, err := s.Put(ctx, put.key, newItems(put.records...), WithCellGlobalID(put.cellGlobalID), WithPlmnID(put.plmnID))
+		assert.NoError(t, err)
+	}
+	return s
+}
+
+func keys(entries []*Entry) []Key {
+	result := make([]Key, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, entry.Key)
+	}
+	return result
+}
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      Query
+		keys       []Key
+		records    []int
+		total      int
+		nextOffset int
+	}{
+		{
+			name:    "all of the entries sorted by node and cell",
+			query:   Query{},
+			keys:    []Key{newTestKey("node1", "cell1"), newTestKey("node1", "cell2"), newTestKey("node2", "cell1")},
+			records: []int{1, 1, 2},
+			total:   3,
+		},
+		{
+			name:    "node",
+			query:   Query{NodeIDs: []string{"node1"}},
+			keys:    []Key{newTestKey("node1", "cell1"), newTestKey("node1", "cell2")},
+			records: []int{1, 1},
+			total:   2,
+		},
+		{
+			name:    "cell object or global ID",
+			query:   Query{CellIDs: []string{"cell2"}, CellGlobalIDs: []string{"cgi-21"}},
+			keys:    []Key{newTestKey("node1", "cell2"), newTestKey("node2", "cell1")},
+			records: []int{1, 2},
+			total:   2,
+		},
+		{
+			name:    "PLMN",
+			query:   Query{PlmnIDs: []uint32{0x001001}},
+			keys:    []Key{newTestKey("node1", "cell1")},
+			records: []int{1},
+			total:   1,
+		},
+		{
+			name:    "measurement name keeps the matching records",
+			query:   Query{MeasurementNames: []string{"A"}},
+			keys:    []Key{newTestKey("node1", "cell2"), newTestKey("node2", "cell1")},
+			records: []int{1, 1},
+			total:   2,
+		},
+		{
+			name:    "time range",
+			query:   Query{StartTime: 20, EndTime: 30},
+			keys:    []Key{newTestKey("node2", "cell1")},
+			records: []int{1},
+			total:   1,
+		},
+		{
+			name:    "no match",
+			query:   Query{NodeIDs: []string{"node3"}},
+			keys:    []Key{},
+			records: []int{},
+		},
+		{
+			name:       "first page",
+			query:      Query{Limit: 2},
+			keys:       []Key{newTestKey("node1", "cell1"), newTestKey("node1", "cell2")},
+			records:    []int{1, 1},
+			total:      3,
+			nextOffset: 2,
+		},
+		{
+			name:    "last page",
+			query:   Query{Offset: 2, Limit: 2},
+			keys:    []Key{newTestKey("node2", "cell1")},
+			records: []int{2},
+			total:   3,
+		},
+		{
+			name:    "offset beyond the entries",
+			query:   Query{Offset: 5},
+			keys:    []Key{},
+			records: []int{},
+			total:   3,
+		},
+	}
+	s := newQueryTestStore(t)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := s.Query(context.Background(), test.query)
+			assert.NoError(t, err)
+			assert.Equal(t, test.keys, keys(result.Entries))
+			records := make([]int, 0, len(result.Entries))
+			for _, entry := range result.Entries {
+				records = append(records, len(entry.Value[0].MeasurementRecords))
+			}
+			assert.Equal(t, test.records, records)
+			assert.Equal(t, test.total, result.Total)
+			assert.Equal(t, test.nextOffset, result.NextOffset)
+		})
+	}
+}
+
+func TestQueryInvalidPagination(t *testing.T) {
+	s := NewStore()
+	_, err := s.Query(context.Background(), Query{Offset: -1})
+	assert.Error(t, err)
+	_, err = s.Query(context.Background(), Query{Limit: -1})
+	assert.Error(t, err)
+}
+
+func TestFilterKeepsEntry(t *testing.T) {
+	entry := &Entry{
+		Key:   newTestKey("node1", "cell1"),
+		Value: newItems(newRecord("A", 10, int64(1)), newRecord("B", 10, int64(2))),
+		Stale: true,
+	}
+	tests := []struct {
+		name    string
+		query   Query
+		match   bool
+		records int
+	}{
+		{"no filter", Query{}, true, 2},
+		{"record filter", Query{MeasurementNames: []string{"B"}}, true, 1},
+		{"no matching record", Query{MeasurementNames: []string{"C"}}, false, 0},
+		{"stale excluded", Query{ExcludeStale: true}, false, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filtered, ok := test.query.Filter(entry)
+			assert.Equal(t, test.match, ok)
+			if ok {
+				assert.Len(t, filtered.Value[0].MeasurementRecords, test.records)
+			}
+		})
+	}
+	// the filtered entry is a copy
+	assert.Len(t, entry.Value[0].MeasurementRecords, 2)
+}
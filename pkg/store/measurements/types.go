@@ -4,6 +4,14 @@
 
 package measurements
 
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/event"
+)
+
 // MeasurementItem measurement item
 type MeasurementItem struct {
 	MeasurementRecords []MeasurementRecord
@@ -14,6 +22,27 @@ type MeasurementRecord struct {
 	Timestamp        uint64
 	MeasurementName  string
 	MeasurementValue interface{}
+	// Labels are the KPM measurement labels of the record, e.g. plmn_id, slice_id or five_qi
+	Labels map[string]string
+}
+
+// SeriesKey identifies the series of a record by its measurement name and label set
+func (r MeasurementRecord) SeriesKey() string {
+	names := make([]string, 0, len(r.Labels))
+	for name := range r.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(r.MeasurementName)
+	for _, name := range names {
+		b.WriteString("\x00")
+		b.WriteString(name)
+		b.WriteString("=")
+		b.WriteString(r.Labels[name])
+	}
+	return b.String()
 }
 
 // CellIdentity is the ID for each cell
@@ -29,24 +58,45 @@ type Key struct {
 
 // Entry measurement store entry
 type Entry struct {
-	Key   Key
-	Value interface{}
+	Key          Key
+	Value        []MeasurementItem
+	CellGlobalID string
+	PlmnID       uint32
+	// UpdatedAt is the time the entry was put
+	UpdatedAt time.Time
+	// Stale is set when the entry has not been updated within the stale TTL
+	Stale bool
 }
 
+// Event measurement store event
+type Event = event.Event[Key, *Entry]
+
 // MeasurementEvent a measurement event
-type MeasurementEvent int
+type MeasurementEvent = event.Type
 
 const (
 	// None none cell event
-	None MeasurementEvent = iota
+	None = event.None
 	// Created created measurement event
-	Created
+	Created = event.Created
 	// Updated updated measurement event
-	Updated
+	Updated = event.Updated
 	// Deleted deleted measurement event
-	Deleted
+	Deleted = event.Deleted
+	// Resync resync required event, followed by the current entries
+	Resync = event.Resync
+)
+
+// StalePolicy defines what happens to the entries that are not updated within the stale TTL
+type StalePolicy int
+
+const (
+	// EvictStale deletes the stale entries
+	EvictStale StalePolicy = iota
+	// MarkStale keeps the stale entries and marks them as stale
+	MarkStale
 )
 
-func (e MeasurementEvent) String() string {
-	return [...]string{"None", "Created", "Updated", "Deleted"}[e]
+func (p StalePolicy) String() string {
+	return [...]string{"EvictStale", "MarkStale"}[p]
 }
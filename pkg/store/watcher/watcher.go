@@ -5,71 +5,290 @@
 package watcher
 
 import (
+	"container/list"
 	"sync"
+	"sync/atomic"
 
-	"github.com/onosproject/onos-kpimon/pkg/store/event"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
 
 	"github.com/google/uuid"
 )
 
-// EventChannel is a channel which can accept an Event
-type EventChannel chan event.Event
+var log = logging.GetLogger()
 
-// Watchers stores the information about watchers
-type Watchers struct {
-	watchers map[uuid.UUID]Watcher
+const defaultQueueSize = 1000
+
+// EventChannel is a channel which can accept an event
+type EventChannel[E any] chan E
+
+// SlowConsumerPolicy defines what happens when a watcher queue is full
+type SlowConsumerPolicy int
+
+const (
+	// DropOldest discards the oldest queued event to make room for the new one
+	DropOldest SlowConsumerPolicy = iota
+	// DropNewest discards the incoming event
+	DropNewest
+	// Disconnect removes the watcher and closes its channel
+	Disconnect
+)
+
+func (p SlowConsumerPolicy) String() string {
+	return [...]string{"DropOldest", "DropNewest", "Disconnect"}[p]
+}
+
+// Options watchers options
+type Options struct {
+	// QueueSize is the maximum number of pending events per watcher
+	QueueSize int
+	// Policy is applied when the queue of a watcher is full
+	Policy SlowConsumerPolicy
+}
+
+// Option watchers option interface
+type Option interface {
+	apply(*Options)
+}
+
+type funcOption struct {
+	f func(*Options)
+}
+
+func (f funcOption) apply(options *Options) {
+	f.f(options)
+}
+
+func newOption(f func(*Options)) Option {
+	return funcOption{
+		f: f,
+	}
+}
+
+// WithQueueSize sets the maximum number of pending events per watcher
+func WithQueueSize(size int) Option {
+	return newOption(func(options *Options) {
+		options.QueueSize = size
+	})
+}
+
+// WithSlowConsumerPolicy sets the policy applied when a watcher queue is full
+func WithSlowConsumerPolicy(policy SlowConsumerPolicy) Option {
+	return newOption(func(options *Options) {
+		options.Policy = policy
+	})
+}
+
+// Watchers stores the information about watchers of events of type E
+type Watchers[E any] struct {
+	watchers map[uuid.UUID]*Watcher[E]
+	options  Options
+	dropped  uint64
 	rm       sync.RWMutex
 }
 
 // Watcher event watcher
-type Watcher struct {
-	id uuid.UUID
-	ch chan<- event.Event
+// Events are queued in a bounded buffer and delivered in order by a dedicated goroutine,
+// so a slow consumer never blocks the sender.
+type Watcher[E any] struct {
+	id     uuid.UUID
+	ch     chan<- E
+	buffer *list.List
+	// replay holds the replay events, delivered before the buffered events and not bounded by the queue size
+	replay  []E
+	cond    *sync.Cond
+	options Options
+	closed  bool
+	done    chan struct{}
+	dropped uint64
 }
 
 // NewWatchers creates watchers
-func NewWatchers() *Watchers {
-	return &Watchers{
-		watchers: make(map[uuid.UUID]Watcher),
+func NewWatchers[E any](opts ...Option) *Watchers[E] {
+	options := Options{
+		QueueSize: defaultQueueSize,
+		Policy:    DropOldest,
+	}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	if options.QueueSize <= 0 {
+		options.QueueSize = defaultQueueSize
+	}
+
+	return &Watchers[E]{
+		watchers: make(map[uuid.UUID]*Watcher[E]),
+		options:  options,
 	}
 }
 
-// Send sends an event for all registered watchers
-func (ws *Watchers) Send(event event.Event) {
+// Send queues an event for all registered watchers; it never blocks on a watcher channel
+func (ws *Watchers[E]) Send(event E) {
+	var disconnected []uuid.UUID
 	ws.rm.RLock()
-	go func() {
-		for _, watcher := range ws.watchers {
-			watcher.ch <- event
+	for id, watcher := range ws.watchers {
+		if !watcher.push(event) {
+			disconnected = append(disconnected, id)
 		}
-	}()
+	}
 	ws.rm.RUnlock()
+
+	for _, id := range disconnected {
+		log.Warnf("Disconnecting slow watcher %s", id)
+		err := ws.RemoveWatcher(id)
+		if err != nil && !errors.IsNotFound(err) {
+			log.Warn(err)
+		}
+	}
 }
 
 // AddWatcher adds a watcher
-func (ws *Watchers) AddWatcher(id uuid.UUID, ch chan<- event.Event) error {
+// The given channel is owned by the watcher from now on and it is closed once the watcher is removed.
+// The replay events, if any, are delivered before any event sent after the watcher is added;
+// they are not bounded by the queue size.
+func (ws *Watchers[E]) AddWatcher(id uuid.UUID, ch chan<- E, replay ...E) error {
+	return ws.AddWatcherWithPolicy(id, ch, ws.options.Policy, replay...)
+}
+
+// AddWatcherWithPolicy adds a watcher whose full queue is handled with the given policy instead of the policy of the watchers
+func (ws *Watchers[E]) AddWatcherWithPolicy(id uuid.UUID, ch chan<- E, policy SlowConsumerPolicy, replay ...E) error {
 	ws.rm.Lock()
-	watcher := Watcher{
-		id: id,
-		ch: ch,
+	defer ws.rm.Unlock()
+	if _, ok := ws.watchers[id]; ok {
+		return errors.NewAlreadyExists("watcher %s already exists", id)
+	}
+	options := ws.options
+	options.Policy = policy
+	watcher := &Watcher[E]{
+		id:      id,
+		ch:      ch,
+		buffer:  list.New(),
+		cond:    sync.NewCond(&sync.Mutex{}),
+		options: options,
+		done:    make(chan struct{}),
 	}
+	watcher.replay = append(watcher.replay, replay...)
 	ws.watchers[id] = watcher
-	ws.rm.Unlock()
+	go watcher.drain()
 	return nil
-
 }
 
-// RemoveWatcher removes a watcher
-func (ws *Watchers) RemoveWatcher(id uuid.UUID) error {
+// RemoveWatcher removes a watcher and closes its channel
+func (ws *Watchers[E]) RemoveWatcher(id uuid.UUID) error {
 	ws.rm.Lock()
-	watchers := make(map[uuid.UUID]Watcher, len(ws.watchers)-1)
+	watcher, ok := ws.watchers[id]
+	if !ok {
+		ws.rm.Unlock()
+		return errors.NewNotFound("watcher %s not found", id)
+	}
+	delete(ws.watchers, id)
+	ws.rm.Unlock()
+
+	atomic.AddUint64(&ws.dropped, watcher.close())
+	return nil
+}
+
+// DroppedEvents returns the number of events dropped for all of watchers, including removed ones
+func (ws *Watchers[E]) DroppedEvents() uint64 {
+	dropped := atomic.LoadUint64(&ws.dropped)
+	ws.rm.RLock()
+	defer ws.rm.RUnlock()
 	for _, watcher := range ws.watchers {
-		if watcher.id != id {
-			watchers[id] = watcher
+		dropped += watcher.Dropped()
+	}
+	return dropped
+}
+
+// Len returns the number of registered watchers
+func (ws *Watchers[E]) Len() int {
+	ws.rm.RLock()
+	defer ws.rm.RUnlock()
+	return len(ws.watchers)
+}
+
+// Dropped returns the number of events dropped for this watcher
+func (w *Watcher[E]) Dropped() uint64 {
+	return atomic.LoadUint64(&w.dropped)
+}
 
+// push appends an event to the watcher queue; it returns false if the watcher must be disconnected
+func (w *Watcher[E]) push(event E) bool {
+	w.cond.L.Lock()
+	defer w.cond.L.Unlock()
+	if w.closed {
+		return true
+	}
+	if w.buffer.Len() >= w.options.QueueSize {
+		if w.Dropped() == 0 {
+			log.Warnf("Watcher %s queue is full, applying the %s policy", w.id, w.options.Policy)
+		}
+		switch w.options.Policy {
+		case DropOldest:
+			w.buffer.Remove(w.buffer.Front())
+			atomic.AddUint64(&w.dropped, 1)
+		case DropNewest:
+			atomic.AddUint64(&w.dropped, 1)
+			return true
+		case Disconnect:
+			atomic.AddUint64(&w.dropped, 1)
+			return false
 		}
 	}
-	ws.watchers = watchers
-	ws.rm.Unlock()
-	return nil
+	w.buffer.PushBack(event)
+	w.cond.Signal()
+	return true
+}
+
+// next reads the next event from the queue or blocks until one becomes available
+func (w *Watcher[E]) next() (E, bool) {
+	w.cond.L.Lock()
+	defer w.cond.L.Unlock()
+	if len(w.replay) > 0 {
+		result := w.replay[0]
+		w.replay = w.replay[1:]
+		return result, true
+	}
+	for w.buffer.Len() == 0 {
+		if w.closed {
+			var empty E
+			return empty, false
+		}
+		w.cond.Wait()
+	}
+	result := w.buffer.Front().Value.(E)
+	w.buffer.Remove(w.buffer.Front())
+	return result, true
+}
+
+// drain dequeues events and writes them to the watcher channel
+func (w *Watcher[E]) drain() {
+	defer close(w.ch)
+	for {
+		e, ok := w.next()
+		if !ok {
+			return
+		}
+		select {
+		case w.ch <- e:
+		case <-w.done:
+			return
+		}
+	}
+}
 
+// close stops the watcher and discards pending events, which are counted as dropped; it returns the number of dropped events
+func (w *Watcher[E]) close() uint64 {
+	w.cond.L.Lock()
+	defer w.cond.L.Unlock()
+	if w.closed {
+		return 0
+	}
+	w.closed = true
+	// the pending events are never delivered
+	atomic.AddUint64(&w.dropped, uint64(w.buffer.Len()+len(w.replay)))
+	w.buffer.Init()
+	w.replay = nil
+	close(w.done)
+	w.cond.Broadcast()
+	return w.Dropped()
 }
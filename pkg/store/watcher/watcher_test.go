@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package watcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// waitHeld waits until the drain goroutine of a watcher holds an event and its queue is empty
+func waitHeld(t *testing.T, w *Watcher[int]) {
+	assert.Eventually(t, func() bool {
+		w.cond.L.Lock()
+		defer w.cond.L.Unlock()
+		return w.buffer.Len() == 0
+	}, time.Second, time.Millisecond)
+}
+
+func receive(ch <-chan int) []int {
+	received := make([]int, 0)
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return received
+			}
+			received = append(received, e)
+		case <-time.After(100 * time.Millisecond):
+			return received
+		}
+	}
+}
+
+func TestSlowConsumerPolicies(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       SlowConsumerPolicy
+		received     []int
+		dropped      uint64
+		watchers     int
+		chanClosed   bool
+		totalDropped uint64
+	}{
+		{
+			name:         "drop oldest",
+			policy:       DropOldest,
+			received:     []int{1, 4, 5},
+			dropped:      2,
+			watchers:     1,
+			totalDropped: 2,
+		},
+		{
+			name:         "drop newest",
+			policy:       DropNewest,
+			received:     []int{1, 2, 3},
+			dropped:      2,
+			watchers:     1,
+			totalDropped: 2,
+		},
+		{
+			name:       "disconnect",
+			policy:     Disconnect,
+			watchers:   0,
+			chanClosed: true,
+			// the event overflowing the queue and the two pending events
+			totalDropped: 3,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ws := NewWatchers[int](WithQueueSize(2), WithSlowConsumerPolicy(test.policy))
+			id := uuid.New()
+			ch := make(chan int)
+			assert.NoError(t, ws.AddWatcher(id, ch))
+			w := ws.watchers[id]
+
+			ws.Send(1)
+			waitHeld(t, w)
+			for i := 2; i <= 5; i++ {
+				ws.Send(i)
+			}
+			assert.Equal(t, test.watchers, ws.Len())
+			if !test.chanClosed {
+				assert.Equal(t, test.dropped, w.Dropped())
+			}
+			if test.chanClosed {
+				// the event held by the drain goroutine may still be delivered until the channel is closed
+				for range ch {
+				}
+			} else {
+				assert.Equal(t, test.received, receive(ch))
+			}
+			assert.Equal(t, test.totalDropped, ws.DroppedEvents())
+		})
+	}
+}
+
+func TestReplay(t *testing.T) {
+	ws := NewWatchers[int](WithQueueSize(1))
+	ch := make(chan int)
+	// the replay events are not bounded by the queue size
+	assert.NoError(t, ws.AddWatcher(uuid.New(), ch, 1, 2, 3))
+	ws.Send(4)
+	assert.Equal(t, []int{1, 2, 3, 4}, receive(ch))
+	assert.Equal(t, uint64(0), ws.DroppedEvents())
+}
+
+func TestRemoveWatcher(t *testing.T) {
+	ws := NewWatchers[int]()
+	id := uuid.New()
+	ch := make(chan int)
+	assert.NoError(t, ws.AddWatcher(id, ch))
+	assert.Error(t, ws.AddWatcher(id, make(chan int)))
+	w := ws.watchers[id]
+
+	ws.Send(1)
+	waitHeld(t, w)
+	ws.Send(2)
+	ws.Send(3)
+	assert.NoError(t, ws.RemoveWatcher(id))
+	assert.Error(t, ws.RemoveWatcher(id))
+	// the pending events are counted as dropped once the watcher is removed
+	assert.Equal(t, uint64(2), ws.DroppedEvents())
+	for range ch {
+	}
+	// the removed watchers no longer get the events
+	ws.Send(4)
+	assert.Equal(t, 0, ws.Len())
+}
+
+func TestAddWatcherWithPolicy(t *testing.T) {
+	ws := NewWatchers[int](WithQueueSize(1), WithSlowConsumerPolicy(DropOldest))
+	dropOldest := make(chan int)
+	assert.NoError(t, ws.AddWatcher(uuid.New(), dropOldest))
+	id := uuid.New()
+	disconnect := make(chan int)
+	assert.NoError(t, ws.AddWatcherWithPolicy(id, disconnect, Disconnect))
+	w := ws.watchers[id]
+
+	ws.Send(1)
+	waitHeld(t, w)
+	ws.Send(2)
+	ws.Send(3)
+	// the watcher with its own policy is disconnected while the other one drops its oldest event
+	assert.Equal(t, 1, ws.Len())
+	for range disconnect {
+	}
+	assert.Equal(t, []int{1, 3}, receive(dropOldest))
+}
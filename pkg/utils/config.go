@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+const (
+	// StaleReportPeriodsConfigPath number of report periods without update after which a cell measurement is stale
+	StaleReportPeriodsConfigPath = "/measurements/stale_report_periods"
+	// StalePolicyConfigPath policy applied to stale cell measurements, either "mark" or "evict"
+	StalePolicyConfigPath = "/measurements/stale_policy"
+	// MaxRecordsConfigPath maximum number of stored measurement records
+	MaxRecordsConfigPath = "/measurements/max_records"
+	// MaxBytesConfigPath maximum approximate memory used by the stored measurements
+	MaxBytesConfigPath = "/measurements/max_bytes"
+	// PriorityMeasurementsConfigPath comma separated measurement names evicted last, highest priority first
+	PriorityMeasurementsConfigPath = "/measurements/priority_measurements"
+	// ReplayBufferSizeConfigPath number of latest measurement events kept to resume the watches
+	ReplayBufferSizeConfigPath = "/measurements/replay_buffer_size"
+	// WatcherQueueSizeConfigPath maximum number of measurement events pending for each watcher
+	WatcherQueueSizeConfigPath = "/measurements/watcher_queue_size"
+	// SlowConsumerPolicyConfigPath policy applied when the queue of a watcher is full, either "drop_oldest", "drop_newest" or "disconnect"
+	SlowConsumerPolicyConfigPath = "/measurements/slow_consumer_policy"
+	// MetricsMaxSeriesConfigPath maximum number of measurement series exposed on the metrics endpoint
+	MetricsMaxSeriesConfigPath = "/metrics/max_series"
+	// ExportBatchSizeConfigPath maximum number of measurement events written at once to an export sink
+	ExportBatchSizeConfigPath = "/export/batch_size"
+	// ExportFlushIntervalConfigPath maximum number of milliseconds a measurement event waits for its export batch to fill up
+	ExportFlushIntervalConfigPath = "/export/flush_interval_ms"
+	// ExportBufferSizeConfigPath maximum number of measurement events waiting to be written to an export sink
+	ExportBufferSizeConfigPath = "/export/buffer_size"
+	// ExportMaxRetriesConfigPath number of times a failed write to an export sink is retried
+	ExportMaxRetriesConfigPath = "/export/max_retries"
+	// KafkaBrokersConfigPath comma separated addresses of the Kafka brokers the measurement updates are published to
+	KafkaBrokersConfigPath = "/export/kafka/brokers"
+	// KafkaTopicConfigPath Kafka topic all of the measurement updates are published to, whatever their measurements or event type
+	KafkaTopicConfigPath = "/export/kafka/topic"
+	// KafkaEncodingConfigPath encoding of the Kafka message values, either "json" or "protobuf"
+	KafkaEncodingConfigPath = "/export/kafka/encoding"
+	// InfluxURLConfigPath InfluxDB write URL the measurement records are written to in the line protocol
+	InfluxURLConfigPath = "/export/influx/url"
+	// InfluxTokenConfigPath InfluxDB API token
+	InfluxTokenConfigPath = "/export/influx/token"
+	// FileDirConfigPath directory the measurement records are recorded to
+	FileDirConfigPath = "/export/file/dir"
+	// FileFormatConfigPath format of the recorded files, either "json" for JSON lines or "csv"
+	FileFormatConfigPath = "/export/file/format"
+	// FileMaxSizeConfigPath size in megabytes after which a recorded file is rotated
+	FileMaxSizeConfigPath = "/export/file/max_size_mb"
+	// FileRotationIntervalConfigPath age in seconds after which a recorded file is rotated
+	FileRotationIntervalConfigPath = "/export/file/rotation_interval_seconds"
+	// FileCompressionConfigPath compression of the rotated files, either "gzip" or "none"
+	FileCompressionConfigPath = "/export/file/compression"
+	// FileMaxFilesConfigPath number of rotated files kept
+	FileMaxFilesConfigPath = "/export/file/max_files"
+	// WebhookURLsConfigPath comma separated URLs the KPI events are posted to
+	WebhookURLsConfigPath = "/webhooks/urls"
+	// WebhookSecretConfigPath key of the HMAC-SHA256 signature of the webhook payloads
+	WebhookSecretConfigPath = "/webhooks/secret"
+	// WebhookEventsConfigPath comma separated types of the KPI events posted to the webhooks
+	WebhookEventsConfigPath = "/webhooks/events"
+	// WebhookThresholdsConfigPath comma separated "name<operator>value" measurement thresholds whose crossings are posted to the webhooks
+	WebhookThresholdsConfigPath = "/webhooks/thresholds"
+	// WebhookRateLimitConfigPath maximum number of requests per minute to each webhook
+	WebhookRateLimitConfigPath = "/webhooks/rate_limit_per_minute"
+	// WebhookMaxRetriesConfigPath number of times a failed webhook request is retried
+	WebhookMaxRetriesConfigPath = "/webhooks/max_retries"
+	// ReplicaSetConfigPath comma separated "id=address" members of the replica set sharing the E2 nodes
+	ReplicaSetConfigPath = "/sharding/replicas"
+	// HistoryRetentionConfigPath number of seconds the measurement history is kept for
+	HistoryRetentionConfigPath = "/history/retention_seconds"
+	// HistoryMaxSamplesConfigPath maximum number of samples kept per measurement series
+	HistoryMaxSamplesConfigPath = "/history/max_samples"
+	// NodeAggregatesConfigPath comma separated "name:function" E2 node aggregates of the cell measurements written to topo
+	NodeAggregatesConfigPath = "/topo/node_aggregates"
+)
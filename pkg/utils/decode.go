@@ -4,7 +4,28 @@
 
 package utils
 
+import (
+	"encoding/hex"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// plmnIDHexLen is the length of the PLMN ID at the beginning of a hex encoded cell global ID
+const plmnIDHexLen = 6
+
 // DecodePlmnIDToUint32 decodes PLMN ID from byte array to uint32
 func DecodePlmnIDToUint32(plmnBytes []byte) uint32 {
 	return uint32(plmnBytes[0]) | uint32(plmnBytes[1])<<8 | uint32(plmnBytes[2])<<16
 }
+
+// DecodePlmnIDFromCellGlobalID decodes PLMN ID from a hex encoded cell global ID to uint32
+func DecodePlmnIDFromCellGlobalID(cellGlobalID string) (uint32, error) {
+	if len(cellGlobalID) <= plmnIDHexLen {
+		return 0, errors.NewInvalid("cell global ID %s is too short to hold a PLMN ID", cellGlobalID)
+	}
+	plmnBytes, err := hex.DecodeString(cellGlobalID[:plmnIDHexLen])
+	if err != nil {
+		return 0, errors.NewInvalid("cell global ID %s is not hex encoded", cellGlobalID)
+	}
+	return DecodePlmnIDToUint32(plmnBytes), nil
+}
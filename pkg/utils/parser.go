@@ -17,7 +17,7 @@ var log = logging.GetLogger("utils", "parser")
 func ParseEntry(entry *measurementStore.Entry) *kpimonapi.MeasurementItems {
 	var err error
 
-	measEntryItems := entry.Value.([]measurementStore.MeasurementItem)
+	measEntryItems := entry.Value
 	measItem := &kpimonapi.MeasurementItem{}
 	measItems := &kpimonapi.MeasurementItems{}
 	for _, entryItem := range measEntryItems {
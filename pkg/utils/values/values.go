@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package values provides the helpers shared by the consumers of the measurement values
+package values
+
+import "math"
+
+// ToFloat64 converts an integer or real measurement value; false is returned for the records without value
+func ToFloat64(value interface{}) (float64, bool) {
+	switch val := value.(type) {
+	case int64:
+		return float64(val), true
+	case float64:
+		return val, true
+	default:
+		return 0, false
+	}
+}
+
+// Sum sums the values
+func Sum(values []float64) float64 {
+	result := 0.0
+	for _, value := range values {
+		result += value
+	}
+	return result
+}
+
+// Avg averages the values; NaN is returned if there is no value
+func Avg(values []float64) float64 {
+	return Sum(values) / float64(len(values))
+}
+
+// Min gets the minimum value; +Inf is returned if there is no value
+func Min(values []float64) float64 {
+	result := math.Inf(1)
+	for _, value := range values {
+		result = math.Min(result, value)
+	}
+	return result
+}
+
+// Max gets the maximum value; -Inf is returned if there is no value
+func Max(values []float64) float64 {
+	result := math.Inf(-1)
+	for _, value := range values {
+		result = math.Max(result, value)
+	}
+	return result
+}
+
+// Contains returns true if the value is one of the values
+func Contains[T comparable](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package values
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToFloat64(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  float64
+		ok    bool
+	}{
+		{"integer", int64(3), 3, true},
+		{"real", 2.5, 2.5, true},
+		{"no value", int32(0), 0, false},
+		{"nil", nil, 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, ok := ToFloat64(test.value)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.want, value)
+		})
+	}
+}
+
+func TestFunctions(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		sum    float64
+		avg    float64
+		min    float64
+		max    float64
+	}{
+		{"values", []float64{2, -1, 5}, 6, 2, -1, 5},
+		{"single value", []float64{1.5}, 1.5, 1.5, 1.5, 1.5},
+		{"no value", nil, 0, math.NaN(), math.Inf(1), math.Inf(-1)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.sum, Sum(test.values))
+			if math.IsNaN(test.avg) {
+				assert.True(t, math.IsNaN(Avg(test.values)))
+			} else {
+				assert.Equal(t, test.avg, Avg(test.values))
+			}
+			assert.Equal(t, test.min, Min(test.values))
+			assert.Equal(t, test.max, Max(test.values))
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	assert.True(t, Contains([]string{"a", "b"}, "b"))
+	assert.False(t, Contains([]string{"a", "b"}, "c"))
+	assert.False(t, Contains(nil, uint32(1)))
+}
@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var droppedDesc = prometheus.NewDesc("kpimon_webhook_dropped_events_total",
+	"Number of webhook events dropped because the queue of an endpoint was full", nil, nil)
+
+// Describe describes the delivery metrics of the endpoints
+func (n *Notifier) Describe(ch chan<- *prometheus.Desc) {
+	ch <- droppedDesc
+}
+
+// Collect collects the delivery metrics of the endpoints
+func (n *Notifier) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(droppedDesc, prometheus.CounterValue, float64(n.DroppedEvents()))
+}
+
+var _ prometheus.Collector = &Notifier{}
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+const (
+	// EventHeader holds the type of the posted event
+	EventHeader = "X-Kpimon-Event"
+	// DeliveryHeader holds the ID of the posted event
+	DeliveryHeader = "X-Kpimon-Delivery"
+	// TimestampHeader holds the Unix time in seconds the request was signed at
+	TimestampHeader = "X-Kpimon-Timestamp"
+	// SignatureHeader holds the "sha256=<hex>" HMAC-SHA256 of "<timestamp>.<body>" keyed by the endpoint secret
+	SignatureHeader = "X-Kpimon-Signature"
+
+	userAgent = "onos-kpimon"
+	// maxErrorBody is the maximum number of bytes of an error response reported in the delivery error
+	maxErrorBody = 512
+)
+
+// Sign gets the signature of a payload signed at the given Unix time, as set in the signature header
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// encode encodes an event as JSON, keeping the threshold operators readable
+func encode(event Event) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(event); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// endpoint posts the events to a webhook URL, one request per event
+type endpoint struct {
+	url     string
+	options EndpointOptions
+	events  map[Type]bool
+	// interval is the minimum delay between two requests, which enforces the rate limit
+	interval time.Duration
+	lastSent time.Time
+}
+
+func (e *endpoint) accepts(eventType Type) bool {
+	return len(e.events) == 0 || e.events[eventType]
+}
+
+// run posts the events received on the channel until it is closed
+func (e *endpoint) run(ctx context.Context, ch <-chan Event) {
+	defer e.options.Client.CloseIdleConnections()
+	for event := range ch {
+		if !e.accepts(event.Type) {
+			continue
+		}
+		body, err := encode(event)
+		if err != nil {
+			log.Warnf("Failed to encode webhook event %s: %v", event.ID, err)
+			continue
+		}
+		e.deliver(ctx, event, body)
+	}
+}
+
+// deliver posts an event, retrying with an exponential backoff on the network errors and the retryable responses
+func (e *endpoint) deliver(ctx context.Context, event Event, body []byte) {
+	backoff := e.options.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		if !e.wait(ctx) {
+			return
+		}
+		retryable, err := e.post(ctx, event, body)
+		if err == nil {
+			log.Debugf("Posted webhook event %s %s to %s", event.Type, event.ID, e.url)
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if !retryable || attempt >= e.options.MaxRetries {
+			log.Warnf("Failed to post webhook event %s %s to %s after %d retries: %v", event.Type, event.ID, e.url, attempt, err)
+			return
+		}
+		log.Debugf("Retrying webhook event %s %s to %s in %s: %v", event.Type, event.ID, e.url, backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
+
+// wait waits until the next request is allowed by the rate limit
+func (e *endpoint) wait(ctx context.Context) bool {
+	delay := e.interval - time.Since(e.lastSent)
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return false
+		}
+	}
+	e.lastSent = time.Now()
+	return true
+}
+
+// post posts an event once; it returns whether a failed request may be retried
+func (e *endpoint) post(ctx context.Context, event Event, body []byte) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return false, errors.NewInvalid(err.Error())
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", userAgent)
+	request.Header.Set(EventHeader, string(event.Type))
+	request.Header.Set(DeliveryHeader, event.ID)
+	if e.options.Secret != "" {
+		// the signature covers the timestamp so that the receivers can reject the replayed requests
+		timestamp := time.Now().Unix()
+		request.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+		request.Header.Set(SignatureHeader, Sign(e.options.Secret, timestamp, body))
+	}
+
+	response, err := e.options.Client.Do(request)
+	if err != nil {
+		return true, errors.NewUnavailable(err.Error())
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		respBody, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBody))
+		err := errors.NewUnavailable("%s: %s", response.Status, strings.TrimSpace(string(respBody)))
+		// the other client errors are not fixed by retrying
+		retryable := response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests ||
+			response.StatusCode == http.StatusRequestTimeout
+		return retryable, err
+	}
+	// drain the body so that the connection is reused
+	_, _ = io.Copy(io.Discard, response.Body)
+	return false, nil
+}
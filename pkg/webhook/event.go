@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// Type is the type of a webhook event
+type Type string
+
+const (
+	// ThresholdCrossed is sent when a measurement value crosses a threshold into its breach
+	ThresholdCrossed Type = "threshold_crossed"
+	// NodeStoppedReporting is sent when none of the cells of an E2 node has fresh measurements anymore
+	NodeStoppedReporting Type = "node_stopped_reporting"
+	// SubscriptionFailed is sent when the KPM subscription of an E2 node fails
+	SubscriptionFailed Type = "subscription_failed"
+)
+
+// ParseType parses a webhook event type
+func ParseType(value string) (Type, error) {
+	switch t := Type(value); t {
+	case ThresholdCrossed, NodeStoppedReporting, SubscriptionFailed:
+		return t, nil
+	default:
+		return "", errors.NewInvalid("unknown webhook event type %s", value)
+	}
+}
+
+// Event is the JSON payload posted to the webhook endpoints
+type Event struct {
+	// ID identifies the event; the retries of a delivery carry the same ID so that the receivers can deduplicate them
+	ID           string    `json:"id"`
+	Type         Type      `json:"type"`
+	Time         time.Time `json:"time"`
+	NodeID       string    `json:"node_id"`
+	CellObjectID string    `json:"cell_object_id,omitempty"`
+	CellGlobalID string    `json:"cell_global_id,omitempty"`
+	// Measurement, Labels, Value and Threshold are set for the threshold events
+	Measurement string            `json:"measurement,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Value       *float64          `json:"value,omitempty"`
+	Threshold   *Threshold        `json:"threshold,omitempty"`
+	// Error is set for the subscription failures
+	Error string `json:"error,omitempty"`
+}
+
+func newEvent(eventType Type, nodeID string) Event {
+	return Event{
+		ID:     uuid.New().String(),
+		Type:   eventType,
+		Time:   time.Now().UTC(),
+		NodeID: nodeID,
+	}
+}
+
+// NewSubscriptionFailedEvent creates the event of a failed subscription to an E2 node
+func NewSubscriptionFailedEvent(nodeID string, err error) Event {
+	e := newEvent(SubscriptionFailed, nodeID)
+	if err != nil {
+		e.Error = err.Error()
+	}
+	return e
+}
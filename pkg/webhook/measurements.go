@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/generic"
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	valueutils "github.com/onosproject/onos-kpimon/pkg/utils/values"
+)
+
+// tracker finds the threshold crossings and the E2 nodes that stop reporting in the measurement store events
+type tracker struct {
+	thresholds []Threshold
+	// breaches holds the breached thresholds of the latest value of each series of each cell,
+	// keyed by the series key and the threshold index
+	breaches map[measurements.Key]map[breachKey]bool
+	// reporting holds the E2 nodes with fresh measurements
+	reporting map[string]*node
+	// silenceTimeout returns the duration without report after which a node has stopped reporting
+	silenceTimeout func() time.Duration
+}
+
+// node is an E2 node with fresh measurements
+type node struct {
+	// cells are the cells of the node with fresh measurements
+	cells map[string]struct{}
+	// reportedAt is the update time of the latest measurements of the node
+	reportedAt time.Time
+}
+
+type breachKey struct {
+	series    string
+	threshold int
+}
+
+func newTracker(thresholds []Threshold, silenceTimeout func() time.Duration) *tracker {
+	if silenceTimeout == nil {
+		silenceTimeout = func() time.Duration {
+			return 0
+		}
+	}
+	return &tracker{
+		thresholds:     thresholds,
+		breaches:       make(map[measurements.Key]map[breachKey]bool),
+		reporting:      make(map[string]*node),
+		silenceTimeout: silenceTimeout,
+	}
+}
+
+// watchMeasurements publishes the events found in the measurement store until the context is done
+// The current entries are replayed first to learn the current state without publishing it.
+func (n *Notifier) watchMeasurements(ctx context.Context, store measurements.Store) error {
+	ch := make(chan measurements.Event)
+	err := store.Watch(ctx, ch, generic.WithReplay())
+	if err != nil {
+		return err
+	}
+	t := newTracker(n.options.Thresholds, n.options.SilenceTimeout)
+	go func() {
+		for event := range ch {
+			for _, e := range t.handle(event) {
+				n.Publish(e)
+			}
+		}
+	}()
+	return nil
+}
+
+// handle updates the state with a measurement store event and gets the events to publish
+func (t *tracker) handle(event measurements.Event) []Event {
+	var events []Event
+	switch event.Type {
+	case measurements.Resync:
+		// the current entries follow as None events
+		t.breaches = make(map[measurements.Key]map[breachKey]bool)
+		t.reporting = make(map[string]*node)
+	case measurements.None:
+		if !event.Value.Stale {
+			t.checkThresholds(event.Value)
+			t.report(event.Value)
+		}
+	case measurements.Created, measurements.Updated:
+		if event.Value.Stale {
+			// the entries are only marked stale once they are not updated within the stale TTL
+			delete(t.breaches, event.Key)
+			if t.stopReporting(event.Key) != nil {
+				events = append(events, newNodeStoppedReportingEvent(event.Key.NodeID))
+			}
+			break
+		}
+		events = append(events, t.checkThresholds(event.Value)...)
+		t.report(event.Value)
+	case measurements.Deleted:
+		// the entries are also deleted while their node is still reporting, when the budget evicts all of their
+		// records or when the node moves to another shard, so only the nodes silent for long enough are notified
+		delete(t.breaches, event.Key)
+		if n := t.stopReporting(event.Key); n != nil && time.Since(n.reportedAt) >= t.silenceTimeout() {
+			events = append(events, newNodeStoppedReportingEvent(event.Key.NodeID))
+		}
+	}
+	return events
+}
+
+// checkThresholds updates the breached thresholds of the latest values of an entry and gets the new breaches
+func (t *tracker) checkThresholds(entry *measurements.Entry) []Event {
+	if len(t.thresholds) == 0 {
+		return nil
+	}
+	previous := t.breaches[entry.Key]
+	breaches := make(map[breachKey]bool)
+	var events []Event
+	for series, record := range latestRecords(entry) {
+		value, ok := valueutils.ToFloat64(record.MeasurementValue)
+		if !ok {
+			continue
+		}
+		for i, threshold := range t.thresholds {
+			if threshold.Measurement != record.MeasurementName || !threshold.Breached(value) {
+				continue
+			}
+			key := breachKey{series: series, threshold: i}
+			breaches[key] = true
+			if !previous[key] {
+				events = append(events, newThresholdCrossedEvent(entry, record, value, threshold))
+			}
+		}
+	}
+	t.breaches[entry.Key] = breaches
+	return events
+}
+
+func (t *tracker) report(entry *measurements.Entry) {
+	n, ok := t.reporting[entry.Key.NodeID]
+	if !ok {
+		n = &node{cells: make(map[string]struct{})}
+		t.reporting[entry.Key.NodeID] = n
+	}
+	n.cells[entry.Key.CellIdentity.CellID] = struct{}{}
+	if entry.UpdatedAt.After(n.reportedAt) {
+		n.reportedAt = entry.UpdatedAt
+	}
+}
+
+// stopReporting removes a cell from the reporting cells of its E2 node; it returns the node if it was its last cell
+func (t *tracker) stopReporting(key measurements.Key) *node {
+	n, ok := t.reporting[key.NodeID]
+	if !ok {
+		return nil
+	}
+	if _, ok := n.cells[key.CellIdentity.CellID]; !ok {
+		return nil
+	}
+	delete(n.cells, key.CellIdentity.CellID)
+	if len(n.cells) > 0 {
+		return nil
+	}
+	delete(t.reporting, key.NodeID)
+	return n
+}
+
+func newThresholdCrossedEvent(entry *measurements.Entry, record measurements.MeasurementRecord, value float64, threshold Threshold) Event {
+	e := newEvent(ThresholdCrossed, entry.Key.NodeID)
+	e.CellObjectID = entry.Key.CellIdentity.CellID
+	e.CellGlobalID = entry.CellGlobalID
+	e.Measurement = record.MeasurementName
+	e.Labels = record.Labels
+	e.Value = &value
+	e.Threshold = &threshold
+	return e
+}
+
+func newNodeStoppedReportingEvent(nodeID string) Event {
+	return newEvent(NodeStoppedReporting, nodeID)
+}
+
+// latestRecords gets the latest record of each series of an entry
+func latestRecords(entry *measurements.Entry) map[string]measurements.MeasurementRecord {
+	latest := make(map[string]measurements.MeasurementRecord)
+	for _, measItem := range entry.Value {
+		for _, record := range measItem.MeasurementRecords {
+			key := record.SeriesKey()
+			if current, ok := latest[key]; !ok || current.Timestamp <= record.Timestamp {
+				latest[key] = record
+			}
+		}
+	}
+	return latest
+}
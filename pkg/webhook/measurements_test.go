@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/stretchr/testify/assert"
+)
+
+// published is the type, E2 node, cell and value of a published event
+type published struct {
+	eventType Type
+	nodeID    string
+	cellID    string
+	value     float64
+}
+
+func newTestEvent(eventType measurements.MeasurementEvent, nodeID string, cellID string, stale bool, records ...measurements.MeasurementRecord) measurements.Event {
+	key := measurements.NewKey(measurements.CellIdentity{CellID: cellID}, nodeID)
+	return measurements.Event{
+		Key:  key,
+		Type: eventType,
+		Value: &measurements.Entry{
+			Key:       key,
+			Value:     []measurements.MeasurementItem{{MeasurementRecords: records}},
+			UpdatedAt: time.Now(),
+			Stale:     stale,
+		},
+	}
+}
+
+// reportedAgo sets the update time of the entry of an event to the given duration ago
+func reportedAgo(event measurements.Event, d time.Duration) measurements.Event {
+	event.Value.UpdatedAt = time.Now().Add(-d)
+	return event
+}
+
+func newTestRecord(timestamp uint64, name string, value interface{}, labels map[string]string) measurements.MeasurementRecord {
+	return measurements.MeasurementRecord{Timestamp: timestamp, MeasurementName: name, MeasurementValue: value, Labels: labels}
+}
+
+func TestTracker(t *testing.T) {
+	thresholds := []Threshold{
+		{Measurement: "A", Operator: Above, Value: 10},
+		{Measurement: "A", Operator: Above, Value: 100},
+		{Measurement: "B", Operator: BelowOrEqual, Value: 0},
+	}
+	steps := []struct {
+		name      string
+		event     measurements.Event
+		published []published
+	}{
+		{
+			name:  "within the thresholds",
+			event: newTestEvent(measurements.Created, "e2:1", "1", false, newTestRecord(1, "A", int64(5), nil)),
+		},
+		{
+			name:      "crossed",
+			event:     newTestEvent(measurements.Updated, "e2:1", "1", false, newTestRecord(2, "A", int64(50), nil)),
+			published: []published{{ThresholdCrossed, "e2:1", "1", 50}},
+		},
+		{
+			name:  "still breached",
+			event: newTestEvent(measurements.Updated, "e2:1", "1", false, newTestRecord(3, "A", 60.5, nil)),
+		},
+		{
+			name:      "second threshold crossed",
+			event:     newTestEvent(measurements.Updated, "e2:1", "1", false, newTestRecord(4, "A", int64(500), nil)),
+			published: []published{{ThresholdCrossed, "e2:1", "1", 500}},
+		},
+		{
+			name:  "back within the thresholds",
+			event: newTestEvent(measurements.Updated, "e2:1", "1", false, newTestRecord(5, "A", int64(1), nil)),
+		},
+		{
+			name: "latest record of each series",
+			event: newTestEvent(measurements.Updated, "e2:1", "1", false,
+				newTestRecord(7, "A", int64(20), nil),
+				newTestRecord(6, "A", int64(1), nil),
+				newTestRecord(6, "B", int64(0), map[string]string{"slice_id": "1"}),
+				newTestRecord(6, "B", int64(1), map[string]string{"slice_id": "2"})),
+			published: []published{{ThresholdCrossed, "e2:1", "1", 20}, {ThresholdCrossed, "e2:1", "1", 0}},
+		},
+		{
+			name:  "records without value",
+			event: newTestEvent(measurements.Updated, "e2:1", "2", false, newTestRecord(7, "B", int32(0), nil)),
+		},
+		{
+			name:  "other cell of the E2 node stale",
+			event: newTestEvent(measurements.Updated, "e2:1", "2", true, newTestRecord(7, "B", int32(0), nil)),
+		},
+		{
+			name:  "last cell of the E2 node deleted while reporting",
+			event: newTestEvent(measurements.Deleted, "e2:1", "1", false),
+		},
+		{
+			name:      "breach of a deleted cell crossed again",
+			event:     newTestEvent(measurements.Created, "e2:1", "1", false, newTestRecord(8, "A", int64(20), nil)),
+			published: []published{{ThresholdCrossed, "e2:1", "1", 20}},
+		},
+		{
+			name:  "resync",
+			event: measurements.Event{Type: measurements.Resync},
+		},
+		{
+			name:  "replayed breach not published",
+			event: newTestEvent(measurements.None, "e2:1", "1", false, newTestRecord(8, "A", int64(20), nil)),
+		},
+		{
+			name:  "replayed breach still breached",
+			event: newTestEvent(measurements.Updated, "e2:1", "1", false, newTestRecord(9, "A", int64(30), nil)),
+		},
+		{
+			name:      "stale cell",
+			event:     newTestEvent(measurements.Updated, "e2:1", "1", true, newTestRecord(9, "A", int64(30), nil)),
+			published: []published{{NodeStoppedReporting, "e2:1", "", 0}},
+		},
+		{
+			name:  "silent E2 node",
+			event: reportedAgo(newTestEvent(measurements.Created, "e2:2", "1", false, newTestRecord(1, "A", int64(1), nil)), 2*time.Hour),
+		},
+		{
+			name:      "last cell of the silent E2 node deleted",
+			event:     reportedAgo(newTestEvent(measurements.Deleted, "e2:2", "1", false), 2*time.Hour),
+			published: []published{{NodeStoppedReporting, "e2:2", "", 0}},
+		},
+	}
+
+	tracker := newTracker(thresholds, func() time.Duration {
+		return time.Hour
+	})
+	for _, step := range steps {
+		events := tracker.handle(step.event)
+		actual := make([]published, 0, len(events))
+		for _, e := range events {
+			p := published{eventType: e.Type, nodeID: e.NodeID, cellID: e.CellObjectID}
+			if e.Value != nil {
+				p.value = *e.Value
+			}
+			actual = append(actual, p)
+		}
+		assert.ElementsMatch(t, step.published, actual, step.name)
+	}
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/onosproject/onos-kpimon/pkg/store/watcher"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+)
+
+var log = logging.GetLogger()
+
+const (
+	defaultQueueSize    = 1000
+	defaultRateLimit    = 1
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = time.Second
+	defaultTimeout      = 10 * time.Second
+	maxRetryBackoff     = time.Minute
+)
+
+// Notifier posts the KPI events to a set of webhook endpoints
+// The events are published to the watchers of the endpoints, so that each endpoint has its own queue and delivery
+// loop: a slow or failing endpoint does not hold back the others, and the oldest events are dropped once its queue is full.
+type Notifier struct {
+	options   Options
+	watchers  *watcher.Watchers[Event]
+	mu        sync.RWMutex
+	endpoints []*endpoint
+}
+
+// NewNotifier creates a new notifier
+func NewNotifier(opts ...Option) *Notifier {
+	options := Options{
+		QueueSize: defaultQueueSize,
+	}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	return &Notifier{
+		options:  options,
+		watchers: watcher.NewWatchers[Event](watcher.WithQueueSize(options.QueueSize), watcher.WithSlowConsumerPolicy(watcher.DropOldest)),
+	}
+}
+
+// AddEndpoint adds a webhook endpoint; the endpoints are added before Run
+func (n *Notifier) AddEndpoint(endpointURL string, opts ...EndpointOption) error {
+	options := EndpointOptions{
+		RateLimit:    defaultRateLimit,
+		MaxRetries:   defaultMaxRetries,
+		RetryBackoff: defaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	if options.RateLimit <= 0 {
+		options.RateLimit = defaultRateLimit
+	}
+	if options.MaxRetries < 0 {
+		options.MaxRetries = 0
+	}
+	if options.Client == nil {
+		options.Client = &http.Client{
+			Timeout: defaultTimeout,
+		}
+	}
+	u, err := url.Parse(endpointURL)
+	if err != nil {
+		return errors.NewInvalid("invalid webhook URL %s: %v", endpointURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NewInvalid("invalid webhook URL %s: the scheme is not http or https", endpointURL)
+	}
+	events := make(map[Type]bool, len(options.Events))
+	for _, eventType := range options.Events {
+		events[eventType] = true
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, e := range n.endpoints {
+		if e.url == endpointURL {
+			return errors.NewAlreadyExists("webhook endpoint %s already exists", endpointURL)
+		}
+	}
+	n.endpoints = append(n.endpoints, &endpoint{
+		url:      endpointURL,
+		options:  options,
+		events:   events,
+		interval: time.Duration(float64(time.Second) / options.RateLimit),
+	})
+	return nil
+}
+
+// Len returns the number of endpoints
+func (n *Notifier) Len() int {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return len(n.endpoints)
+}
+
+// Publish queues an event for all of the endpoints accepting its type; it never blocks
+func (n *Notifier) Publish(event Event) {
+	log.Debugf("Publishing webhook event %s of E2 node %s", event.Type, event.NodeID)
+	n.watchers.Send(event)
+}
+
+// DroppedEvents returns the number of events dropped from the queues of the endpoints
+func (n *Notifier) DroppedEvents() uint64 {
+	return n.watchers.DroppedEvents()
+}
+
+// Run starts the delivery loops of the endpoints and publishes the threshold crossings and the E2 nodes
+// that stop reporting found in the measurement store until the context is done
+func (n *Notifier) Run(ctx context.Context, store measurements.Store) error {
+	n.mu.RLock()
+	endpoints := n.endpoints
+	n.mu.RUnlock()
+
+	ids := make([]uuid.UUID, 0, len(endpoints))
+	for _, e := range endpoints {
+		id := uuid.New()
+		ch := make(chan Event)
+		if err := n.watchers.AddWatcher(id, ch); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		go e.run(ctx, ch)
+	}
+	go func() {
+		<-ctx.Done()
+		for _, id := range ids {
+			if err := n.watchers.RemoveWatcher(id); err != nil {
+				log.Warn(err)
+			}
+		}
+	}()
+
+	return n.watchMeasurements(ctx, store)
+}
@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-kpimon/pkg/store/measurements"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// request is a request received by the test endpoint
+type request struct {
+	header http.Header
+	body   []byte
+	event  Event
+}
+
+// testEndpoint is a webhook endpoint answering with the scripted statuses, then with 204
+type testEndpoint struct {
+	server   *httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	requests chan request
+	// release blocks the responses until it is closed, if set
+	release chan struct{}
+}
+
+func newTestEndpoint(t *testing.T, statuses ...int) *testEndpoint {
+	e := &testEndpoint{
+		statuses: statuses,
+		requests: make(chan request, 100),
+	}
+	e.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var event Event
+		assert.NoError(t, json.Unmarshal(body, &event))
+		e.requests <- request{header: r.Header, body: body, event: event}
+		if e.release != nil {
+			<-e.release
+		}
+		w.WriteHeader(e.nextStatus())
+	}))
+	t.Cleanup(e.server.Close)
+	return e
+}
+
+func (e *testEndpoint) nextStatus() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.statuses) == 0 {
+		return http.StatusNoContent
+	}
+	status := e.statuses[0]
+	e.statuses = e.statuses[1:]
+	return status
+}
+
+func (e *testEndpoint) nextRequests(t *testing.T, n int) []request {
+	requests := make([]request, 0, n)
+	for i := 0; i < n; i++ {
+		select {
+		case r := <-e.requests:
+			requests = append(requests, r)
+		case <-time.After(time.Second):
+			t.Fatalf("received %d of %d requests", i, n)
+		}
+	}
+	return requests
+}
+
+func (e *testEndpoint) assertNoRequest(t *testing.T) {
+	select {
+	case r := <-e.requests:
+		t.Fatalf("unexpected request %s", r.body)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func startNotifier(t *testing.T, ctx context.Context, notifier *Notifier) measurements.Store {
+	store := measurements.NewStore()
+	assert.NoError(t, notifier.Run(ctx, store))
+	return store
+}
+
+func TestAddEndpoint(t *testing.T) {
+	notifier := NewNotifier()
+	assert.NoError(t, notifier.AddEndpoint("http://receiver/hooks"))
+	assert.Error(t, notifier.AddEndpoint("http://receiver/hooks"))
+	assert.Error(t, notifier.AddEndpoint("receiver/hooks"))
+	assert.Equal(t, 1, notifier.Len())
+}
+
+func TestSignature(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	endpoint := newTestEndpoint(t)
+	notifier := NewNotifier()
+	assert.NoError(t, notifier.AddEndpoint(endpoint.server.URL, WithSecret("secret"), WithRateLimit(1000)))
+	startNotifier(t, ctx, notifier)
+
+	event := NewSubscriptionFailedEvent("e2:1", errors.New("no KPM report style"))
+	notifier.Publish(event)
+	r := endpoint.nextRequests(t, 1)[0]
+	assert.Equal(t, event.ID, r.event.ID)
+	assert.Equal(t, "no KPM report style", r.event.Error)
+	assert.Equal(t, "application/json", r.header.Get("Content-Type"))
+	assert.Equal(t, string(SubscriptionFailed), r.header.Get(EventHeader))
+	assert.Equal(t, event.ID, r.header.Get(DeliveryHeader))
+
+	// the signature is the HMAC of the timestamp and the body keyed by the secret
+	timestamp, err := strconv.ParseInt(r.header.Get(TimestampHeader), 10, 64)
+	assert.NoError(t, err)
+	assert.InDelta(t, time.Now().Unix(), timestamp, 5)
+	assert.Equal(t, Sign("secret", timestamp, r.body), r.header.Get(SignatureHeader))
+	assert.NotEqual(t, Sign("other", timestamp, r.body), r.header.Get(SignatureHeader))
+	assert.NotEqual(t, Sign("secret", timestamp+1, r.body), r.header.Get(SignatureHeader))
+}
+
+func TestUnsigned(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	endpoint := newTestEndpoint(t)
+	notifier := NewNotifier()
+	assert.NoError(t, notifier.AddEndpoint(endpoint.server.URL, WithRateLimit(1000)))
+	startNotifier(t, ctx, notifier)
+
+	notifier.Publish(NewSubscriptionFailedEvent("e2:1", nil))
+	r := endpoint.nextRequests(t, 1)[0]
+	assert.Equal(t, "", r.header.Get(TimestampHeader))
+	assert.Equal(t, "", r.header.Get(SignatureHeader))
+}
+
+func TestEventFilter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	all := newTestEndpoint(t)
+	thresholds := newTestEndpoint(t)
+	notifier := NewNotifier(WithThresholds(Threshold{Measurement: "A", Operator: Above, Value: 10}))
+	assert.NoError(t, notifier.AddEndpoint(all.server.URL, WithRateLimit(1000)))
+	assert.NoError(t, notifier.AddEndpoint(thresholds.server.URL, WithRateLimit(1000), WithEvents(ThresholdCrossed)))
+	store := startNotifier(t, ctx, notifier)
+
+	notifier.Publish(NewSubscriptionFailedEvent("e2:1", nil))
+	key := measurements.NewKey(measurements.CellIdentity{CellID: "1"}, "e2:1")
+	_, err := store.Put(ctx, key, []measurements.MeasurementItem{{
+		MeasurementRecords: []measurements.MeasurementRecord{newTestRecord(1, "A", int64(20), nil)},
+	}})
+	assert.NoError(t, err)
+
+	requests := all.nextRequests(t, 2)
+	assert.Equal(t, SubscriptionFailed, requests[0].event.Type)
+	assert.Equal(t, ThresholdCrossed, requests[1].event.Type)
+	r := thresholds.nextRequests(t, 1)[0]
+	assert.Equal(t, ThresholdCrossed, r.event.Type)
+	assert.Equal(t, "1", r.event.CellObjectID)
+	assert.Equal(t, "A", r.event.Measurement)
+	assert.Equal(t, 20.0, *r.event.Value)
+	assert.Equal(t, Threshold{Measurement: "A", Operator: Above, Value: 10}, *r.event.Threshold)
+	thresholds.assertNoRequest(t)
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		requests int
+	}{
+		{
+			name:     "delivered",
+			requests: 1,
+		},
+		{
+			name:     "server error retried",
+			statuses: []int{http.StatusServiceUnavailable, http.StatusInternalServerError},
+			requests: 3,
+		},
+		{
+			name:     "rate limited retried",
+			statuses: []int{http.StatusTooManyRequests},
+			requests: 2,
+		},
+		{
+			name:     "client error not retried",
+			statuses: []int{http.StatusBadRequest},
+			requests: 1,
+		},
+		{
+			name:     "retries exhausted",
+			statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			requests: 3,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			endpoint := newTestEndpoint(t, test.statuses...)
+			notifier := NewNotifier()
+			assert.NoError(t, notifier.AddEndpoint(endpoint.server.URL,
+				WithRateLimit(1000), WithMaxRetries(2), WithRetryBackoff(time.Millisecond)))
+			startNotifier(t, ctx, notifier)
+
+			event := NewSubscriptionFailedEvent("e2:1", nil)
+			notifier.Publish(event)
+			// the retries carry the ID of the event
+			for _, r := range endpoint.nextRequests(t, test.requests) {
+				assert.Equal(t, event.ID, r.header.Get(DeliveryHeader))
+			}
+			endpoint.assertNoRequest(t)
+		})
+	}
+}
+
+func TestDroppedEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	endpoint := newTestEndpoint(t)
+	endpoint.release = make(chan struct{})
+	defer close(endpoint.release)
+	notifier := NewNotifier(WithQueueSize(1))
+	assert.NoError(t, notifier.AddEndpoint(endpoint.server.URL, WithRateLimit(1000)))
+	startNotifier(t, ctx, notifier)
+	assert.Equal(t, 0.0, testutil.ToFloat64(notifier))
+
+	// the oldest events are dropped while the endpoint is blocked on the first one
+	notifier.Publish(NewSubscriptionFailedEvent("e2:1", nil))
+	endpoint.nextRequests(t, 1)
+	for i := 0; i < 5; i++ {
+		notifier.Publish(NewSubscriptionFailedEvent("e2:1", nil))
+	}
+	assert.Eventually(t, func() bool {
+		return notifier.DroppedEvents() > 0
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, float64(notifier.DroppedEvents()), testutil.ToFloat64(notifier))
+}
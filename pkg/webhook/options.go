@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"net/http"
+	"time"
+)
+
+// Options notifier options
+type Options struct {
+	// Thresholds are the measurement thresholds whose crossings are notified
+	Thresholds []Threshold
+	// QueueSize is the maximum number of events waiting to be posted to an endpoint; the oldest are dropped beyond it
+	QueueSize int
+	// SilenceTimeout returns the duration without report after which an E2 node whose cells are deleted has stopped reporting;
+	// the deletions of the cells of the nodes that reported within it, e.g. on a budget eviction or a shard rebalance, are not notified
+	SilenceTimeout func() time.Duration
+}
+
+// Option notifier option interface
+type Option interface {
+	apply(*Options)
+}
+
+type funcOption struct {
+	f func(*Options)
+}
+
+func (f funcOption) apply(options *Options) {
+	f.f(options)
+}
+
+func newOption(f func(*Options)) Option {
+	return funcOption{
+		f: f,
+	}
+}
+
+// WithThresholds sets the measurement thresholds whose crossings are notified
+func WithThresholds(thresholds ...Threshold) Option {
+	return newOption(func(options *Options) {
+		options.Thresholds = append(options.Thresholds, thresholds...)
+	})
+}
+
+// WithQueueSize sets the maximum number of events waiting to be posted to an endpoint
+func WithQueueSize(size int) Option {
+	return newOption(func(options *Options) {
+		options.QueueSize = size
+	})
+}
+
+// WithSilenceTimeout sets the duration without report after which an E2 node whose cells are deleted has stopped reporting
+func WithSilenceTimeout(timeout func() time.Duration) Option {
+	return newOption(func(options *Options) {
+		options.SilenceTimeout = timeout
+	})
+}
+
+// EndpointOptions webhook endpoint options
+type EndpointOptions struct {
+	// Secret is the key of the HMAC-SHA256 signature of the payloads; the payloads are not signed without it
+	Secret string
+	// Events are the event types posted to the endpoint; all of them are posted if empty
+	Events []Type
+	// RateLimit is the maximum number of requests per second to the endpoint, retries included
+	RateLimit float64
+	// MaxRetries is the number of times a failed delivery is retried before the event is given up on
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, doubled on each retry
+	RetryBackoff time.Duration
+	// Client is the HTTP client posting the events
+	Client *http.Client
+}
+
+// EndpointOption webhook endpoint option interface
+type EndpointOption interface {
+	apply(*EndpointOptions)
+}
+
+type funcEndpointOption struct {
+	f func(*EndpointOptions)
+}
+
+func (f funcEndpointOption) apply(options *EndpointOptions) {
+	f.f(options)
+}
+
+func newEndpointOption(f func(*EndpointOptions)) EndpointOption {
+	return funcEndpointOption{
+		f: f,
+	}
+}
+
+// WithSecret sets the key of the HMAC-SHA256 signature of the payloads
+func WithSecret(secret string) EndpointOption {
+	return newEndpointOption(func(options *EndpointOptions) {
+		options.Secret = secret
+	})
+}
+
+// WithEvents sets the event types posted to the endpoint
+func WithEvents(events ...Type) EndpointOption {
+	return newEndpointOption(func(options *EndpointOptions) {
+		options.Events = append(options.Events, events...)
+	})
+}
+
+// WithRateLimit sets the maximum number of requests per second to the endpoint
+func WithRateLimit(rate float64) EndpointOption {
+	return newEndpointOption(func(options *EndpointOptions) {
+		options.RateLimit = rate
+	})
+}
+
+// WithMaxRetries sets the number of times a failed delivery is retried
+func WithMaxRetries(maxRetries int) EndpointOption {
+	return newEndpointOption(func(options *EndpointOptions) {
+		options.MaxRetries = maxRetries
+	})
+}
+
+// WithRetryBackoff sets the delay before the first retry
+func WithRetryBackoff(backoff time.Duration) EndpointOption {
+	return newEndpointOption(func(options *EndpointOptions) {
+		options.RetryBackoff = backoff
+	})
+}
+
+// WithClient sets the HTTP client posting the events
+func WithClient(client *http.Client) EndpointOption {
+	return newEndpointOption(func(options *EndpointOptions) {
+		options.Client = client
+	})
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// Operator is the comparison of a threshold
+type Operator string
+
+const (
+	// Above is breached by the values greater than the threshold
+	Above Operator = ">"
+	// AboveOrEqual is breached by the values greater than or equal to the threshold
+	AboveOrEqual Operator = ">="
+	// Below is breached by the values less than the threshold
+	Below Operator = "<"
+	// BelowOrEqual is breached by the values less than or equal to the threshold
+	BelowOrEqual Operator = "<="
+)
+
+// Threshold is a threshold of a measurement
+type Threshold struct {
+	Measurement string   `json:"measurement"`
+	Operator    Operator `json:"operator"`
+	Value       float64  `json:"value"`
+}
+
+// Breached returns whether a value breaches the threshold
+func (t Threshold) Breached(value float64) bool {
+	switch t.Operator {
+	case Above:
+		return value > t.Value
+	case AboveOrEqual:
+		return value >= t.Value
+	case Below:
+		return value < t.Value
+	case BelowOrEqual:
+		return value <= t.Value
+	}
+	return false
+}
+
+func (t Threshold) String() string {
+	return t.Measurement + string(t.Operator) + strconv.FormatFloat(t.Value, 'g', -1, 64)
+}
+
+// ParseThresholds parses comma separated "name<operator>value" thresholds, e.g. "RRC.ConnEstabSucc.Sum<10,DRB.UEThpDl>=1e6";
+// the operators are >, >=, < and <=
+func ParseThresholds(thresholds string) ([]Threshold, error) {
+	var result []Threshold
+	for _, threshold := range strings.Split(thresholds, ",") {
+		threshold = strings.TrimSpace(threshold)
+		if threshold == "" {
+			continue
+		}
+		i := strings.IndexAny(threshold, "<>")
+		if i <= 0 {
+			return nil, errors.NewInvalid("invalid threshold %s: expected name<operator>value", threshold)
+		}
+		operator := Operator(threshold[i : i+1])
+		j := i + 1
+		if strings.HasPrefix(threshold[j:], "=") {
+			operator += "="
+			j++
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(threshold[j:]), 64)
+		if err != nil {
+			return nil, errors.NewInvalid("invalid threshold %s: %v", threshold, err)
+		}
+		result = append(result, Threshold{
+			Measurement: strings.TrimSpace(threshold[:i]),
+			Operator:    operator,
+			Value:       value,
+		})
+	}
+	return result, nil
+}
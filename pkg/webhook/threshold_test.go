@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseThresholds(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		thresholds []Threshold
+		isError    func(error) bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "operators",
+			value: "A>1, B >= 2,,C<-3.5,D<=1e6",
+			thresholds: []Threshold{
+				{Measurement: "A", Operator: Above, Value: 1},
+				{Measurement: "B", Operator: AboveOrEqual, Value: 2},
+				{Measurement: "C", Operator: Below, Value: -3.5},
+				{Measurement: "D", Operator: BelowOrEqual, Value: 1e6},
+			},
+		},
+		{
+			name:    "no operator",
+			value:   "A=1",
+			isError: errors.IsInvalid,
+		},
+		{
+			name:    "no name",
+			value:   ">1",
+			isError: errors.IsInvalid,
+		},
+		{
+			name:    "invalid value",
+			value:   "A>high",
+			isError: errors.IsInvalid,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			thresholds, err := ParseThresholds(test.value)
+			if test.isError != nil {
+				assert.True(t, test.isError(err), err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.thresholds, thresholds)
+		})
+	}
+}
+
+func TestBreached(t *testing.T) {
+	tests := []struct {
+		operator Operator
+		breached []bool
+	}{
+		{Above, []bool{false, false, true}},
+		{AboveOrEqual, []bool{false, true, true}},
+		{Below, []bool{true, false, false}},
+		{BelowOrEqual, []bool{true, true, false}},
+	}
+	for _, test := range tests {
+		t.Run(string(test.operator), func(t *testing.T) {
+			threshold := Threshold{Measurement: "A", Operator: test.operator, Value: 10}
+			for i, value := range []float64{9, 10, 11} {
+				assert.Equal(t, test.breached[i], threshold.Breached(value), "%s %g", threshold, value)
+			}
+		})
+	}
+}
@@ -30,7 +30,8 @@ func (s *TestSuite) TestKpmSm(t *testing.T) {
 	_, err := certs.HandleCertPaths(cfg.CAPath, cfg.KeyPath, cfg.CertPath, true)
 	assert.NoError(t, err)
 
-	mgr := manager.NewManager(cfg)
+	mgr, err := manager.NewManager(cfg)
+	assert.NoError(t, err)
 	mgr.Run()
 
 	ctx, cancel := context.WithTimeout(context.Background(), utils.TestTimeout)
@@ -89,7 +89,7 @@ func verifyMonResults(t *testing.T, store measurements.Store) bool {
 	mValueAvg := make(map[string]int64)
 	for e := range ch {
 		mKey[e.Key.NodeID]++
-		for _, item := range e.Value.([]measurements.MeasurementItem) {
+		for _, item := range e.Value {
 			var avgNumUEs int64
 			for _, record := range item.MeasurementRecords {
 				switch record.MeasurementName {